@@ -16,22 +16,43 @@ import (
 
 func main() {
 	env := flag.String("env", "example", "specify the environment to use (example, development, production, etc.)")
+	autoMigrate := flag.Bool("auto-migrate", false, "apply pending database migrations before starting")
 	flag.Parse()
 
 	fmt.Println("Using environment:", *env)
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(*env, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	var migrations fx.Option = fx.Options()
+	if *autoMigrate {
+		migrations = fx.Invoke(di.RunMigrations)
+	}
+
 	app := fx.New(
 		fx.Supply(*env),
 		di.SettingManagerModule,
 		di.DefaultsModule,
 		di.CopierModule,
 		di.ConfigModule,
+		di.ConfigValidationModule,
 		di.LoggerModule,
+		di.ConfigReloadModule,
+		migrations,
+		di.TracingModule,
 		di.DatabaseModule,
+		di.AuthModule,
 		di.ApplicationModule,
 		di.HTTPModule,
+		di.GRPCModule,
+		di.WorkerModule,
+		di.MetricsModule,
 		fx.Invoke(di.StartServer),
 	)
 
@@ -41,4 +62,9 @@ func main() {
 
 	<-app.Done()
 	fmt.Println("Shutting down gracefully...")
+	stopCtx, cancel := context.WithTimeout(context.Background(), app.StopTimeout())
+	defer cancel()
+	if err := app.Stop(stopCtx); err != nil {
+		log.Println("Error stopping application:", err)
+	}
 }
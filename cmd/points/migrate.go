@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"points/internal/di"
+	"points/internal/domain/port"
+	"points/internal/infrastructure/migration"
+
+	"go.uber.org/fx"
+)
+
+const migrateUsage = "usage: points [-env <env>] migrate up | down [steps] | version | force <version>"
+
+// runMigrate handles "points migrate ..." with the embedded migrations and the
+// postgres settings of the selected environment.
+func runMigrate(env string, args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	var config port.Config
+	app := fx.New(
+		fx.Supply(env),
+		fx.NopLogger,
+		di.SettingManagerModule,
+		di.DefaultsModule,
+		di.CopierModule,
+		di.ConfigModule,
+		fx.Populate(&config),
+	)
+	if err := app.Err(); err != nil {
+		return err
+	}
+
+	migrator, err := migration.NewMigrator(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := migrator.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid down steps %q: %w", args[1], err)
+			}
+		}
+		err = migrator.Down(steps)
+	case "force":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], convErr)
+		}
+		err = migrator.Force(version)
+	case "version":
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version: %d (dirty: %t)\n", version, dirty)
+	return nil
+}
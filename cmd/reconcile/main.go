@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"points/internal/di"
+	"points/internal/usecase/reconcile"
+
+	"go.uber.org/fx"
+)
+
+func main() {
+	env := flag.String("env", "example", "specify the environment to use (example, development, production, etc.)")
+	output := flag.String("output", "", "write the JSON report to this file instead of stdout")
+	flag.Parse()
+
+	var service reconcile.ReconciliationApplicationService
+	app := fx.New(
+		fx.Supply(*env),
+		di.SettingManagerModule,
+		di.DefaultsModule,
+		di.CopierModule,
+		di.ConfigModule,
+		di.LoggerModule,
+		di.DatabaseModule,
+		di.ApplicationModule,
+		fx.Populate(&service),
+	)
+
+	if err := app.Start(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := service.Reconcile(context.Background())
+	if stopErr := app.Stop(context.Background()); stopErr != nil {
+		log.Println("Error stopping application:", stopErr)
+	}
+	if err != nil {
+		log.Fatalf("Error running reconciliation: %v", err)
+	}
+
+	if err := writeReport(report, *output); err != nil {
+		log.Fatalf("Error writing report: %v", err)
+	}
+
+	if !report.Consistent {
+		os.Exit(1)
+	}
+}
+
+func writeReport(report *reconcile.Report, path string) error {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"points/internal/domain"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationMetadata = "authorization"
+	apiKeyMetadata        = "x-api-key"
+)
+
+// AuthInterceptor authenticates the bearer token or API key sent as metadata
+// and stores the resulting principal in the context.
+func AuthInterceptor(authenticator domain.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		var (
+			principal *valueobject.Principal
+			err       error
+		)
+		if token, ok := strings.CutPrefix(firstMetadata(md, authorizationMetadata), "Bearer "); ok {
+			principal, err = authenticator.AuthenticateToken(ctx, strings.TrimSpace(token))
+		} else if key := firstMetadata(md, apiKeyMetadata); key != "" {
+			principal, err = authenticator.AuthenticateAPIKey(ctx, key)
+		} else {
+			err = apperror.Wrap(errcode.ErrUnauthorized, "authentication", errors.New("missing credentials"))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(valueobject.WithPrincipal(ctx, principal), req)
+	}
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
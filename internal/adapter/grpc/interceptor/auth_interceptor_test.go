@@ -0,0 +1,42 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	authenticator := mock.NewMockAuthenticator(ctrl)
+	service := &valueobject.Principal{Subject: "billing", Kind: valueobject.PrincipalKindService}
+	authenticator.EXPECT().AuthenticateAPIKey(gomock.Any(), "good-key").Return(service, nil).Times(1)
+
+	intercept := AuthInterceptor(authenticator)
+	info := &grpc.UnaryServerInfo{FullMethod: "/points.v1.TradeService/Transfer"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		principal, _ := valueobject.PrincipalFromContext(ctx)
+		return principal, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiKeyMetadata, "good-key"))
+	resp, err := intercept(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, service, resp)
+
+	_, err = intercept(context.Background(), nil, info, handler)
+	appErr, ok := err.(*apperror.AppError)
+	if assert.True(t, ok) {
+		assert.Equal(t, errcode.ErrUnauthorized, appErr.Code)
+	}
+}
@@ -0,0 +1,38 @@
+package interceptor
+
+import (
+	"context"
+	stdErrors "errors"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorInterceptor turns application errors into gRPC statuses whose message
+// is the errcode, matching the "status" field of the HTTP error body.
+func ErrorInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		logger.Error("Request error",
+			zap.String("method", info.FullMethod),
+			zap.String("error", err.Error()),
+		)
+
+		var appErr *apperror.AppError
+		if stdErrors.As(err, &appErr) {
+			return nil, status.Error(mapErrorCodeToGRPCCode(appErr.Code), appErr.Code.String())
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Error(codes.Internal, errcode.ErrInternal.String())
+	}
+}
@@ -0,0 +1,85 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorInterceptor_TableDriven(t *testing.T) {
+	tests := []struct {
+		name            string
+		handlerErr      error
+		expectedCode    codes.Code
+		expectedMessage string
+	}{
+		{
+			name:            "AppError",
+			handlerErr:      apperror.Wrap(errcode.ErrInsufficientBalance, "try phase", errors.New("not enough")),
+			expectedCode:    codes.FailedPrecondition,
+			expectedMessage: errcode.ErrInsufficientBalance.String(),
+		},
+		{
+			name:            "LockNotObtained",
+			handlerErr:      apperror.Wrap(errcode.ErrDistrubutedLockNotObtained, "lock", errors.New("busy")),
+			expectedCode:    codes.Unavailable,
+			expectedMessage: errcode.ErrDistrubutedLockNotObtained.String(),
+		},
+		{
+			name:            "Forbidden",
+			handlerErr:      apperror.Wrap(errcode.ErrForbidden, "authorize caller", errors.New("other account")),
+			expectedCode:    codes.PermissionDenied,
+			expectedMessage: errcode.ErrForbidden.String(),
+		},
+		{
+			name:            "StatusError",
+			handlerErr:      status.Error(codes.Canceled, "canceled"),
+			expectedCode:    codes.Canceled,
+			expectedMessage: "canceled",
+		},
+		{
+			name:            "GenericError",
+			handlerErr:      errors.New("some generic error"),
+			expectedCode:    codes.Internal,
+			expectedMessage: errcode.ErrInternal.String(),
+		},
+	}
+
+	intercept := ErrorInterceptor(zap.NewNop())
+	info := &grpc.UnaryServerInfo{FullMethod: "/points.v1.TradeService/Transfer"}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := intercept(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tc.handlerErr
+			})
+
+			assert.Nil(t, resp)
+			st, ok := status.FromError(err)
+			assert.True(t, ok, "error should carry a grpc status")
+			assert.Equal(t, tc.expectedCode, st.Code())
+			assert.Equal(t, tc.expectedMessage, st.Message())
+		})
+	}
+}
+
+func TestErrorInterceptor_PassesResponseThrough(t *testing.T) {
+	intercept := ErrorInterceptor(zap.NewNop())
+	info := &grpc.UnaryServerInfo{FullMethod: "/points.v1.TradeService/GetTrade"}
+
+	resp, err := intercept(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+}
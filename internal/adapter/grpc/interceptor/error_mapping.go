@@ -0,0 +1,86 @@
+package interceptor
+
+import (
+	"points/internal/shared/errcode"
+
+	"google.golang.org/grpc/codes"
+)
+
+func mapErrorCodeToGRPCCode(e errcode.ErrorCode) codes.Code {
+	switch e {
+	case errcode.ErrOK:
+		return codes.OK
+	case errcode.ErrInternal:
+		return codes.Internal
+	case errcode.ErrInvalidRequest:
+		return codes.InvalidArgument
+	case errcode.ErrNotFound:
+		return codes.NotFound
+	case errcode.ErrUnauthorized:
+		return codes.Unauthenticated
+	case errcode.ErrConflict:
+		return codes.AlreadyExists
+	case errcode.ErrForbidden:
+		return codes.PermissionDenied
+	case errcode.ErrUnavailable:
+		return codes.Unavailable
+	case errcode.ErrGetAccount:
+		return codes.InvalidArgument
+	case errcode.ErrCreateAccount:
+		return codes.Internal
+	case errcode.ErrAccountNotFound:
+		return codes.NotFound
+	case errcode.ErrInsufficientBalance:
+		return codes.FailedPrecondition
+	case errcode.ErrReserveBalance:
+		return codes.Internal
+	case errcode.ErrUnreserveBalance:
+		return codes.Internal
+	case errcode.ErrCreateTransaction:
+		return codes.Internal
+	case errcode.ErrGetTransaction:
+		return codes.InvalidArgument
+	case errcode.ErrUpdateTransaction:
+		return codes.Internal
+	case errcode.ErrPayloadMarshal:
+		return codes.Internal
+	case errcode.ErrCreateEvent:
+		return codes.Internal
+	case errcode.ErrTransactionExpired:
+		return codes.FailedPrecondition
+	case errcode.ErrGetEvent:
+		return codes.Internal
+	case errcode.ErrPublishEvent:
+		return codes.Internal
+	case errcode.ErrUpdateEvent:
+		return codes.Internal
+	case errcode.ErrTransactionNotFound:
+		return codes.NotFound
+	case errcode.ErrCreateLedgerEntry:
+		return codes.Internal
+	case errcode.ErrGetLedgerEntry:
+		return codes.Internal
+	case errcode.ErrCreditBalance:
+		return codes.Internal
+	case errcode.ErrDebitBalance:
+		return codes.Internal
+	case errcode.ErrAssetMismatch:
+		return codes.InvalidArgument
+	case errcode.ErrGetPointLot:
+		return codes.Internal
+	case errcode.ErrUpdatePointLot:
+		return codes.Internal
+	case errcode.ErrDistrubutedLockNotObtained:
+		return codes.Unavailable
+	case errcode.ErrDistrubutedLockAcquire:
+		return codes.Internal
+	case errcode.ErrDistrubutedLockRelease:
+		return codes.Internal
+	case errcode.ErrDistrubutedLockRenew:
+		return codes.Internal
+	case errcode.ErrStaleFencingToken:
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
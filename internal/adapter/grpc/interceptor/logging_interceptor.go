@@ -0,0 +1,29 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+func LoggerInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		startTime := time.Now()
+
+		logger.Info("Incoming request",
+			zap.String("method", info.FullMethod),
+		)
+
+		resp, err := handler(ctx, req)
+
+		logger.Info("Request completed",
+			zap.String("method", info.FullMethod),
+			zap.String("status", status.Code(err).String()),
+			zap.Duration("duration", time.Since(startTime)),
+		)
+		return resp, err
+	}
+}
@@ -0,0 +1,38 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"google.golang.org/grpc"
+)
+
+// RBACInterceptor lets a call through when the role policy grants its
+// principal the permission required maps its method to. Methods missing from
+// required are rejected. It must run after AuthInterceptor and leaves the
+// principal with its resolved permissions in the context.
+func RBACInterceptor(policy domain.AccessPolicy, required map[string]valueobject.Permission) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		principal, ok := valueobject.PrincipalFromContext(ctx)
+		if !ok {
+			return nil, apperror.Wrap(errcode.ErrUnauthorized, "authorization", errors.New("missing principal"))
+		}
+
+		permission, ok := required[info.FullMethod]
+		if !ok {
+			return nil, apperror.Wrap(errcode.ErrForbidden, "authorization", fmt.Errorf("no permission configured for %s", info.FullMethod))
+		}
+
+		resolved := principal.WithPermissions(policy.Permissions(principal))
+		if !resolved.HasPermission(permission) {
+			return nil, apperror.Wrap(errcode.ErrForbidden, "authorization", fmt.Errorf("%s lacks permission %s", principal.Subject, permission))
+		}
+
+		return handler(valueobject.WithPrincipal(ctx, resolved), req)
+	}
+}
@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRBACInterceptor(t *testing.T) {
+	user := &valueobject.Principal{Subject: "1", Kind: valueobject.PrincipalKindUser, AccountID: 1}
+	required := map[string]valueobject.Permission{
+		"/points.v1.TradeService/Transfer": valueobject.PermissionTradeWrite,
+	}
+
+	tests := []struct {
+		name         string
+		principal    *valueobject.Principal
+		method       string
+		granted      []valueobject.Permission
+		expectedCode errcode.ErrorCode
+	}{
+		{name: "granted", principal: user, method: "/points.v1.TradeService/Transfer", granted: []valueobject.Permission{valueobject.PermissionTradeWrite}},
+		{name: "missing permission", principal: user, method: "/points.v1.TradeService/Transfer", granted: []valueobject.Permission{valueobject.PermissionTradeRead}, expectedCode: errcode.ErrForbidden},
+		{name: "unmapped method", principal: user, method: "/points.v1.TradeService/Unknown", expectedCode: errcode.ErrForbidden},
+		{name: "missing principal", method: "/points.v1.TradeService/Transfer", expectedCode: errcode.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			policy := mock.NewMockAccessPolicy(ctrl)
+			policy.EXPECT().Permissions(tt.principal).Return(tt.granted).MaxTimes(1)
+
+			ctx := context.Background()
+			if tt.principal != nil {
+				ctx = valueobject.WithPrincipal(ctx, tt.principal)
+			}
+			intercept := RBACInterceptor(policy, required)
+			resp, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				principal, _ := valueobject.PrincipalFromContext(ctx)
+				return principal, nil
+			})
+
+			if tt.expectedCode != errcode.ErrOK {
+				appErr, ok := err.(*apperror.AppError)
+				if assert.True(t, ok, "expected an AppError, got %v", err) {
+					assert.Equal(t, tt.expectedCode, appErr.Code)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			got := resp.(*valueobject.Principal)
+			assert.True(t, got.HasPermission(valueobject.PermissionTradeWrite), "handler should see the resolved permissions")
+		})
+	}
+}
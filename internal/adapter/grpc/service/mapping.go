@@ -0,0 +1,71 @@
+package service
+
+import (
+	"points/internal/adapter/grpc/pb"
+	"points/internal/domain/entity"
+	"points/internal/domain/query"
+	"points/internal/domain/valueobject"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTrade(trade *entity.TradeRecords) *pb.Trade {
+	response := &pb.Trade{
+		TransactionId:     trade.TransactionID,
+		Nonce:             trade.Nonce,
+		From:              trade.FromAccountID,
+		To:                trade.ToAccountID,
+		Amount:            trade.Amount.Value().String(),
+		AssetCode:         trade.Amount.Asset().String(),
+		Type:              trade.Type().String(),
+		Status:            valueobject.TccStatus(trade.Status).String(),
+		ReasonCode:        trade.ReasonCode,
+		ExternalReference: trade.ExternalReference,
+		CreatedAt:         timestamppb.New(trade.CreatedAt),
+		UpdatedAt:         timestamppb.New(trade.UpdatedAt),
+	}
+	if trade.ExpiredAt != nil {
+		response.ExpiredAt = timestamppb.New(*trade.ExpiredAt)
+	}
+	return response
+}
+
+func newTradeDetail(detail *query.TradeDetail) *pb.TradeDetail {
+	events := make([]*pb.TradeEvent, 0, len(detail.Events))
+	for _, evt := range detail.Events {
+		events = append(events, &pb.TradeEvent{
+			Id:        evt.ID,
+			EventType: evt.EventType,
+			Payload:   evt.Payload,
+			CreatedAt: timestamppb.New(evt.CreatedAt),
+		})
+	}
+
+	return &pb.TradeDetail{
+		Trade:  newTrade(detail.Trade),
+		Events: events,
+	}
+}
+
+func newTradePage(page *query.TradePage) *pb.TradePage {
+	trades := make([]*pb.Trade, 0, len(page.Trades))
+	for _, trade := range page.Trades {
+		trades = append(trades, newTrade(trade))
+	}
+
+	return &pb.TradePage{
+		Trades:     trades,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func newAccount(account *entity.Account) *pb.Account {
+	return &pb.Account{
+		UserId:           account.UserID,
+		AssetCode:        account.Asset().String(),
+		AvailableBalance: account.AvailableBalance.Value().String(),
+		ReservedBalance:  account.ReservedBalance.Value().String(),
+		TotalBalance:     account.TotalBalance().Value().String(),
+		UpdatedAt:        timestamppb.New(account.UpdatedAt),
+	}
+}
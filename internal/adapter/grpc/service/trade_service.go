@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"points/internal/adapter/grpc/pb"
+	"points/internal/domain"
+	"points/internal/domain/command"
+	"points/internal/domain/query"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type TradeService struct {
+	pb.UnimplementedTradeServiceServer
+	TradeUsecase        domain.TradeUsecase
+	TradeQueryUsecase   domain.TradeQueryUsecase
+	AccountQueryUsecase domain.AccountQueryUsecase
+}
+
+// TradeServicePermissions maps each TradeService method to the permission a
+// caller needs, matching the HTTP route groups.
+var TradeServicePermissions = map[string]valueobject.Permission{
+	pb.TradeService_Transfer_FullMethodName:          valueobject.PermissionTradeWrite,
+	pb.TradeService_Confirm_FullMethodName:           valueobject.PermissionTradeWrite,
+	pb.TradeService_Cancel_FullMethodName:            valueobject.PermissionTradeWrite,
+	pb.TradeService_GetTrade_FullMethodName:          valueobject.PermissionTradeRead,
+	pb.TradeService_ListAccountTrades_FullMethodName: valueobject.PermissionTradeRead,
+	pb.TradeService_GetAccount_FullMethodName:        valueobject.PermissionAccountRead,
+}
+
+func NewTradeService(
+	tradeUsecase domain.TradeUsecase,
+	tradeQueryUsecase domain.TradeQueryUsecase,
+	accountQueryUsecase domain.AccountQueryUsecase,
+) *TradeService {
+	return &TradeService{
+		TradeUsecase:        tradeUsecase,
+		TradeQueryUsecase:   tradeQueryUsecase,
+		AccountQueryUsecase: accountQueryUsecase,
+	}
+}
+
+func (s *TradeService) Transfer(ctx context.Context, req *pb.TransferRequest) (*pb.Trade, error) {
+	base, err := newBaseCommand(req.GetFrom(), req.GetTo(), req.GetNonce())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetTimeoutSeconds() < 0 {
+		return nil, invalidRequest("timeout_seconds must not be negative")
+	}
+	amount, err := valueobject.NewMoneyFromString(req.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	autoConfirm := true
+	if req.AutoConfirm != nil {
+		autoConfirm = req.GetAutoConfirm()
+	}
+
+	trans, err := s.TradeUsecase.Transfer(ctx, &command.TransferCommand{
+		BaseCommand:    base,
+		Amount:         amount,
+		AssetCode:      valueobject.AssetCode(req.GetAssetCode()),
+		AutoConfirm:    autoConfirm,
+		TimeoutSeconds: req.GetTimeoutSeconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newTrade(trans), nil
+}
+
+func (s *TradeService) Confirm(ctx context.Context, req *pb.SettleRequest) (*pb.Trade, error) {
+	base, err := newBaseCommand(req.GetFrom(), req.GetTo(), req.GetNonce())
+	if err != nil {
+		return nil, err
+	}
+
+	trans, err := s.TradeUsecase.ManualConfirm(ctx, &command.ConfirmCommand{BaseCommand: base})
+	if err != nil {
+		return nil, err
+	}
+	return newTrade(trans), nil
+}
+
+func (s *TradeService) Cancel(ctx context.Context, req *pb.SettleRequest) (*pb.Trade, error) {
+	base, err := newBaseCommand(req.GetFrom(), req.GetTo(), req.GetNonce())
+	if err != nil {
+		return nil, err
+	}
+
+	trans, err := s.TradeUsecase.Cancel(ctx, &command.CancelCommand{BaseCommand: base})
+	if err != nil {
+		return nil, err
+	}
+	return newTrade(trans), nil
+}
+
+func (s *TradeService) GetTrade(ctx context.Context, req *pb.GetTradeRequest) (*pb.TradeDetail, error) {
+	if req.GetFrom() == 0 || req.GetNonce() == 0 {
+		return nil, invalidRequest("from and nonce are required")
+	}
+
+	detail, err := s.TradeQueryUsecase.GetTrade(ctx, &query.GetTradeQuery{
+		From:  req.GetFrom(),
+		Nonce: req.GetNonce(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newTradeDetail(detail), nil
+}
+
+func (s *TradeService) ListAccountTrades(ctx context.Context, req *pb.ListAccountTradesRequest) (*pb.TradePage, error) {
+	if req.GetAccountId() == 0 {
+		return nil, invalidRequest("account_id is required")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > 100 {
+		return nil, invalidRequest("limit must be between 1 and 100")
+	}
+
+	listQuery := &query.ListAccountTradesQuery{
+		AccountID: req.GetAccountId(),
+		StartTime: optionalTime(req.GetStartTime()),
+		EndTime:   optionalTime(req.GetEndTime()),
+		Cursor:    req.GetCursor(),
+		Limit:     int(req.GetLimit()),
+	}
+	if req.GetAssetCode() != "" {
+		asset, err := valueobject.NewAssetCode(req.GetAssetCode())
+		if err != nil {
+			return nil, err
+		}
+		listQuery.AssetCode = &asset
+	}
+	if req.GetStatus() != "" {
+		status, err := valueobject.ParseTccStatus(req.GetStatus())
+		if err != nil {
+			return nil, err
+		}
+		listQuery.Status = &status
+	}
+
+	page, err := s.TradeQueryUsecase.ListAccountTrades(ctx, listQuery)
+	if err != nil {
+		return nil, err
+	}
+	return newTradePage(page), nil
+}
+
+func (s *TradeService) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.Account, error) {
+	if req.GetUserId() == 0 {
+		return nil, invalidRequest("user_id is required")
+	}
+	asset, err := valueobject.NewAssetCode(req.GetAssetCode())
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.AccountQueryUsecase.GetAccount(ctx, req.GetUserId(), asset)
+	if err != nil {
+		return nil, err
+	}
+	return newAccount(account), nil
+}
+
+// newBaseCommand applies the same rules as dto.BaseRequest.
+func newBaseCommand(from, to, nonce int64) (command.BaseCommand, error) {
+	if from == 0 || to == 0 {
+		return command.BaseCommand{}, invalidRequest("from and to are required")
+	}
+	if nonce <= 0 {
+		return command.BaseCommand{}, invalidRequest("nonce must be greater than 0")
+	}
+	return command.BaseCommand{From: from, To: to, Nonce: nonce}, nil
+}
+
+func invalidRequest(msg string) error {
+	return apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", errors.New(msg))
+}
+
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"points/internal/adapter/grpc/pb"
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+	"points/internal/domain/query"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestTradeService(ctrl *gomock.Controller) (*TradeService, *mock.MockTradeUsecase, *mock.MockTradeQueryUsecase, *mock.MockAccountQueryUsecase) {
+	tradeUsecase := mock.NewMockTradeUsecase(ctrl)
+	tradeQueryUsecase := mock.NewMockTradeQueryUsecase(ctrl)
+	accountQueryUsecase := mock.NewMockAccountQueryUsecase(ctrl)
+	return NewTradeService(tradeUsecase, tradeQueryUsecase, accountQueryUsecase), tradeUsecase, tradeQueryUsecase, accountQueryUsecase
+}
+
+func newTestTradeRecord() *entity.TradeRecords {
+	return &entity.TradeRecords{
+		TransactionID: "tx-123",
+		Nonce:         12345,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
+		Status:        int32(valueobject.TccConfirmed),
+	}
+}
+
+func assertErrorCode(t *testing.T, err error, code errcode.ErrorCode) {
+	appErr, ok := err.(*apperror.AppError)
+	if assert.True(t, ok, "expected an AppError, got %v", err) {
+		assert.Equal(t, code, appErr.Code)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	testCases := []struct {
+		name                string
+		request             *pb.TransferRequest
+		expectCall          bool
+		expectedAutoConfirm bool
+		usecaseErr          error
+		expectedErr         errcode.ErrorCode
+	}{
+		{
+			name:                "auto_confirm omitted defaults to true",
+			request:             &pb.TransferRequest{From: 1, To: 2, Nonce: 12345, Amount: "100"},
+			expectCall:          true,
+			expectedAutoConfirm: true,
+		},
+		{
+			name:                "auto_confirm explicitly false",
+			request:             &pb.TransferRequest{From: 1, To: 2, Nonce: 12345, Amount: "100", AutoConfirm: proto.Bool(false)},
+			expectCall:          true,
+			expectedAutoConfirm: false,
+		},
+		{
+			name:        "missing receiver",
+			request:     &pb.TransferRequest{From: 1, Nonce: 12345, Amount: "100"},
+			expectedErr: errcode.ErrInvalidRequest,
+		},
+		{
+			name:        "non positive nonce",
+			request:     &pb.TransferRequest{From: 1, To: 2, Amount: "100"},
+			expectedErr: errcode.ErrInvalidRequest,
+		},
+		{
+			name:        "malformed amount",
+			request:     &pb.TransferRequest{From: 1, To: 2, Nonce: 12345, Amount: "abc"},
+			expectedErr: errcode.ErrInvalidRequest,
+		},
+		{
+			name:                "usecase error",
+			request:             &pb.TransferRequest{From: 1, To: 2, Nonce: 12345, Amount: "100"},
+			expectCall:          true,
+			expectedAutoConfirm: true,
+			usecaseErr:          apperror.Wrap(errcode.ErrInsufficientBalance, "try phase", nil),
+			expectedErr:         errcode.ErrInsufficientBalance,
+		},
+		{
+			name:                "caller of another account",
+			request:             &pb.TransferRequest{From: 1, To: 2, Nonce: 12345, Amount: "100"},
+			expectCall:          true,
+			expectedAutoConfirm: true,
+			usecaseErr:          apperror.Wrap(errcode.ErrForbidden, "authorize caller", nil),
+			expectedErr:         errcode.ErrForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc, tradeUsecase, _, _ := newTestTradeService(ctrl)
+
+			if tc.expectCall {
+				tradeUsecase.EXPECT().Transfer(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, cmd *command.TransferCommand) (*entity.TradeRecords, error) {
+						assert.Equal(t, command.BaseCommand{From: 1, To: 2, Nonce: 12345}, cmd.BaseCommand)
+						assert.True(t, cmd.Amount.Value().Equal(decimal.NewFromInt(100)))
+						assert.Equal(t, tc.expectedAutoConfirm, cmd.AutoConfirm)
+						if tc.usecaseErr != nil {
+							return nil, tc.usecaseErr
+						}
+						return newTestTradeRecord(), nil
+					}).Times(1)
+			}
+
+			trade, err := svc.Transfer(context.Background(), tc.request)
+			if tc.expectedErr != errcode.ErrOK {
+				assert.Nil(t, trade)
+				assertErrorCode(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "tx-123", trade.GetTransactionId())
+			assert.Equal(t, "100", trade.GetAmount())
+			assert.Equal(t, valueobject.DefaultAssetCode.String(), trade.GetAssetCode())
+			assert.Equal(t, valueobject.TccConfirmed.String(), trade.GetStatus())
+		})
+	}
+}
+
+func TestConfirmAndCancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, tradeUsecase, _, _ := newTestTradeService(ctrl)
+	base := command.BaseCommand{From: 1, To: 2, Nonce: 12345}
+
+	tradeUsecase.EXPECT().ManualConfirm(gomock.Any(), &command.ConfirmCommand{BaseCommand: base}).Return(newTestTradeRecord(), nil).Times(1)
+	tradeUsecase.EXPECT().Cancel(gomock.Any(), &command.CancelCommand{BaseCommand: base}).
+		Return(nil, apperror.Wrap(errcode.ErrTransactionNotFound, "cancel phase", nil)).Times(1)
+
+	trade, err := svc.Confirm(context.Background(), &pb.SettleRequest{From: 1, To: 2, Nonce: 12345})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12345), trade.GetNonce())
+
+	_, err = svc.Cancel(context.Background(), &pb.SettleRequest{From: 1, To: 2, Nonce: 12345})
+	assertErrorCode(t, err, errcode.ErrTransactionNotFound)
+
+	_, err = svc.Confirm(context.Background(), &pb.SettleRequest{From: 1, Nonce: 12345})
+	assertErrorCode(t, err, errcode.ErrInvalidRequest)
+}
+
+func TestGetTrade(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, _, tradeQueryUsecase, _ := newTestTradeService(ctrl)
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tradeQueryUsecase.EXPECT().GetTrade(gomock.Any(), &query.GetTradeQuery{From: 1, Nonce: 12345}).Return(&query.TradeDetail{
+		Trade: newTestTradeRecord(),
+		Events: []*entity.TransactionEvent{
+			{ID: 7, EventType: "confirmed", Payload: `{"nonce":12345}`, CreatedAt: createdAt},
+		},
+	}, nil).Times(1)
+
+	detail, err := svc.GetTrade(context.Background(), &pb.GetTradeRequest{From: 1, Nonce: 12345})
+	assert.NoError(t, err)
+	assert.Equal(t, "tx-123", detail.GetTrade().GetTransactionId())
+	assert.Len(t, detail.GetEvents(), 1)
+	assert.Equal(t, int32(7), detail.GetEvents()[0].GetId())
+	assert.Equal(t, createdAt, detail.GetEvents()[0].GetCreatedAt().AsTime())
+}
+
+func TestListAccountTrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, _, tradeQueryUsecase, _ := newTestTradeService(ctrl)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tradeQueryUsecase.EXPECT().ListAccountTrades(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req *query.ListAccountTradesQuery) (*query.TradePage, error) {
+			assert.Equal(t, int64(1), req.AccountID)
+			assert.Equal(t, valueobject.AssetCode("CASHBACK"), *req.AssetCode)
+			assert.Equal(t, valueobject.TccPending, *req.Status)
+			assert.Equal(t, start, *req.StartTime)
+			assert.Nil(t, req.EndTime)
+			assert.Equal(t, 10, req.Limit)
+			return &query.TradePage{Trades: []*entity.TradeRecords{newTestTradeRecord()}, NextCursor: "next"}, nil
+		}).Times(1)
+
+	page, err := svc.ListAccountTrades(context.Background(), &pb.ListAccountTradesRequest{
+		AccountId: 1,
+		AssetCode: "cashback",
+		Status:    "pending",
+		StartTime: timestamppb.New(start),
+		Limit:     10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, page.GetTrades(), 1)
+	assert.Equal(t, "next", page.GetNextCursor())
+
+	_, err = svc.ListAccountTrades(context.Background(), &pb.ListAccountTradesRequest{AccountId: 1, Limit: 101})
+	assertErrorCode(t, err, errcode.ErrInvalidRequest)
+}
+
+func TestGetAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	svc, _, _, accountQueryUsecase := newTestTradeService(ctrl)
+
+	accountQueryUsecase.EXPECT().GetAccount(gomock.Any(), int64(1), valueobject.DefaultAssetCode).Return(&entity.Account{
+		UserID:           1,
+		AvailableBalance: valueobject.NewMoneyFromDecimal(decimal.NewFromInt(80)),
+		ReservedBalance:  valueobject.NewMoneyFromDecimal(decimal.NewFromInt(20)),
+	}, nil).Times(1)
+
+	account, err := svc.GetAccount(context.Background(), &pb.GetAccountRequest{UserId: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, valueobject.DefaultAssetCode.String(), account.GetAssetCode())
+	assert.Equal(t, "80", account.GetAvailableBalance())
+	assert.Equal(t, "20", account.GetReservedBalance())
+	assert.Equal(t, "100", account.GetTotalBalance())
+}
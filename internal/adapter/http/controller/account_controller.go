@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"net/http"
+	"points/internal/adapter/http/dto"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/internal/shared/mapper"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountController struct {
+	AccountQueryUsecase domain.AccountQueryUsecase
+	config              port.Config
+}
+
+func NewAccountController(usecase domain.AccountQueryUsecase, config port.Config) *AccountController {
+	return &AccountController{
+		AccountQueryUsecase: usecase,
+		config:              config,
+	}
+}
+
+func (h *AccountController) GetAccount(c *gin.Context) {
+	var request dto.GetAccountRequest
+
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", err))
+		return
+	}
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", err))
+		return
+	}
+
+	asset, err := valueobject.NewAssetCode(request.AssetCode)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	account, err := h.AccountQueryUsecase.GetAccount(c, request.UserID, asset)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response, err := mapper.MapStruct[dto.AccountResponse](h.config, account)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response.TotalBalance = account.TotalBalance().Value()
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(response))
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"points/internal/adapter/http/dto"
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAccountHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	updatedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	account := &entity.Account{
+		UserID:           1,
+		AvailableBalance: valueobject.NewMoneyFromDecimal(decimal.NewFromInt(80)),
+		ReservedBalance:  valueobject.NewMoneyFromDecimal(decimal.NewFromInt(20)),
+		UpdatedAt:        updatedAt,
+	}
+
+	testCases := []struct {
+		name                string
+		path                string
+		asset               valueobject.AssetCode
+		usecaseAccount      *entity.Account
+		usecaseErr          error
+		expectUsecaseCall   bool
+		expectedHTTPStatus  int
+		expectedResponseStr []string
+	}{
+		{
+			name:               "Success",
+			path:               "/accounts/1",
+			usecaseAccount:     account,
+			expectUsecaseCall:  true,
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponseStr: []string{
+				errcode.ErrOK.String(),
+				`"available_balance":"80"`,
+				`"reserved_balance":"20"`,
+				`"total_balance":"100"`,
+				`"updated_at":"2025-01-02T03:04:05Z"`,
+			},
+		},
+		{
+			name:                "Asset Query",
+			path:                "/accounts/1?asset_code=cashback",
+			asset:               "CASHBACK",
+			usecaseAccount:      account,
+			expectUsecaseCall:   true,
+			expectedHTTPStatus:  http.StatusOK,
+			expectedResponseStr: []string{errcode.ErrOK.String()},
+		},
+		{
+			name:                "Invalid Asset",
+			path:                "/accounts/1?asset_code=cash-back",
+			expectUsecaseCall:   false,
+			expectedHTTPStatus:  http.StatusBadRequest,
+			expectedResponseStr: []string{errcode.ErrInvalidRequest.String()},
+		},
+		{
+			name:                "Account Of Another User",
+			path:                "/accounts/1",
+			usecaseErr:          apperror.Wrap(errcode.ErrForbidden, "authorize caller", nil),
+			expectUsecaseCall:   true,
+			expectedHTTPStatus:  http.StatusForbidden,
+			expectedResponseStr: []string{errcode.ErrForbidden.String()},
+		},
+		{
+			name:                "Validation Error",
+			path:                "/accounts/abc",
+			expectUsecaseCall:   false,
+			expectedHTTPStatus:  http.StatusBadRequest,
+			expectedResponseStr: []string{errcode.ErrInvalidRequest.String()},
+		},
+		{
+			name:                "Account Not Found",
+			path:                "/accounts/1",
+			usecaseErr:          apperror.Wrap(errcode.ErrAccountNotFound, "get account", nil),
+			expectUsecaseCall:   true,
+			expectedHTTPStatus:  http.StatusNotFound,
+			expectedResponseStr: []string{errcode.ErrAccountNotFound.String()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUsecase := mock.NewMockAccountQueryUsecase(ctrl)
+			mockConfig := mock.NewMockConfig(ctrl)
+			accountController := NewAccountController(mockUsecase, mockConfig)
+			router, _ := setupRouter("/accounts/:id", http.MethodGet, accountController.GetAccount)
+
+			if tc.expectUsecaseCall {
+				mockUsecase.EXPECT().GetAccount(gomock.Any(), int64(1), tc.asset.OrDefault()).Return(tc.usecaseAccount, tc.usecaseErr).Times(1)
+			}
+			mockConfig.EXPECT().
+				Copy(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(dest interface{}, src interface{}) error {
+					if d, ok := dest.(*dto.AccountResponse); ok {
+						*d = dto.AccountResponse{
+							UserID:           account.UserID,
+							AvailableBalance: account.AvailableBalance.Value(),
+							ReservedBalance:  account.ReservedBalance.Value(),
+							UpdatedAt:        account.UpdatedAt,
+						}
+					}
+					return nil
+				}).AnyTimes()
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for _, expected := range tc.expectedResponseStr {
+				assert.Contains(t, rr.Body.String(), expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+	"points/internal/adapter/http/dto"
+	"points/internal/domain"
+	"points/internal/domain/command"
+	"points/internal/domain/port"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/internal/shared/mapper"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AdminController struct {
+	AdminUsecase domain.AdminUsecase
+	config       port.Config
+}
+
+func NewAdminController(usecase domain.AdminUsecase, config port.Config) *AdminController {
+	return &AdminController{
+		AdminUsecase: usecase,
+		config:       config,
+	}
+}
+
+func (h *AdminController) Mint(c *gin.Context) {
+	var request dto.MintRequest
+
+	if err := c.ShouldBind(&request); err != nil {
+		c.Error(apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", err))
+		return
+	}
+
+	cmd, err := mapper.MapStruct[command.MintCommand](h.config, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	trans, err := h.AdminUsecase.Mint(c, cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewTradeResponse(trans)))
+}
+
+func (h *AdminController) Burn(c *gin.Context) {
+	var request dto.BurnRequest
+
+	if err := c.ShouldBind(&request); err != nil {
+		c.Error(apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", err))
+		return
+	}
+
+	cmd, err := mapper.MapStruct[command.BurnCommand](h.config, &request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	trans, err := h.AdminUsecase.Burn(c, cmd)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewTradeResponse(trans)))
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAdminController(ctrl *gomock.Controller) (*AdminController, *mock.MockAdminUsecase) {
+	mockUsecase := mock.NewMockAdminUsecase(ctrl)
+	mockConfig := mock.NewMockConfig(ctrl)
+	copier := infrastructure.NewCopierImpl()
+	mockConfig.EXPECT().Copy(gomock.Any(), gomock.Any()).DoAndReturn(copier.Copy).AnyTimes()
+	return NewAdminController(mockUsecase, mockConfig), mockUsecase
+}
+
+func TestMintHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expectedCmd := &command.MintCommand{
+		AdjustmentCommand: command.AdjustmentCommand{
+			AccountID:         1,
+			Nonce:             7,
+			Amount:            valueobject.NewMoneyFromDecimal(decimal.NewFromInt(500)),
+			AssetCode:         "CASHBACK",
+			ReasonCode:        "campaign",
+			ExternalReference: "ticket-42",
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		requestBody         string
+		expectUsecaseCall   bool
+		usecaseErr          error
+		expectedHTTPStatus  int
+		expectedResponseStr []string
+	}{
+		{
+			name:               "Success",
+			requestBody:        `{"account_id": 1, "nonce": 7, "amount": 500, "asset_code": "CASHBACK", "reason_code": "campaign", "external_reference": "ticket-42"}`,
+			expectUsecaseCall:  true,
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponseStr: []string{
+				errcode.ErrOK.String(),
+				`"type":"mint"`,
+				`"asset_code":"CASHBACK"`,
+				`"reason_code":"campaign"`,
+				`"external_reference":"ticket-42"`,
+			},
+		},
+		{
+			name:                "Missing Reason Code",
+			requestBody:         `{"account_id": 1, "nonce": 7, "amount": 500}`,
+			expectedHTTPStatus:  http.StatusBadRequest,
+			expectedResponseStr: []string{errcode.ErrInvalidRequest.String()},
+		},
+		{
+			name:                "Nonce Conflict",
+			requestBody:         `{"account_id": 1, "nonce": 7, "amount": 500, "asset_code": "CASHBACK", "reason_code": "campaign", "external_reference": "ticket-42"}`,
+			expectUsecaseCall:   true,
+			usecaseErr:          apperror.Wrap(errcode.ErrConflict, "mint phase - conflict nonce", nil),
+			expectedHTTPStatus:  http.StatusConflict,
+			expectedResponseStr: []string{errcode.ErrConflict.String()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			adminController, mockUsecase := newTestAdminController(ctrl)
+			router, _ := setupRouter("/admin/mint", http.MethodPost, adminController.Mint)
+
+			if tc.expectUsecaseCall {
+				var trans *entity.TradeRecords
+				if tc.usecaseErr == nil {
+					trans = &entity.TradeRecords{
+						TransactionID:     "tx-mint",
+						Nonce:             7,
+						FromAccountID:     valueobject.SystemAccountID,
+						ToAccountID:       1,
+						Amount:            expectedCmd.Amount.WithAsset(expectedCmd.AssetCode),
+						Status:            int32(valueobject.TccConfirmed),
+						TradeType:         valueobject.TradeTypeMint,
+						ReasonCode:        "campaign",
+						ExternalReference: "ticket-42",
+					}
+				}
+				mockUsecase.EXPECT().Mint(gomock.Any(), expectedCmd).Return(trans, tc.usecaseErr).Times(1)
+			}
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/mint", strings.NewReader(tc.requestBody))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for _, expected := range tc.expectedResponseStr {
+				assert.Contains(t, rr.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestBurnHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	adminController, mockUsecase := newTestAdminController(ctrl)
+	router, _ := setupRouter("/admin/burn", http.MethodPost, adminController.Burn)
+
+	expectedCmd := &command.BurnCommand{
+		AdjustmentCommand: command.AdjustmentCommand{
+			AccountID:  1,
+			Nonce:      8,
+			Amount:     valueobject.NewMoneyFromDecimal(decimal.NewFromInt(2000)),
+			ReasonCode: "fraud",
+		},
+	}
+	mockUsecase.EXPECT().Burn(gomock.Any(), expectedCmd).
+		Return(nil, apperror.Wrap(errcode.ErrInsufficientBalance, "insufficient balance", nil)).Times(1)
+
+	req, err := http.NewRequest(http.MethodPost, "/admin/burn", strings.NewReader(`{"account_id": 1, "nonce": 8, "amount": 2000, "reason_code": "fraud"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.NotEqual(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), errcode.ErrInsufficientBalance.String())
+}
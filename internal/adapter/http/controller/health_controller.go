@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"points/internal/adapter/http/dto"
+	"points/internal/domain"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthController struct {
+	checkers []domain.HealthChecker
+	timeout  time.Duration
+}
+
+func NewHealthController(timeout time.Duration, checkers ...domain.HealthChecker) *HealthController {
+	return &HealthController{
+		checkers: checkers,
+		timeout:  timeout,
+	}
+}
+
+// Healthz reports that the process is alive without touching dependencies.
+func (h *HealthController) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.HealthResponse{Status: dto.HealthStatusOK})
+}
+
+// Readyz runs every dependency check and answers 503 when any of them fails.
+func (h *HealthController) Readyz(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c, h.timeout)
+	defer cancel()
+
+	results := make([]dto.DependencyCheck, len(h.checkers))
+	var wg sync.WaitGroup
+	for i, checker := range h.checkers {
+		wg.Add(1)
+		go func(i int, checker domain.HealthChecker) {
+			defer wg.Done()
+			results[i] = dto.DependencyCheck{Status: dto.HealthStatusOK}
+			if err := checker.Check(ctx); err != nil {
+				results[i] = dto.DependencyCheck{Status: dto.HealthStatusUnavailable, Error: err.Error()}
+			}
+		}(i, checker)
+	}
+	wg.Wait()
+
+	response := dto.HealthResponse{Status: dto.HealthStatusOK, Checks: make(map[string]dto.DependencyCheck, len(h.checkers))}
+	status := http.StatusOK
+	for i, checker := range h.checkers {
+		response.Checks[checker.Name()] = results[i]
+		if results[i].Status != dto.HealthStatusOK {
+			response.Status = dto.HealthStatusUnavailable
+			status = http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(status, response)
+}
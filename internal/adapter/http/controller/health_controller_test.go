@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthController_Healthz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	checker := mock.NewMockHealthChecker(ctrl)
+	checker.EXPECT().Check(gomock.Any()).Times(0)
+
+	healthController := NewHealthController(time.Second, checker)
+	router, _ := setupRouter("/healthz", http.MethodGet, healthController.Healthz)
+
+	req, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+}
+
+func TestHealthController_Readyz(t *testing.T) {
+	testCases := []struct {
+		name               string
+		redisErr           error
+		expectedHTTPStatus int
+		expectedBody       string
+	}{
+		{
+			name:               "All Dependencies Ready",
+			expectedHTTPStatus: http.StatusOK,
+			expectedBody:       `{"status":"ok","checks":{"postgres":{"status":"ok"},"redis":{"status":"ok"}}}`,
+		},
+		{
+			name:               "Redis Down",
+			redisErr:           errors.New("connection refused"),
+			expectedHTTPStatus: http.StatusServiceUnavailable,
+			expectedBody:       `{"status":"unavailable","checks":{"postgres":{"status":"ok"},"redis":{"status":"unavailable","error":"connection refused"}}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			postgres := mock.NewMockHealthChecker(ctrl)
+			postgres.EXPECT().Name().Return("postgres").AnyTimes()
+			postgres.EXPECT().Check(gomock.Any()).Return(nil).Times(1)
+			redis := mock.NewMockHealthChecker(ctrl)
+			redis.EXPECT().Name().Return("redis").AnyTimes()
+			redis.EXPECT().Check(gomock.Any()).Return(tc.redisErr).Times(1)
+
+			healthController := NewHealthController(time.Second, postgres, redis)
+			router, _ := setupRouter("/readyz", http.MethodGet, healthController.Readyz)
+
+			req, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+			assert.NoError(t, err)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			assert.JSONEq(t, tc.expectedBody, rr.Body.String())
+		})
+	}
+}
@@ -40,12 +40,13 @@ func (h *TradeController) Transfer(c *gin.Context) {
 		return
 	}
 
-	if err := h.TradeUsecase.Transfer(c, cmd); err != nil {
+	trans, err := h.TradeUsecase.Transfer(c, cmd)
+	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.NewSuccessResponse())
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewTradeResponse(trans)))
 }
 
 func (h *TradeController) Confirm(c *gin.Context) {
@@ -61,12 +62,13 @@ func (h *TradeController) Confirm(c *gin.Context) {
 		c.Error(err)
 		return
 	}
-	if err := h.TradeUsecase.ManualConfirm(c, cmd); err != nil {
+	trans, err := h.TradeUsecase.ManualConfirm(c, cmd)
+	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.NewSuccessResponse())
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewTradeResponse(trans)))
 }
 
 func (h *TradeController) Cancel(c *gin.Context) {
@@ -82,10 +84,11 @@ func (h *TradeController) Cancel(c *gin.Context) {
 		c.Error(err)
 		return
 	}
-	if err := h.TradeUsecase.Cancel(c, cmd); err != nil {
+	trans, err := h.TradeUsecase.Cancel(c, cmd)
+	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.NewSuccessResponse())
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewTradeResponse(trans)))
 }
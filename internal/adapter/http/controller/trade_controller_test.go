@@ -9,6 +9,7 @@ import (
 
 	"points/internal/adapter/http/middleware"
 	"points/internal/domain/command"
+	"points/internal/domain/entity"
 	"points/internal/domain/valueobject"
 	"points/internal/shared/apperror"
 	"points/internal/shared/errcode"
@@ -44,6 +45,20 @@ func newTestTradeController(ctrl *gomock.Controller) *TradeController {
 	return NewTradeController(mockTradeUsecase, mockConfig)
 }
 
+func newTestTradeRecord(err error) *entity.TradeRecords {
+	if err != nil {
+		return nil
+	}
+	return &entity.TradeRecords{
+		TransactionID: "tx-123",
+		Nonce:         12345,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
+		Status:        int32(valueobject.TccConfirmed),
+	}
+}
+
 func newExpectedTransferCommand(autoConfirm bool) *command.TransferCommand {
 	return &command.TransferCommand{
 		BaseCommand: command.BaseCommand{
@@ -131,6 +146,20 @@ func TestTransferHandler(t *testing.T) {
 			expectedHTTPStatus:  http.StatusInternalServerError,
 			expectedResponseStr: errcode.ErrInternal.String(),
 		},
+		{
+			name: "Caller Of Another Account",
+			requestBody: `{
+				"from": 1,
+				"to": 2,
+				"nonce": 12345,
+				"amount": 100.0,
+				"auto_confirm": true
+			}`,
+			expectedAutoConfirm: true,
+			tradeUsecaseErr:     apperror.Wrap(errcode.ErrForbidden, "authorize caller", nil),
+			expectedHTTPStatus:  http.StatusForbidden,
+			expectedResponseStr: errcode.ErrForbidden.String(),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -151,7 +180,7 @@ func TestTransferHandler(t *testing.T) {
 				}).AnyTimes()
 			mockTradeUsecase.EXPECT().
 				Transfer(gomock.Any(), newExpectedTransferCommand(tc.expectedAutoConfirm)).
-				Return(tc.tradeUsecaseErr).AnyTimes()
+				Return(newTestTradeRecord(tc.tradeUsecaseErr), tc.tradeUsecaseErr).AnyTimes()
 
 			req, err := http.NewRequest("POST", "/transfer", strings.NewReader(tc.requestBody))
 			assert.NoError(t, err)
@@ -162,6 +191,9 @@ func TestTransferHandler(t *testing.T) {
 
 			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
 			assert.Contains(t, rr.Body.String(), tc.expectedResponseStr)
+			if tc.expectedHTTPStatus == http.StatusOK {
+				assert.Contains(t, rr.Body.String(), `"transaction_id":"tx-123"`)
+			}
 		})
 	}
 }
@@ -233,7 +265,7 @@ func TestConfirmHandler(t *testing.T) {
 				}).AnyTimes()
 			mockTradeUsecase.EXPECT().
 				ManualConfirm(gomock.Any(), expectedCmd).
-				Return(tc.confirmErr).AnyTimes()
+				Return(newTestTradeRecord(tc.confirmErr), tc.confirmErr).AnyTimes()
 
 			req, err := http.NewRequest("POST", "/confirm", strings.NewReader(tc.requestBody))
 			assert.NoError(t, err)
@@ -315,7 +347,7 @@ func TestCancelHandler(t *testing.T) {
 				}).AnyTimes()
 			mockTradeUsecase.EXPECT().
 				Cancel(gomock.Any(), expectedCmd).
-				Return(tc.cancelErr).AnyTimes()
+				Return(newTestTradeRecord(tc.cancelErr), tc.cancelErr).AnyTimes()
 
 			req, err := http.NewRequest("POST", "/cancel", strings.NewReader(tc.requestBody))
 			assert.NoError(t, err)
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"points/internal/adapter/http/dto"
+	"points/internal/domain"
+	"points/internal/domain/query"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TradeQueryController struct {
+	TradeQueryUsecase domain.TradeQueryUsecase
+}
+
+func NewTradeQueryController(usecase domain.TradeQueryUsecase) *TradeQueryController {
+	return &TradeQueryController{
+		TradeQueryUsecase: usecase,
+	}
+}
+
+func (h *TradeQueryController) GetTrade(c *gin.Context) {
+	var request dto.GetTradeRequest
+
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", err))
+		return
+	}
+
+	detail, err := h.TradeQueryUsecase.GetTrade(c, &query.GetTradeQuery{
+		From:  request.From,
+		Nonce: request.Nonce,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewTradeDetailResponse(detail)))
+}
+
+func (h *TradeQueryController) ListAccountTrades(c *gin.Context) {
+	var request dto.ListAccountTradesRequest
+
+	if err := c.ShouldBindUri(&request); err != nil {
+		c.Error(apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", err))
+		return
+	}
+	if err := c.ShouldBindQuery(&request); err != nil {
+		c.Error(apperror.Wrap(errcode.ErrInvalidRequest, "invalid request", err))
+		return
+	}
+
+	listQuery := &query.ListAccountTradesQuery{
+		AccountID: request.UserID,
+		StartTime: request.StartTime,
+		EndTime:   request.EndTime,
+		Cursor:    request.Cursor,
+		Limit:     request.Limit,
+	}
+	if request.AssetCode != "" {
+		asset, err := valueobject.NewAssetCode(request.AssetCode)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		listQuery.AssetCode = &asset
+	}
+	if request.Status != "" {
+		status, err := valueobject.ParseTccStatus(request.Status)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		listQuery.Status = &status
+	}
+
+	page, err := h.TradeQueryUsecase.ListAccountTrades(c, listQuery)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewDataResponse(dto.NewTradePageResponse(page)))
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"points/internal/domain/entity"
+	"points/internal/domain/query"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDummyTrade() *entity.TradeRecords {
+	return &entity.TradeRecords{
+		TransactionID: "tx-123",
+		Nonce:         12345,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
+		Status:        int32(valueobject.TccConfirmed),
+		CreatedAt:     time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestGetTradeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCases := []struct {
+		name                string
+		path                string
+		expectUsecaseCall   bool
+		usecaseErr          error
+		expectedHTTPStatus  int
+		expectedResponseStr []string
+	}{
+		{
+			name:               "Success",
+			path:               "/trade/1/12345",
+			expectUsecaseCall:  true,
+			expectedHTTPStatus: http.StatusOK,
+			expectedResponseStr: []string{
+				`"transaction_id":"tx-123"`,
+				`"status":"confirmed"`,
+				`"event_type":"pending"`,
+				`"payload":{"Action":"pending"}`,
+			},
+		},
+		{
+			name:                "Validation Error",
+			path:                "/trade/1/abc",
+			expectedHTTPStatus:  http.StatusBadRequest,
+			expectedResponseStr: []string{errcode.ErrInvalidRequest.String()},
+		},
+		{
+			name:                "Not Found",
+			path:                "/trade/1/12345",
+			expectUsecaseCall:   true,
+			usecaseErr:          apperror.Wrap(errcode.ErrTransactionNotFound, "get trade", nil),
+			expectedHTTPStatus:  http.StatusNotFound,
+			expectedResponseStr: []string{errcode.ErrTransactionNotFound.String()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUsecase := mock.NewMockTradeQueryUsecase(ctrl)
+			tradeQueryController := NewTradeQueryController(mockUsecase)
+			router, _ := setupRouter("/trade/:from/:nonce", http.MethodGet, tradeQueryController.GetTrade)
+
+			if tc.expectUsecaseCall {
+				var detail *query.TradeDetail
+				if tc.usecaseErr == nil {
+					detail = &query.TradeDetail{
+						Trade: newDummyTrade(),
+						Events: []*entity.TransactionEvent{
+							{ID: 1, TransactionID: "tx-123", EventType: "pending", Payload: `{"Action":"pending"}`},
+						},
+					}
+				}
+				mockUsecase.EXPECT().GetTrade(gomock.Any(), &query.GetTradeQuery{From: 1, Nonce: 12345}).Return(detail, tc.usecaseErr).Times(1)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for _, expected := range tc.expectedResponseStr {
+				assert.Contains(t, rr.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestListAccountTradesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	confirmed := valueobject.TccConfirmed
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name                string
+		path                string
+		expectedQuery       *query.ListAccountTradesQuery
+		expectedHTTPStatus  int
+		expectedResponseStr []string
+	}{
+		{
+			name: "Success with filters",
+			path: "/accounts/1/trades?status=confirmed&start_time=2025-01-01T00:00:00Z&limit=1&cursor=abc",
+			expectedQuery: &query.ListAccountTradesQuery{
+				AccountID: 1,
+				Status:    &confirmed,
+				StartTime: &startTime,
+				Cursor:    "abc",
+				Limit:     1,
+			},
+			expectedHTTPStatus:  http.StatusOK,
+			expectedResponseStr: []string{`"transaction_id":"tx-123"`, `"next_cursor":"next"`},
+		},
+		{
+			name:                "Invalid status",
+			path:                "/accounts/1/trades?status=unknown",
+			expectedHTTPStatus:  http.StatusBadRequest,
+			expectedResponseStr: []string{errcode.ErrInvalidRequest.String()},
+		},
+		{
+			name:                "Limit too large",
+			path:                "/accounts/1/trades?limit=1000",
+			expectedHTTPStatus:  http.StatusBadRequest,
+			expectedResponseStr: []string{errcode.ErrInvalidRequest.String()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockUsecase := mock.NewMockTradeQueryUsecase(ctrl)
+			tradeQueryController := NewTradeQueryController(mockUsecase)
+			router, _ := setupRouter("/accounts/:id/trades", http.MethodGet, tradeQueryController.ListAccountTrades)
+
+			if tc.expectedQuery != nil {
+				mockUsecase.EXPECT().ListAccountTrades(gomock.Any(), tc.expectedQuery).Return(&query.TradePage{
+					Trades:     []*entity.TradeRecords{newDummyTrade()},
+					NextCursor: "next",
+				}, nil).Times(1)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expectedHTTPStatus, rr.Code)
+			for _, expected := range tc.expectedResponseStr {
+				assert.Contains(t, rr.Body.String(), expected)
+			}
+		})
+	}
+}
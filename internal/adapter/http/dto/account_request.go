@@ -0,0 +1,6 @@
+package dto
+
+type GetAccountRequest struct {
+	UserID    int64  `uri:"id" binding:"required"`
+	AssetCode string `form:"asset_code" binding:"omitempty,max=16"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type AccountResponse struct {
+	UserID           int64           `json:"user_id"`
+	AssetCode        string          `json:"asset_code"`
+	AvailableBalance decimal.Decimal `json:"available_balance"`
+	ReservedBalance  decimal.Decimal `json:"reserved_balance"`
+	TotalBalance     decimal.Decimal `json:"total_balance"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
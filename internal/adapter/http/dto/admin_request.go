@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/shopspring/decimal"
+
+type AdjustmentRequest struct {
+	AccountID         int64           `json:"account_id" form:"account_id" binding:"required"`
+	Nonce             int64           `json:"nonce" form:"nonce" binding:"required,gt=0"`
+	Amount            decimal.Decimal `json:"amount" form:"amount" binding:"required"`
+	AssetCode         string          `json:"asset_code" form:"asset_code" binding:"omitempty,max=16"`
+	ReasonCode        string          `json:"reason_code" form:"reason_code" binding:"required,max=50"`
+	ExternalReference string          `json:"external_reference" form:"external_reference" binding:"max=255"`
+}
+
+type MintRequest struct {
+	AdjustmentRequest
+}
+
+type BurnRequest struct {
+	AdjustmentRequest
+}
@@ -20,3 +20,15 @@ func NewSuccessResponse() *BaseResponse {
 		Message: errcode.ErrOK.GetMessage(),
 	}
 }
+
+type DataResponse[T any] struct {
+	BaseResponse
+	Data T `json:"data"`
+}
+
+func NewDataResponse[T any](data T) *DataResponse[T] {
+	return &DataResponse[T]{
+		BaseResponse: *NewSuccessResponse(),
+		Data:         data,
+	}
+}
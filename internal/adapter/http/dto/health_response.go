@@ -0,0 +1,16 @@
+package dto
+
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+type HealthResponse struct {
+	Status string                     `json:"status"`
+	Checks map[string]DependencyCheck `json:"checks,omitempty"`
+}
+
+type DependencyCheck struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
@@ -1,17 +1,23 @@
 package dto
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type BaseRequest struct {
 	From  int64 `json:"from" form:"from" binding:"required"`
 	To    int64 `json:"to" form:"to" binding:"required"`
-	Nonce int64 `json:"nonce" form:"nonce" binding:"required"`
+	Nonce int64 `json:"nonce" form:"nonce" binding:"required,gt=0"`
 }
 
 type TransferRequest struct {
 	BaseRequest
-	Amount      decimal.Decimal `json:"amount" form:"amount" binding:"required"`
-	AutoConfirm *bool           `json:"auto_confirm" form:"auto_confirm" default:"true"`
+	Amount         decimal.Decimal `json:"amount" form:"amount" binding:"required"`
+	AssetCode      string          `json:"asset_code" form:"asset_code" binding:"omitempty,max=16"`
+	AutoConfirm    *bool           `json:"auto_confirm" form:"auto_confirm" default:"true"`
+	TimeoutSeconds int64           `json:"timeout_seconds" form:"timeout_seconds" binding:"omitempty,min=0"`
 }
 
 type ConfirmRequest struct {
@@ -21,3 +27,18 @@ type ConfirmRequest struct {
 type CancelRequest struct {
 	BaseRequest
 }
+
+type GetTradeRequest struct {
+	From  int64 `uri:"from" binding:"required"`
+	Nonce int64 `uri:"nonce" binding:"required"`
+}
+
+type ListAccountTradesRequest struct {
+	UserID    int64      `uri:"id" binding:"required"`
+	AssetCode string     `form:"asset_code" binding:"omitempty,max=16"`
+	Status    string     `form:"status" binding:"omitempty,oneof=pending confirmed canceled"`
+	StartTime *time.Time `form:"start_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndTime   *time.Time `form:"end_time" time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor    string     `form:"cursor"`
+	Limit     int        `form:"limit" binding:"omitempty,min=1,max=100"`
+}
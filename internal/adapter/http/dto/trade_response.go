@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"encoding/json"
+	"points/internal/domain/entity"
+	"points/internal/domain/query"
+	"points/internal/domain/valueobject"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type TradeResponse struct {
+	TransactionID     string          `json:"transaction_id"`
+	Nonce             int64           `json:"nonce"`
+	From              int64           `json:"from"`
+	To                int64           `json:"to"`
+	Amount            decimal.Decimal `json:"amount"`
+	AssetCode         string          `json:"asset_code"`
+	Type              string          `json:"type"`
+	Status            string          `json:"status"`
+	ReasonCode        string          `json:"reason_code,omitempty"`
+	ExternalReference string          `json:"external_reference,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	ExpiredAt         *time.Time      `json:"expired_at,omitempty"`
+}
+
+type TradeEventResponse struct {
+	ID        int32           `json:"id"`
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type TradeDetailResponse struct {
+	TradeResponse
+	Events []TradeEventResponse `json:"events"`
+}
+
+type TradePageResponse struct {
+	Trades     []TradeResponse `json:"trades"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+func NewTradeResponse(trade *entity.TradeRecords) TradeResponse {
+	return TradeResponse{
+		TransactionID:     trade.TransactionID,
+		Nonce:             trade.Nonce,
+		From:              trade.FromAccountID,
+		To:                trade.ToAccountID,
+		Amount:            trade.Amount.Value(),
+		AssetCode:         trade.Amount.Asset().String(),
+		Type:              trade.Type().String(),
+		Status:            valueobject.TccStatus(trade.Status).String(),
+		ReasonCode:        trade.ReasonCode,
+		ExternalReference: trade.ExternalReference,
+		CreatedAt:         trade.CreatedAt,
+		UpdatedAt:         trade.UpdatedAt,
+		ExpiredAt:         trade.ExpiredAt,
+	}
+}
+
+func NewTradeDetailResponse(detail *query.TradeDetail) TradeDetailResponse {
+	events := make([]TradeEventResponse, 0, len(detail.Events))
+	for _, evt := range detail.Events {
+		events = append(events, TradeEventResponse{
+			ID:        evt.ID,
+			EventType: evt.EventType,
+			Payload:   json.RawMessage(evt.Payload),
+			CreatedAt: evt.CreatedAt,
+		})
+	}
+
+	return TradeDetailResponse{
+		TradeResponse: NewTradeResponse(detail.Trade),
+		Events:        events,
+	}
+}
+
+func NewTradePageResponse(page *query.TradePage) TradePageResponse {
+	trades := make([]TradeResponse, 0, len(page.Trades))
+	for _, trade := range page.Trades {
+		trades = append(trades, NewTradeResponse(trade))
+	}
+
+	return TradePageResponse{
+		Trades:     trades,
+		NextCursor: page.NextCursor,
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"points/internal/domain"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AuthorizationHeader = "Authorization"
+	APIKeyHeader        = "X-API-Key"
+)
+
+// AuthMiddleware authenticates the bearer token or API key of a request and
+// stores the resulting principal in the request context.
+func AuthMiddleware(authenticator domain.Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var (
+			principal *valueobject.Principal
+			err       error
+		)
+		if token, ok := strings.CutPrefix(c.GetHeader(AuthorizationHeader), "Bearer "); ok {
+			principal, err = authenticator.AuthenticateToken(c.Request.Context(), strings.TrimSpace(token))
+		} else if key := c.GetHeader(APIKeyHeader); key != "" {
+			principal, err = authenticator.AuthenticateAPIKey(c.Request.Context(), key)
+		} else {
+			err = apperror.Wrap(errcode.ErrUnauthorized, "authentication", errors.New("missing credentials"))
+		}
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(valueobject.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	user := &valueobject.Principal{Subject: "1", Kind: valueobject.PrincipalKindUser, AccountID: 1}
+	service := &valueobject.Principal{Subject: "billing", Kind: valueobject.PrincipalKindService}
+	rejected := apperror.Wrap(errcode.ErrUnauthorized, "authenticate", errors.New("rejected"))
+
+	tests := []struct {
+		name              string
+		headers           map[string]string
+		setup             func(authenticator *mock.MockAuthenticator)
+		expectedHTTPCode  int
+		expectedPrincipal *valueobject.Principal
+	}{
+		{
+			name:    "valid bearer token",
+			headers: map[string]string{AuthorizationHeader: "Bearer good-token"},
+			setup: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().AuthenticateToken(gomock.Any(), "good-token").Return(user, nil)
+			},
+			expectedHTTPCode:  http.StatusOK,
+			expectedPrincipal: user,
+		},
+		{
+			name:    "valid api key",
+			headers: map[string]string{APIKeyHeader: "good-key"},
+			setup: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().AuthenticateAPIKey(gomock.Any(), "good-key").Return(service, nil)
+			},
+			expectedHTTPCode:  http.StatusOK,
+			expectedPrincipal: service,
+		},
+		{
+			name:    "rejected token",
+			headers: map[string]string{AuthorizationHeader: "Bearer bad-token"},
+			setup: func(authenticator *mock.MockAuthenticator) {
+				authenticator.EXPECT().AuthenticateToken(gomock.Any(), "bad-token").Return(nil, rejected)
+			},
+			expectedHTTPCode: http.StatusUnauthorized,
+		},
+		{
+			name:             "missing credentials",
+			setup:            func(authenticator *mock.MockAuthenticator) {},
+			expectedHTTPCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			authenticator := mock.NewMockAuthenticator(ctrl)
+			tt.setup(authenticator)
+
+			var got *valueobject.Principal
+			router := gin.New()
+			router.Use(ErrorHandlerMiddleware(zap.NewNop()))
+			router.POST("/trade/transfer", AuthMiddleware(authenticator), func(c *gin.Context) {
+				got, _ = valueobject.PrincipalFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/trade/transfer", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedHTTPCode, rr.Code)
+			assert.Equal(t, tt.expectedPrincipal, got)
+			if tt.expectedHTTPCode == http.StatusUnauthorized {
+				assert.Contains(t, rr.Body.String(), errcode.ErrUnauthorized.String())
+			}
+		})
+	}
+}
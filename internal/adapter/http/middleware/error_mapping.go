@@ -19,6 +19,10 @@ func mapErrorCodeToHTTPStatus(e errcode.ErrorCode) int {
 		return http.StatusUnauthorized
 	case errcode.ErrConflict:
 		return http.StatusConflict
+	case errcode.ErrForbidden:
+		return http.StatusForbidden
+	case errcode.ErrUnavailable:
+		return http.StatusServiceUnavailable
 	case errcode.ErrGetAccount:
 		return http.StatusBadRequest
 	case errcode.ErrCreateAccount:
@@ -41,6 +45,30 @@ func mapErrorCodeToHTTPStatus(e errcode.ErrorCode) int {
 		return http.StatusInternalServerError
 	case errcode.ErrCreateEvent:
 		return http.StatusInternalServerError
+	case errcode.ErrTransactionExpired:
+		return http.StatusConflict
+	case errcode.ErrGetEvent:
+		return http.StatusInternalServerError
+	case errcode.ErrPublishEvent:
+		return http.StatusInternalServerError
+	case errcode.ErrUpdateEvent:
+		return http.StatusInternalServerError
+	case errcode.ErrTransactionNotFound:
+		return http.StatusNotFound
+	case errcode.ErrCreateLedgerEntry:
+		return http.StatusInternalServerError
+	case errcode.ErrGetLedgerEntry:
+		return http.StatusInternalServerError
+	case errcode.ErrCreditBalance:
+		return http.StatusInternalServerError
+	case errcode.ErrDebitBalance:
+		return http.StatusInternalServerError
+	case errcode.ErrAssetMismatch:
+		return http.StatusBadRequest
+	case errcode.ErrGetPointLot:
+		return http.StatusInternalServerError
+	case errcode.ErrUpdatePointLot:
+		return http.StatusInternalServerError
 	case errcode.ErrDistrubutedLockNotObtained:
 		return http.StatusInternalServerError
 	case errcode.ErrDistrubutedLockAcquire:
@@ -49,6 +77,8 @@ func mapErrorCodeToHTTPStatus(e errcode.ErrorCode) int {
 		return http.StatusInternalServerError
 	case errcode.ErrDistrubutedLockRenew:
 		return http.StatusInternalServerError
+	case errcode.ErrStaleFencingToken:
+		return http.StatusConflict
 	default:
 		return http.StatusInternalServerError
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"points/internal/infrastructure/metrics"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -35,6 +36,7 @@ func LoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
 		c.Next()
 
 		duration := time.Since(startTime)
+		metrics.ObserveHTTPRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), duration)
 		logger.Info("Request completed",
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
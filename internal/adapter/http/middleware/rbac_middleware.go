@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RBACMiddleware lets a request through when the role policy grants its
+// principal the required permission. It must run after AuthMiddleware and
+// leaves the principal with its resolved permissions in the request context.
+func RBACMiddleware(policy domain.AccessPolicy, required valueobject.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := valueobject.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			c.Error(apperror.Wrap(errcode.ErrUnauthorized, "authorization", errors.New("missing principal")))
+			c.Abort()
+			return
+		}
+
+		resolved := principal.WithPermissions(policy.Permissions(principal))
+		if !resolved.HasPermission(required) {
+			c.Error(apperror.Wrap(errcode.ErrForbidden, "authorization", fmt.Errorf("%s lacks permission %s", principal.Subject, required)))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(valueobject.WithPrincipal(c.Request.Context(), resolved))
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"points/internal/domain/valueobject"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRBACMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	admin := &valueobject.Principal{Subject: "ops", Kind: valueobject.PrincipalKindService, Roles: []string{"admin"}}
+
+	tests := []struct {
+		name             string
+		principal        *valueobject.Principal
+		granted          []valueobject.Permission
+		expectedHTTPCode int
+		expectedStatus   string
+	}{
+		{name: "granted", principal: admin, granted: []valueobject.Permission{valueobject.PermissionPointsMint}, expectedHTTPCode: http.StatusOK},
+		{name: "missing permission", principal: admin, granted: []valueobject.Permission{valueobject.PermissionTradeRead}, expectedHTTPCode: http.StatusForbidden, expectedStatus: errcode.ErrForbidden.String()},
+		{name: "missing principal", expectedHTTPCode: http.StatusUnauthorized, expectedStatus: errcode.ErrUnauthorized.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			policy := mock.NewMockAccessPolicy(ctrl)
+			if tt.principal != nil {
+				policy.EXPECT().Permissions(tt.principal).Return(tt.granted).Times(1)
+			}
+
+			var got *valueobject.Principal
+			router := gin.New()
+			router.Use(ErrorHandlerMiddleware(zap.NewNop()))
+			router.Use(func(c *gin.Context) {
+				if tt.principal != nil {
+					c.Request = c.Request.WithContext(valueobject.WithPrincipal(c.Request.Context(), tt.principal))
+				}
+			})
+			router.POST("/admin/mint", RBACMiddleware(policy, valueobject.PermissionPointsMint), func(c *gin.Context) {
+				got, _ = valueobject.PrincipalFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/mint", nil)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedHTTPCode, rr.Code)
+			if tt.expectedStatus != "" {
+				assert.Contains(t, rr.Body.String(), tt.expectedStatus)
+				return
+			}
+			assert.True(t, got.HasPermission(valueobject.PermissionPointsMint), "handler should see the resolved permissions")
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"points/internal/adapter/http/controller"
+	"points/internal/adapter/http/middleware"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure/persistence/repository"
+	"points/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterAccountRoutes(server *gin.Engine, db *gorm.DB, config port.Config, authenticator domain.Authenticator, policy domain.AccessPolicy) {
+	unitOfWork := repository.NewGormUnitOfWorkImpl(db, config)
+	accountQueryUsecase := usecase.NewAccountQueryUsecase(unitOfWork)
+	accountController := controller.NewAccountController(accountQueryUsecase, config)
+	tradeQueryController := controller.NewTradeQueryController(usecase.NewTradeQueryUsecase(unitOfWork))
+
+	account := server.Group("/accounts", middleware.AuthMiddleware(authenticator), middleware.RBACMiddleware(policy, valueobject.PermissionAccountRead))
+	{
+		account.GET("/:id", accountController.GetAccount)
+		account.GET("/:id/trades", tradeQueryController.ListAccountTrades)
+	}
+}
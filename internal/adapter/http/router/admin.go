@@ -0,0 +1,30 @@
+package router
+
+import (
+	"points/internal/adapter/http/controller"
+	"points/internal/adapter/http/middleware"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure/persistence/repository"
+	"points/internal/usecase"
+	"points/internal/usecase/drain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterAdminRoutes(server *gin.Engine, db *gorm.DB, locker domain.Locker, config port.Config, authenticator domain.Authenticator, policy domain.AccessPolicy, gate *drain.TradeGate) {
+	unitOfWork := repository.NewGormUnitOfWorkImpl(db, config)
+	adminController := controller.NewAdminController(gate.GuardAdmin(usecase.NewAdminUsecase(unitOfWork, locker, config)), config)
+
+	admin := server.Group("/admin", middleware.AuthMiddleware(authenticator))
+	mint := admin.Group("", middleware.RBACMiddleware(policy, valueobject.PermissionPointsMint))
+	{
+		mint.POST("/mint", adminController.Mint)
+	}
+	burn := admin.Group("", middleware.RBACMiddleware(policy, valueobject.PermissionPointsBurn))
+	{
+		burn.POST("/burn", adminController.Burn)
+	}
+}
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"points/internal/adapter/http/controller"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/infrastructure/health"
+	"points/internal/usecase/drain"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func RegisterHealthRoutes(server *gin.Engine, db *gorm.DB, redisClient *redis.Client, config port.Config, gate *drain.TradeGate) {
+	config.SetDefaultInt("health.HEALTH_CHECK_TIMEOUT", 2)
+	timeout := time.Duration(config.GetInt("health.HEALTH_CHECK_TIMEOUT")) * time.Second
+
+	checkers := []domain.HealthChecker{
+		health.NewPostgresChecker(db),
+		health.NewMigrationChecker(db),
+	}
+	if redisClient != nil {
+		checkers = append(checkers, health.NewRedisChecker(redisClient))
+	}
+	checkers = append(checkers, gate)
+
+	healthController := controller.NewHealthController(timeout, checkers...)
+	server.GET("/healthz", healthController.Healthz)
+	server.GET("/readyz", healthController.Readyz)
+}
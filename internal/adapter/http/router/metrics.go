@@ -0,0 +1,10 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func RegisterMetricsRoutes(server *gin.Engine) {
+	server.GET("/metrics", gin.WrapH(promhttp.Handler()))
+}
@@ -2,26 +2,35 @@ package router
 
 import (
 	"points/internal/adapter/http/controller"
+	"points/internal/adapter/http/middleware"
+	"points/internal/domain"
 	"points/internal/domain/port"
-	"points/internal/infrastructure/distributedlock"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure/metrics"
 	"points/internal/infrastructure/persistence/repository"
+	"points/internal/infrastructure/tracing"
 	"points/internal/usecase"
+	"points/internal/usecase/drain"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func RegisterUserRoutes(server *gin.Engine, db *gorm.DB, redisClient *redis.Client, config port.Config) {
+func RegisterUserRoutes(server *gin.Engine, db *gorm.DB, locker domain.Locker, config port.Config, authenticator domain.Authenticator, policy domain.AccessPolicy, gate *drain.TradeGate) {
 	unitOfWork := repository.NewGormUnitOfWorkImpl(db, config)
-	locker := distributedlock.NewRedisLocker(redisClient)
-	tradeUsecase := usecase.NewTradeUsecase(unitOfWork, locker, config)
+	tradeUsecase := tracing.InstrumentTradeUsecase(metrics.InstrumentTradeUsecase(gate.Guard(usecase.NewTradeUsecase(unitOfWork, locker, config))))
 	tradeController := controller.NewTradeController(tradeUsecase, config)
+	tradeQueryController := controller.NewTradeQueryController(usecase.NewTradeQueryUsecase(unitOfWork))
 
-	user := server.Group("/trade")
+	user := server.Group("/trade", middleware.AuthMiddleware(authenticator))
+	write := user.Group("", middleware.RBACMiddleware(policy, valueobject.PermissionTradeWrite))
 	{
-		user.POST("/transfer", tradeController.Transfer)
-		user.POST("/confirm", tradeController.Confirm)
-		user.POST("/cancel", tradeController.Cancel)
+		write.POST("/transfer", tradeController.Transfer)
+		write.POST("/confirm", tradeController.Confirm)
+		write.POST("/cancel", tradeController.Cancel)
+	}
+	read := user.Group("", middleware.RBACMiddleware(policy, valueobject.PermissionTradeRead))
+	{
+		read.GET("/:from/:nonce", tradeQueryController.GetTrade)
 	}
 }
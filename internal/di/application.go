@@ -4,8 +4,12 @@ import (
 	"points/internal/domain"
 	"points/internal/domain/port"
 	"points/internal/domain/repository"
+	"points/internal/infrastructure/metrics"
+	"points/internal/infrastructure/tracing"
 	"points/internal/usecase"
+	"points/internal/usecase/drain"
 	"points/internal/usecase/locking"
+	"points/internal/usecase/reconcile"
 	"points/internal/usecase/transaction"
 
 	"go.uber.org/fx"
@@ -16,7 +20,15 @@ var ApplicationModule = fx.Options(
 		return locking.NewAccountLockService(locker, config)
 	}),
 	fx.Provide(transaction.NewTransactionApplicationService),
-	fx.Provide(func(uow repository.UnitOfWork, locker domain.Locker, config port.Config) domain.TradeUsecase {
-		return usecase.NewTradeUsecase(uow, locker, config)
+	fx.Provide(drain.NewTradeGate),
+	fx.Provide(func(uow repository.UnitOfWork, locker domain.Locker, config port.Config, gate *drain.TradeGate) domain.TradeUsecase {
+		return tracing.InstrumentTradeUsecase(metrics.InstrumentTradeUsecase(gate.Guard(usecase.NewTradeUsecase(uow, locker, config))))
 	}),
+	fx.Provide(func(uow repository.UnitOfWork) domain.TradeQueryUsecase {
+		return usecase.NewTradeQueryUsecase(uow)
+	}),
+	fx.Provide(func(uow repository.UnitOfWork) domain.AccountQueryUsecase {
+		return usecase.NewAccountQueryUsecase(uow)
+	}),
+	fx.Provide(reconcile.NewReconciliationApplicationService),
 )
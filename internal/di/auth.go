@@ -0,0 +1,12 @@
+package di
+
+import (
+	"points/internal/infrastructure/auth"
+
+	"go.uber.org/fx"
+)
+
+var AuthModule = fx.Options(
+	fx.Provide(auth.NewAuthenticator),
+	fx.Provide(auth.NewAccessPolicy),
+)
@@ -0,0 +1,37 @@
+package di
+
+import (
+	"points/internal/domain/port"
+	"points/internal/infrastructure"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var ConfigReloadModule = fx.Options(
+	fx.Invoke(WatchConfig),
+)
+
+// WatchConfig reloads the config file on change. Lock timing subscribes on its
+// own; this applies logger.LOG_LEVEL and warns about settings that only take
+// effect after a restart.
+func WatchConfig(settings port.SettingsManager, config port.Config, env string, level zap.AtomicLevel, logger *zap.Logger) {
+	config.Subscribe(func(change port.ConfigChange) {
+		if change.Err != nil {
+			logger.Error("failed to reload config, keeping previous settings", zap.Error(change.Err))
+			return
+		}
+		logger.Info("config reloaded", zap.Strings("keys", change.Keys))
+		if len(change.RestartRequired) > 0 {
+			logger.Warn("changed settings take effect after a restart", zap.Strings("keys", change.RestartRequired))
+		}
+
+		next, err := infrastructure.NewLogLevel(env, config.GetString("logger.LOG_LEVEL"))
+		if err != nil {
+			logger.Error("invalid log level, keeping the current one", zap.Error(err))
+			return
+		}
+		level.SetLevel(next.Level())
+	})
+	settings.WatchConfig()
+}
@@ -0,0 +1,93 @@
+package di
+
+import (
+	"points/internal/domain/port"
+	"points/internal/infrastructure"
+	"points/internal/infrastructure/auth"
+	"points/internal/infrastructure/dbconnection"
+	"points/internal/infrastructure/tracing"
+
+	"go.uber.org/fx"
+)
+
+var ConfigValidationModule = fx.Options(
+	fx.Invoke(ValidateConfig),
+)
+
+// The sections below are read key by key where they are used. Keys with a
+// default are pointers, so only values that are actually set are checked.
+
+type ServerConfig struct {
+	Host            string `mapstructure:"SERVER_HOST"`
+	Port            int    `mapstructure:"SERVER_PORT" validate:"required,min=1,max=65535"`
+	ShutdownTimeout *int   `mapstructure:"SERVER_SHUTDOWN_TIMEOUT" validate:"omitempty,min=0"`
+}
+
+type GRPCConfig struct {
+	Host string `mapstructure:"GRPC_HOST"`
+	Port *int   `mapstructure:"GRPC_PORT" validate:"omitempty,min=1,max=65535"`
+}
+
+type LockConfig struct {
+	Backend       string `mapstructure:"LOCK_BACKEND" validate:"omitempty,oneof=redis postgres memory"`
+	TTL           *int   `mapstructure:"LOCK_TTL" validate:"omitempty,min=1"`
+	RetryInterval *int   `mapstructure:"LOCK_RETRY_INTERVAL" validate:"omitempty,min=0"`
+}
+
+type OutboxConfig struct {
+	Publisher    string `mapstructure:"OUTBOX_PUBLISHER" validate:"omitempty,oneof=redis none"`
+	Stream       string `mapstructure:"OUTBOX_STREAM"`
+	StreamMaxLen *int   `mapstructure:"OUTBOX_STREAM_MAXLEN" validate:"omitempty,min=0"`
+	BatchSize    *int   `mapstructure:"OUTBOX_BATCH_SIZE" validate:"omitempty,min=1"`
+	PollInterval *int   `mapstructure:"OUTBOX_POLL_INTERVAL" validate:"omitempty,min=1"`
+	MaxBackoff   *int   `mapstructure:"OUTBOX_MAX_BACKOFF" validate:"omitempty,min=1"`
+}
+
+type TccConfig struct {
+	PendingTimeout    *int `mapstructure:"TCC_PENDING_TIMEOUT" validate:"omitempty,min=1"`
+	MaxPendingTimeout *int `mapstructure:"TCC_MAX_PENDING_TIMEOUT" validate:"omitempty,min=1"`
+	SweepInterval     *int `mapstructure:"TCC_SWEEP_INTERVAL" validate:"omitempty,min=1"`
+	SweepBatchSize    *int `mapstructure:"TCC_SWEEP_BATCH_SIZE" validate:"omitempty,min=1"`
+}
+
+// ExpiryConfig allows 0 months (never expire) and a 0 interval (no sweep).
+type ExpiryConfig struct {
+	Months    *int `mapstructure:"POINT_EXPIRY_MONTHS" validate:"omitempty,min=0"`
+	Interval  *int `mapstructure:"POINT_EXPIRY_INTERVAL" validate:"omitempty,min=0"`
+	BatchSize *int `mapstructure:"POINT_EXPIRY_BATCH_SIZE" validate:"omitempty,min=1"`
+}
+
+type ReconcileConfig struct {
+	Interval *int `mapstructure:"RECONCILE_INTERVAL" validate:"omitempty,min=0"`
+}
+
+// ValidateConfig checks every section the app reads before any of them is
+// used, so a broken deployment lists all of its problems in one error.
+func ValidateConfig(config port.Config) error {
+	return infrastructure.ValidateConfig(config,
+		infrastructure.ConfigSection{Name: "postgres", Target: &dbconnection.PostgresConfig{}, Required: true},
+		infrastructure.ConfigSection{Name: "redis", Target: &dbconnection.RedisConfig{}, Required: usesRedis(config)},
+		infrastructure.ConfigSection{Name: "server", Target: &ServerConfig{}, Required: true},
+		infrastructure.ConfigSection{Name: "grpc", Target: &GRPCConfig{}},
+		infrastructure.ConfigSection{Name: "lock", Target: &LockConfig{}},
+		infrastructure.ConfigSection{Name: "outbox", Target: &OutboxConfig{}},
+		infrastructure.ConfigSection{Name: "tcc", Target: &TccConfig{}},
+		infrastructure.ConfigSection{Name: "expiry", Target: &ExpiryConfig{}},
+		infrastructure.ConfigSection{Name: "reconcile", Target: &ReconcileConfig{}},
+		infrastructure.ConfigSection{Name: "auth", Target: &auth.AuthConfig{}},
+		infrastructure.ConfigSection{Name: "tracing", Target: &tracing.TracingConfig{}},
+	)
+}
+
+// usesRedis reports whether the lock backend or outbox publisher needs Redis.
+func usesRedis(config port.Config) bool {
+	switch config.GetString("lock.LOCK_BACKEND") {
+	case "", "redis":
+		return true
+	}
+	switch config.GetString("outbox.OUTBOX_PUBLISHER") {
+	case "", "redis":
+		return true
+	}
+	return false
+}
@@ -1,10 +1,20 @@
 package di
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/repository"
 	"points/internal/infrastructure/dbconnection"
+	"points/internal/infrastructure/distributedlock"
+	"points/internal/infrastructure/metrics"
+	persistence "points/internal/infrastructure/persistence/repository"
+	"points/internal/infrastructure/tracing"
 
 	"points/internal/domain/port"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
@@ -12,15 +22,82 @@ import (
 
 var DatabaseModule = fx.Options(
 	fx.Provide(
-		func(config port.Config) (*gorm.DB, error) {
+		func(lifecycle fx.Lifecycle, config port.Config) (*gorm.DB, error) {
 			pgConn := dbconnection.NewPostgresConnection(config)
-			return pgConn.InitPostgresDatabase()
+			db, err := pgConn.InitPostgresDatabase()
+			if err != nil {
+				return nil, err
+			}
+			if err := db.Use(tracing.GormPlugin{}); err != nil {
+				return nil, err
+			}
+			lifecycle.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return pgConn.Close(db)
+				},
+			})
+			return db, nil
 		},
 	),
 	fx.Provide(
-		func(config port.Config) (*redis.Client, error) {
+		// Without a redis section the client is nil, which only the memory
+		// lock backend and the none outbox publisher accept.
+		func(lifecycle fx.Lifecycle, config port.Config) (*redis.Client, error) {
+			if config.Sub("redis") == nil {
+				return nil, nil
+			}
 			redisConn := dbconnection.NewRedisConnection(config)
-			return redisConn.InitRedisDatabase()
+			client, err := redisConn.InitRedisDatabase()
+			if err != nil {
+				return nil, err
+			}
+			if err := redisotel.InstrumentTracing(client); err != nil {
+				return nil, err
+			}
+			lifecycle.Append(fx.Hook{
+				OnStop: func(ctx context.Context) error {
+					return client.Close()
+				},
+			})
+			return client, nil
 		},
 	),
+	fx.Provide(
+		func(db *gorm.DB, config port.Config) repository.UnitOfWork {
+			return persistence.NewGormUnitOfWorkImpl(db, config)
+		},
+	),
+	fx.Provide(NewLocker),
 )
+
+// NewLocker picks the trade lock backend from lock.LOCK_BACKEND: redis
+// (default), postgres advisory locks or memory for a single instance. Every
+// backend takes its fencing tokens from the same Postgres sequence, which is
+// moved past the tokens recorded on accounts on startup.
+func NewLocker(lifecycle fx.Lifecycle, config port.Config, db *gorm.DB, redisClient *redis.Client) (domain.Locker, error) {
+	backend := config.GetString("lock.LOCK_BACKEND")
+	tokens := distributedlock.NewPostgresFencingTokenSource(db)
+	lifecycle.Append(fx.Hook{
+		OnStart: tokens.Resync,
+	})
+
+	switch backend {
+	case "", "redis":
+		if redisClient == nil {
+			return nil, errors.New("lock backend redis needs a redis section")
+		}
+		return metrics.InstrumentLocker(distributedlock.NewRedisLocker(redisClient, tokens)), nil
+	case "postgres":
+		locker := distributedlock.NewPostgresLocker(db, tokens)
+		lifecycle.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				return locker.Close()
+			},
+		})
+		return metrics.InstrumentLocker(locker), nil
+	case "memory":
+		return metrics.InstrumentLocker(distributedlock.NewMemoryLocker(tokens)), nil
+	default:
+		return nil, fmt.Errorf("unsupported lock backend: %s", backend)
+	}
+}
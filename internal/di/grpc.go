@@ -0,0 +1,87 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"points/internal/adapter/grpc/interceptor"
+	"points/internal/adapter/grpc/pb"
+	"points/internal/adapter/grpc/service"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+var GRPCModule = fx.Options(
+	fx.Provide(NewGRPCServer),
+	fx.Provide(service.NewTradeService),
+	fx.Invoke(RegisterGRPCServices),
+	fx.Invoke(StartGRPCServer),
+)
+
+func NewGRPCServer(logger *zap.Logger, authenticator domain.Authenticator, policy domain.AccessPolicy) *grpc.Server {
+	return grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			interceptor.LoggerInterceptor(logger),
+			interceptor.ErrorInterceptor(logger),
+			interceptor.AuthInterceptor(authenticator),
+			interceptor.RBACInterceptor(policy, service.TradeServicePermissions),
+		),
+	)
+}
+
+func RegisterGRPCServices(server *grpc.Server, tradeService *service.TradeService) {
+	pb.RegisterTradeServiceServer(server, tradeService)
+}
+
+// StartGRPCServer serves gRPC until shutdown. On stop it lets in-flight calls
+// finish for up to server.SERVER_SHUTDOWN_TIMEOUT and then closes the
+// remaining connections.
+func StartGRPCServer(lifecycle fx.Lifecycle, server *grpc.Server, config port.Config, logger *zap.Logger) {
+	config.SetDefaultInt("grpc.GRPC_PORT", 9090)
+	config.SetDefaultInt("server.SERVER_SHUTDOWN_TIMEOUT", 10)
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			addr := fmt.Sprintf("%s:%d", config.GetString("grpc.GRPC_HOST"), config.GetInt("grpc.GRPC_PORT"))
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("grpc server failed to listen on %s: %w", addr, err)
+			}
+
+			logger.Info("Starting grpc server", zap.String("addr", addr))
+			go func() {
+				if err := server.Serve(listener); err != nil {
+					logger.Error("grpc server stopped", zap.Error(err))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logger.Info("Shutting down grpc server...")
+			ctx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInt("server.SERVER_SHUTDOWN_TIMEOUT"))*time.Second)
+			defer cancel()
+
+			stopped := make(chan struct{})
+			go func() {
+				server.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				logger.Warn("grpc calls did not finish before shutdown, closing connections", zap.Error(ctx.Err()))
+				server.Stop()
+				return ctx.Err()
+			}
+		},
+	})
+}
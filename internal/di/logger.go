@@ -1,6 +1,7 @@
 package di
 
 import (
+	"points/internal/domain/port"
 	"points/internal/infrastructure"
 
 	"go.uber.org/fx"
@@ -8,7 +9,10 @@ import (
 )
 
 var LoggerModule = fx.Options(
-	fx.Provide(func(env string) (*zap.Logger, error) {
-		return infrastructure.NewZapLogger(env)
+	fx.Provide(func(env string, config port.Config) (zap.AtomicLevel, error) {
+		return infrastructure.NewLogLevel(env, config.GetString("logger.LOG_LEVEL"))
+	}),
+	fx.Provide(func(env string, level zap.AtomicLevel) (*zap.Logger, error) {
+		return infrastructure.NewZapLoggerWithLevel(env, level)
 	}),
 )
@@ -0,0 +1,11 @@
+package di
+
+import (
+	"points/internal/infrastructure/metrics"
+
+	"go.uber.org/fx"
+)
+
+var MetricsModule = fx.Options(
+	fx.Invoke(metrics.RegisterDBStats),
+)
@@ -0,0 +1,32 @@
+package di
+
+import (
+	"points/internal/domain/port"
+	"points/internal/infrastructure/migration"
+
+	"go.uber.org/zap"
+)
+
+// RunMigrations applies pending migrations before anything else touches the
+// database. It is only invoked when the app starts with -auto-migrate.
+func RunMigrations(config port.Config, logger *zap.Logger) (err error) {
+	migrator, err := migration.NewMigrator(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := migrator.Close(); closeErr != nil {
+			logger.Warn("failed to close migrator", zap.Error(closeErr))
+		}
+	}()
+
+	if err := migrator.Up(); err != nil {
+		return err
+	}
+	version, _, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	logger.Info("Database schema is up to date", zap.Uint("version", version))
+	return nil
+}
@@ -2,13 +2,20 @@ package di
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"points/internal/adapter/http/middleware"
 	"points/internal/adapter/http/router"
+	"points/internal/domain"
 	"points/internal/domain/port"
+	"points/internal/usecase/drain"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -21,6 +28,9 @@ var HTTPModule = fx.Options(
 
 func NewGinServer(config port.Config, logger *zap.Logger) *gin.Engine {
 	server := gin.Default()
+	// Lets usecases read the principal that AuthMiddleware stores on the request.
+	server.ContextWithFallback = true
+	server.Use(otelgin.Middleware("points"))
 	server.Use(middleware.LoggerMiddleware(logger))
 	server.Use(middleware.ErrorHandlerMiddleware(logger))
 	return server
@@ -30,29 +40,54 @@ func RegisterRoutes(
 	server *gin.Engine,
 	db *gorm.DB,
 	redisClient *redis.Client,
+	locker domain.Locker,
 	config port.Config,
+	authenticator domain.Authenticator,
+	policy domain.AccessPolicy,
+	gate *drain.TradeGate,
 ) {
 	router.RegisterTestRoutes(server)
-	router.RegisterUserRoutes(server, db, redisClient, config)
+	router.RegisterHealthRoutes(server, db, redisClient, config, gate)
+	router.RegisterMetricsRoutes(server)
+	router.RegisterUserRoutes(server, db, locker, config, authenticator, policy, gate)
+	router.RegisterAccountRoutes(server, db, config, authenticator, policy)
+	router.RegisterAdminRoutes(server, db, locker, config, authenticator, policy, gate)
 }
 
-func StartServer(lifecycle fx.Lifecycle, server *gin.Engine, config port.Config) {
+// StartServer serves HTTP until shutdown. On stop it first drains in-flight
+// trades, mints and burns, rejecting new ones, and then closes the listener and idle
+// connections, both within server.SERVER_SHUTDOWN_TIMEOUT.
+func StartServer(lifecycle fx.Lifecycle, server *gin.Engine, config port.Config, gate *drain.TradeGate, logger *zap.Logger) {
+	config.SetDefaultInt("server.SERVER_SHUTDOWN_TIMEOUT", 10)
+	httpServer := &http.Server{Handler: server}
+
 	lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
 			serverParameter := config.Sub("server")
 			addr := fmt.Sprintf("%s:%s", serverParameter.GetString("SERVER_HOST"), serverParameter.GetString("SERVER_PORT"))
-			fmt.Println("Starting server...")
-			println("test: ", addr)
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("http server failed to listen on %s: %w", addr, err)
+			}
+
+			logger.Info("Starting http server", zap.String("addr", addr))
 			go func() {
-				if err := server.Run(addr); err != nil {
-					panic(fmt.Sprintf("Server failed to start: %v", err))
+				if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.Error("http server stopped", zap.Error(err))
 				}
 			}()
 			return nil
 		},
 		OnStop: func(ctx context.Context) error {
-			fmt.Println("Shutting down server...")
-			return nil
+			logger.Info("Shutting down http server...")
+			ctx, cancel := context.WithTimeout(ctx, time.Duration(config.GetInt("server.SERVER_SHUTDOWN_TIMEOUT"))*time.Second)
+			defer cancel()
+
+			drainErr := gate.Drain(ctx)
+			if drainErr != nil {
+				logger.Warn("in-flight trades did not finish before shutdown", zap.Error(drainErr))
+			}
+			return errors.Join(drainErr, httpServer.Shutdown(ctx))
 		},
 	})
 }
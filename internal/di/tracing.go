@@ -0,0 +1,23 @@
+package di
+
+import (
+	"context"
+	"points/internal/infrastructure/tracing"
+
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/fx"
+)
+
+var TracingModule = fx.Options(
+	fx.Provide(tracing.NewTracerProvider),
+	fx.Invoke(RegisterTracerShutdown),
+)
+
+// RegisterTracerShutdown flushes buffered spans when the app stops.
+func RegisterTracerShutdown(lifecycle fx.Lifecycle, provider *trace.TracerProvider) {
+	lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			return provider.Shutdown(ctx)
+		},
+	})
+}
@@ -0,0 +1,183 @@
+package di
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/infrastructure/messaging"
+	"points/internal/usecase/expiry"
+	"points/internal/usecase/outbox"
+	"points/internal/usecase/reconcile"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+var WorkerModule = fx.Options(
+	fx.Provide(func(uow repository.UnitOfWork, locker domain.Locker, config port.Config) expiry.TccExpiryApplicationService {
+		return expiry.NewTccExpiryApplicationService(uow, locker, config)
+	}),
+	fx.Provide(func(uow repository.UnitOfWork, locker domain.Locker, config port.Config) expiry.PointExpiryApplicationService {
+		return expiry.NewPointExpiryApplicationService(uow, locker, config)
+	}),
+	fx.Provide(NewEventPublisher),
+	fx.Provide(func(uow repository.UnitOfWork, locker domain.Locker, publisher domain.EventPublisher, config port.Config) outbox.OutboxRelayApplicationService {
+		return outbox.NewOutboxRelayApplicationService(uow, locker, publisher, config)
+	}),
+	fx.Invoke(StartTccExpiryWorker),
+	fx.Invoke(StartPointExpiryWorker),
+	fx.Invoke(StartOutboxRelayWorker),
+	fx.Invoke(StartReconciliationWorker),
+)
+
+func NewEventPublisher(config port.Config, redisClient *redis.Client) (domain.EventPublisher, error) {
+	config.SetDefaultInt("outbox.OUTBOX_STREAM_MAXLEN", 100000)
+	publisher := config.GetString("outbox.OUTBOX_PUBLISHER")
+	stream := config.GetString("outbox.OUTBOX_STREAM")
+	if stream == "" {
+		stream = "points:transaction_events"
+	}
+
+	switch publisher {
+	case "", "redis":
+		if redisClient == nil {
+			return nil, errors.New("outbox publisher redis needs a redis section")
+		}
+		return messaging.NewRedisStreamPublisher(redisClient, stream, int64(config.GetInt("outbox.OUTBOX_STREAM_MAXLEN"))), nil
+	case "none":
+		// Events stay in the outbox until a publisher is configured.
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported outbox publisher: %s", publisher)
+	}
+}
+
+func StartTccExpiryWorker(lifecycle fx.Lifecycle, service expiry.TccExpiryApplicationService, config port.Config, logger *zap.Logger) {
+	config.SetDefaultInt("tcc.TCC_SWEEP_INTERVAL", 30)
+	interval := time.Duration(config.GetInt("tcc.TCC_SWEEP_INTERVAL")) * time.Second
+
+	appendWorker(lifecycle, logger, "tcc expiry worker", interval, interval, func(ctx context.Context) error {
+		canceled, err := service.CancelExpiredTransactions(ctx)
+		if canceled > 0 {
+			logger.Info("tcc expiry sweep canceled transactions", zap.Int("count", canceled))
+		}
+		return err
+	})
+}
+
+func StartPointExpiryWorker(lifecycle fx.Lifecycle, service expiry.PointExpiryApplicationService, config port.Config, logger *zap.Logger) {
+	config.SetDefaultInt("expiry.POINT_EXPIRY_INTERVAL", 3600)
+	interval := time.Duration(config.GetInt("expiry.POINT_EXPIRY_INTERVAL")) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	appendWorker(lifecycle, logger, "point expiry worker", interval, interval, func(ctx context.Context) error {
+		expired, err := service.ExpirePointLots(ctx)
+		if expired > 0 {
+			logger.Info("point expiry sweep expired accounts", zap.Int("count", expired))
+		}
+		return err
+	})
+}
+
+func StartOutboxRelayWorker(lifecycle fx.Lifecycle, service outbox.OutboxRelayApplicationService, publisher domain.EventPublisher, config port.Config, logger *zap.Logger) {
+	if publisher == nil {
+		logger.Info("outbox relay worker disabled, no publisher configured")
+		return
+	}
+	config.SetDefaultInt("outbox.OUTBOX_POLL_INTERVAL", 1)
+	config.SetDefaultInt("outbox.OUTBOX_MAX_BACKOFF", 60)
+	interval := time.Duration(config.GetInt("outbox.OUTBOX_POLL_INTERVAL")) * time.Second
+	maxBackoff := time.Duration(config.GetInt("outbox.OUTBOX_MAX_BACKOFF")) * time.Second
+
+	appendWorker(lifecycle, logger, "outbox relay worker", interval, maxBackoff, func(ctx context.Context) error {
+		published, err := service.RelayPendingEvents(ctx)
+		if published > 0 {
+			logger.Debug("outbox relay published events", zap.Int("count", published))
+		}
+		return err
+	})
+}
+
+func StartReconciliationWorker(lifecycle fx.Lifecycle, service reconcile.ReconciliationApplicationService, config port.Config, logger *zap.Logger) {
+	interval := time.Duration(config.GetInt("reconcile.RECONCILE_INTERVAL")) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	appendWorker(lifecycle, logger, "reconciliation worker", interval, interval, func(ctx context.Context) error {
+		report, err := service.Reconcile(ctx)
+		if err != nil {
+			return err
+		}
+		if !report.Consistent {
+			payload, err := json.Marshal(report)
+			if err != nil {
+				return err
+			}
+			logger.Warn("reconciliation found discrepancies",
+				zap.Int("count", len(report.Discrepancies)),
+				zap.String("report", string(payload)),
+			)
+		}
+		return nil
+	})
+}
+
+func appendWorker(lifecycle fx.Lifecycle, logger *zap.Logger, name string, interval, maxBackoff time.Duration, run func(ctx context.Context) error) {
+	workerCtx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			logger.Info("Starting "+name, zap.Duration("interval", interval))
+			go func() {
+				defer close(done)
+				delay := interval
+
+				for {
+					select {
+					case <-workerCtx.Done():
+						return
+					case <-time.After(delay):
+					}
+
+					if err := run(workerCtx); err != nil {
+						delay = nextBackoff(delay, interval, maxBackoff)
+						logger.Error(name+" run failed", zap.Error(err), zap.Duration("retry_in", delay))
+						continue
+					}
+					delay = interval
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return nil
+		},
+	})
+}
+
+func nextBackoff(current, interval, maxBackoff time.Duration) time.Duration {
+	if current < interval {
+		current = interval
+	}
+	next := current * 2
+	if next > maxBackoff {
+		return maxBackoff
+	}
+	return next
+}
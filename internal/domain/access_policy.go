@@ -0,0 +1,7 @@
+package domain
+
+import "points/internal/domain/valueobject"
+
+type AccessPolicy interface {
+	Permissions(principal *valueobject.Principal) []valueobject.Permission
+}
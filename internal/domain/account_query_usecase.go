@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"context"
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+)
+
+type AccountQueryUsecase interface {
+	GetAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+)
+
+type AdminUsecase interface {
+	Mint(ctx context.Context, req *command.MintCommand) (*entity.TradeRecords, error)
+	Burn(ctx context.Context, req *command.BurnCommand) (*entity.TradeRecords, error)
+}
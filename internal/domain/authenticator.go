@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"context"
+	"points/internal/domain/valueobject"
+)
+
+type Authenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*valueobject.Principal, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (*valueobject.Principal, error)
+}
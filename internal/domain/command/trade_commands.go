@@ -10,8 +10,10 @@ type BaseCommand struct {
 
 type TransferCommand struct {
 	BaseCommand
-	Amount      valueobject.Money
-	AutoConfirm bool
+	Amount         valueobject.Money
+	AssetCode      valueobject.AssetCode
+	AutoConfirm    bool
+	TimeoutSeconds int64
 }
 
 type ConfirmCommand struct {
@@ -21,3 +23,20 @@ type ConfirmCommand struct {
 type CancelCommand struct {
 	BaseCommand
 }
+
+type AdjustmentCommand struct {
+	AccountID         int64
+	Nonce             int64
+	Amount            valueobject.Money
+	AssetCode         valueobject.AssetCode
+	ReasonCode        string
+	ExternalReference string
+}
+
+type MintCommand struct {
+	AdjustmentCommand
+}
+
+type BurnCommand struct {
+	AdjustmentCommand
+}
@@ -7,9 +7,15 @@ import (
 
 type Locker interface {
 	Acquire(ctx context.Context, key string, lockDuration, retryInterval time.Duration) (Lock, error)
+	// AcquireAll takes every key or none, so callers locking several
+	// accounts cannot deadlock or hold a partial set.
+	AcquireAll(ctx context.Context, keys []string, lockDuration, retryInterval time.Duration) (Lock, error)
 }
 
 type Lock interface {
 	Release(ctx context.Context) error
 	Renew(ctx context.Context, ttl time.Duration) error
+	// Token is a fencing token that grows with every acquisition across all
+	// keys, or 0 when the lock carries none.
+	Token() int64
 }
@@ -5,29 +5,109 @@ import (
 	"points/internal/shared/apperror"
 	"points/internal/shared/errcode"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type Account struct {
 	UserID           int64
+	AssetCode        valueobject.AssetCode
 	AvailableBalance valueobject.Money
 	ReservedBalance  valueobject.Money
 	UpdatedAt        time.Time
+	Lots             []*PointLot
+}
+
+func (a *Account) Asset() valueobject.AssetCode {
+	return a.AssetCode.OrDefault()
+}
+
+// TotalBalance adds both buckets, which always share the account's asset.
+func (a *Account) TotalBalance() valueobject.Money {
+	return valueobject.NewMoney(a.AvailableBalance.Value().Add(a.ReservedBalance.Value()), a.Asset())
 }
 
-func (a *Account) Reserve(amount valueobject.Money) error {
-	if a.AvailableBalance.LessThan(amount) {
-		return apperror.Wrap(errcode.ErrInsufficientBalance, "insufficient balance", nil)
+// SpendableBalance is the available balance less lots that have expired but
+// have not been burned yet.
+func (a *Account) SpendableBalance(now time.Time) valueobject.Money {
+	expired := decimal.Zero
+	for _, lot := range a.Lots {
+		if lot.IsExpired(now) {
+			expired = expired.Add(lot.RemainingAmount.Value())
+		}
 	}
+	return valueobject.NewMoney(a.AvailableBalance.Value().Sub(expired), a.Asset())
+}
 
-	a.AvailableBalance = a.AvailableBalance.Sub(amount)
-	a.ReservedBalance = a.ReservedBalance.Add(amount)
+// Reserve holds amount for a pending transfer and reports which lots it was
+// taken from.
+func (a *Account) Reserve(amount valueobject.Money, now time.Time) ([]*LotAllocation, error) {
+	if err := a.checkAsset(amount); err != nil {
+		return nil, err
+	}
+	if a.SpendableBalance(now).LessThan(amount) {
+		return nil, apperror.Wrap(errcode.ErrInsufficientBalance, "insufficient balance", nil)
+	}
 
-	return nil
+	a.AvailableBalance = valueobject.NewMoney(a.AvailableBalance.Value().Sub(amount.Value()), a.Asset())
+	a.ReservedBalance = valueobject.NewMoney(a.ReservedBalance.Value().Add(amount.Value()), a.Asset())
+
+	return takeFromLots(a.Lots, amount, now, true), nil
 }
 
 func (a *Account) Unreserve(amount valueobject.Money) error {
+	if err := a.checkAsset(amount); err != nil {
+		return err
+	}
 	if a.ReservedBalance.LessThan(amount) {
 		return apperror.Wrap(errcode.ErrUnreserveBalance, "insufficient balance", nil)
 	}
 	return nil
 }
+
+func (a *Account) Credit(amount valueobject.Money) error {
+	if err := a.checkAsset(amount); err != nil {
+		return err
+	}
+
+	a.AvailableBalance = valueobject.NewMoney(a.AvailableBalance.Value().Add(amount.Value()), a.Asset())
+	return nil
+}
+
+func (a *Account) Debit(amount valueobject.Money, now time.Time) ([]*LotAllocation, error) {
+	if err := a.checkAsset(amount); err != nil {
+		return nil, err
+	}
+	if a.SpendableBalance(now).LessThan(amount) {
+		return nil, apperror.Wrap(errcode.ErrInsufficientBalance, "insufficient balance", nil)
+	}
+
+	a.AvailableBalance = valueobject.NewMoney(a.AvailableBalance.Value().Sub(amount.Value()), a.Asset())
+	return takeFromLots(a.Lots, amount, now, false), nil
+}
+
+// ExpireLots empties every expired lot and returns what was left in each.
+func (a *Account) ExpireLots(now time.Time) []*LotAllocation {
+	var allocations []*LotAllocation
+	for _, lot := range a.Lots {
+		if !lot.IsExpired(now) || !lot.RemainingAmount.Value().IsPositive() {
+			continue
+		}
+
+		allocations = append(allocations, &LotAllocation{
+			LotID:     lot.ID,
+			Amount:    lot.RemainingAmount.WithAsset(a.Asset()),
+			ExpiresAt: lot.ExpiresAt,
+		})
+		a.AvailableBalance = valueobject.NewMoney(a.AvailableBalance.Value().Sub(lot.RemainingAmount.Value()), a.Asset())
+		lot.RemainingAmount = valueobject.ZeroOf(a.Asset())
+	}
+	return allocations
+}
+
+func (a *Account) checkAsset(amount valueobject.Money) error {
+	if amount.Asset() != a.Asset() {
+		return apperror.Wrap(errcode.ErrAssetMismatch, "account asset validation", nil)
+	}
+	return nil
+}
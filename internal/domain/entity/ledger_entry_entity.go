@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"points/internal/domain/valueobject"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type LedgerEntry struct {
+	ID            int64
+	TransactionID string
+	AccountID     int64
+	Bucket        valueobject.LedgerBucket
+	EntryType     valueobject.LedgerEntryType
+	Direction     valueobject.LedgerDirection
+	Amount        valueobject.Money
+	CreatedAt     time.Time
+}
+
+func NewReserveLedgerEntries(transactionID string, accountID int64, amount valueobject.Money) []*LedgerEntry {
+	return newLedgerPair(transactionID, valueobject.LedgerEntryReserve, amount,
+		accountID, valueobject.LedgerBucketAvailable,
+		accountID, valueobject.LedgerBucketReserved,
+	)
+}
+
+func NewReleaseLedgerEntries(transactionID string, accountID int64, amount valueobject.Money) []*LedgerEntry {
+	return newLedgerPair(transactionID, valueobject.LedgerEntryRelease, amount,
+		accountID, valueobject.LedgerBucketReserved,
+		accountID, valueobject.LedgerBucketAvailable,
+	)
+}
+
+func NewSettleLedgerEntries(transactionID string, from, to int64, amount valueobject.Money) []*LedgerEntry {
+	return []*LedgerEntry{
+		{
+			TransactionID: transactionID,
+			AccountID:     from,
+			Bucket:        valueobject.LedgerBucketReserved,
+			EntryType:     valueobject.LedgerEntryDebit,
+			Direction:     valueobject.LedgerDebit,
+			Amount:        amount,
+		},
+		{
+			TransactionID: transactionID,
+			AccountID:     to,
+			Bucket:        valueobject.LedgerBucketAvailable,
+			EntryType:     valueobject.LedgerEntryCredit,
+			Direction:     valueobject.LedgerCredit,
+			Amount:        amount,
+		},
+	}
+}
+
+func NewMintLedgerEntries(transactionID string, accountID int64, amount valueobject.Money) []*LedgerEntry {
+	return newLedgerPair(transactionID, valueobject.LedgerEntryMint, amount,
+		valueobject.SystemAccountID, valueobject.LedgerBucketIssuance,
+		accountID, valueobject.LedgerBucketAvailable,
+	)
+}
+
+func NewBurnLedgerEntries(transactionID string, accountID int64, amount valueobject.Money) []*LedgerEntry {
+	return newLedgerPair(transactionID, valueobject.LedgerEntryBurn, amount,
+		accountID, valueobject.LedgerBucketAvailable,
+		valueobject.SystemAccountID, valueobject.LedgerBucketIssuance,
+	)
+}
+
+func NewExpireLedgerEntries(transactionID string, accountID int64, amount valueobject.Money) []*LedgerEntry {
+	return newLedgerPair(transactionID, valueobject.LedgerEntryExpire, amount,
+		accountID, valueobject.LedgerBucketAvailable,
+		valueobject.SystemAccountID, valueobject.LedgerBucketIssuance,
+	)
+}
+
+// IsLedgerBalanced reports whether debits equal credits for every asset in entries.
+func IsLedgerBalanced(entries []*LedgerEntry) bool {
+	net := make(map[valueobject.AssetCode]decimal.Decimal)
+	for _, entry := range entries {
+		asset := entry.Amount.Asset()
+		switch entry.Direction {
+		case valueobject.LedgerDebit:
+			net[asset] = net[asset].Add(entry.Amount.Value())
+		case valueobject.LedgerCredit:
+			net[asset] = net[asset].Sub(entry.Amount.Value())
+		default:
+			return false
+		}
+	}
+	for _, balance := range net {
+		if !balance.IsZero() {
+			return false
+		}
+	}
+	return len(entries) > 0
+}
+
+func newLedgerPair(
+	transactionID string,
+	entryType valueobject.LedgerEntryType,
+	amount valueobject.Money,
+	debitAccount int64, debitBucket valueobject.LedgerBucket,
+	creditAccount int64, creditBucket valueobject.LedgerBucket,
+) []*LedgerEntry {
+	return []*LedgerEntry{
+		{
+			TransactionID: transactionID,
+			AccountID:     debitAccount,
+			Bucket:        debitBucket,
+			EntryType:     entryType,
+			Direction:     valueobject.LedgerDebit,
+			Amount:        amount,
+		},
+		{
+			TransactionID: transactionID,
+			AccountID:     creditAccount,
+			Bucket:        creditBucket,
+			EntryType:     entryType,
+			Direction:     valueobject.LedgerCredit,
+			Amount:        amount,
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"points/internal/domain/valueobject"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PointLot is the part of a balance that arrived with one credit. Lots are
+// spent soonest-expiring first and burned once their expiry has passed.
+type PointLot struct {
+	ID              int64
+	AccountID       int64
+	TransactionID   string
+	Amount          valueobject.Money
+	RemainingAmount valueobject.Money
+	ReservedAmount  valueobject.Money
+	ExpiresAt       *time.Time
+	CreatedAt       time.Time
+}
+
+// LotAllocation is the share of a lot taken by a single trade.
+type LotAllocation struct {
+	LotID     int64
+	Amount    valueobject.Money
+	ExpiresAt *time.Time
+}
+
+// NewPointLot opens a lot for amount; a nil expiresAt never expires.
+func NewPointLot(accountID int64, transactionID string, amount valueobject.Money, expiresAt *time.Time) *PointLot {
+	return &PointLot{
+		AccountID:       accountID,
+		TransactionID:   transactionID,
+		Amount:          amount,
+		RemainingAmount: amount,
+		ReservedAmount:  valueobject.ZeroOf(amount.Asset()),
+		ExpiresAt:       expiresAt,
+	}
+}
+
+// NewSettledPointLots hands allocated lots over to accountID, keeping every
+// expiry, plus whatever NewUncoveredPointLot adds for the rest of amount.
+func NewSettledPointLots(accountID int64, transactionID string, amount valueobject.Money, allocations []*LotAllocation) []*PointLot {
+	lots := make([]*PointLot, 0, len(allocations)+1)
+	for _, allocation := range allocations {
+		lots = append(lots, NewPointLot(accountID, transactionID, allocation.Amount.WithAsset(amount.Asset()), allocation.ExpiresAt))
+	}
+	if lot := NewUncoveredPointLot(accountID, transactionID, amount, allocations); lot != nil {
+		lots = append(lots, lot)
+	}
+	return lots
+}
+
+// NewUncoveredPointLot returns a lot that never expires for the part of
+// amount the allocations do not cover, or nil when they cover all of it. Only
+// trades reserved before lots existed leave anything uncovered.
+func NewUncoveredPointLot(accountID int64, transactionID string, amount valueobject.Money, allocations []*LotAllocation) *PointLot {
+	uncovered := amount.Value().Sub(SumLotAllocations(allocations).Value())
+	if !uncovered.IsPositive() {
+		return nil
+	}
+	return NewPointLot(accountID, transactionID, valueobject.NewMoney(uncovered, amount.Asset()), nil)
+}
+
+func SumLotAllocations(allocations []*LotAllocation) valueobject.Money {
+	total := decimal.Zero
+	asset := valueobject.DefaultAssetCode
+	for _, allocation := range allocations {
+		total = total.Add(allocation.Amount.Value())
+		asset = allocation.Amount.Asset()
+	}
+	return valueobject.NewMoney(total, asset)
+}
+
+func (l *PointLot) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+// SortPointLots orders lots soonest-expiring first, lots without expiry last
+// and older lots before newer ones.
+func SortPointLots(lots []*PointLot) {
+	sort.SliceStable(lots, func(i, j int) bool {
+		a, b := lots[i], lots[j]
+		switch {
+		case a.ExpiresAt == nil && b.ExpiresAt == nil:
+			return a.ID < b.ID
+		case a.ExpiresAt == nil:
+			return false
+		case b.ExpiresAt == nil:
+			return true
+		case !a.ExpiresAt.Equal(*b.ExpiresAt):
+			return a.ExpiresAt.Before(*b.ExpiresAt)
+		default:
+			return a.ID < b.ID
+		}
+	})
+}
+
+// takeFromLots consumes amount from unexpired lots in spending order. Reserved
+// takes stay on the lot until the trade settles or is released.
+func takeFromLots(lots []*PointLot, amount valueobject.Money, now time.Time, reserve bool) []*LotAllocation {
+	SortPointLots(lots)
+
+	var allocations []*LotAllocation
+	left := amount.Value()
+	for _, lot := range lots {
+		if !left.IsPositive() {
+			break
+		}
+		if lot.IsExpired(now) || !lot.RemainingAmount.Value().IsPositive() {
+			continue
+		}
+
+		take := decimal.Min(left, lot.RemainingAmount.Value())
+		lot.RemainingAmount = valueobject.NewMoney(lot.RemainingAmount.Value().Sub(take), amount.Asset())
+		if reserve {
+			lot.ReservedAmount = valueobject.NewMoney(lot.ReservedAmount.Value().Add(take), amount.Asset())
+		}
+		allocations = append(allocations, &LotAllocation{
+			LotID:     lot.ID,
+			Amount:    valueobject.NewMoney(take, amount.Asset()),
+			ExpiresAt: lot.ExpiresAt,
+		})
+		left = left.Sub(take)
+	}
+	return allocations
+}
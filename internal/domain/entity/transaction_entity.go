@@ -7,18 +7,35 @@ import (
 )
 
 type TradeRecords struct {
-	TransactionID string
-	Nonce         int64
-	FromAccountID int64
-	ToAccountID   int64
-	Amount        valueobject.Money
-	Status        int32
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	events        []event.TransactionEvent
+	TransactionID     string
+	Nonce             int64
+	FromAccountID     int64
+	ToAccountID       int64
+	Amount            valueobject.Money
+	Status            int32
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	ExpiredAt         *time.Time
+	TradeType         valueobject.TradeType
+	ReasonCode        string
+	ExternalReference string
+	events            []event.TransactionEvent
+}
+
+// Type reports the kind of trade, treating records that predate trade types as transfers.
+func (t *TradeRecords) Type() valueobject.TradeType {
+	if t.TradeType == "" {
+		return valueobject.TradeTypeTransfer
+	}
+	return t.TradeType
+}
+
+func (t *TradeRecords) IsExpired(now time.Time) bool {
+	return t.ExpiredAt != nil && !now.Before(*t.ExpiredAt)
 }
 
 func (t *TradeRecords) Transfer() {
+	t.TradeType = valueobject.TradeTypeTransfer
 	t.Status = int32(valueobject.TccPending)
 	t.events = append(t.events, event.TransactionEvent{
 		TransactionID: t.TransactionID,
@@ -26,6 +43,7 @@ func (t *TradeRecords) Transfer() {
 		FromAccountID: t.FromAccountID,
 		ToAccountID:   t.ToAccountID,
 		Amount:        t.Amount,
+		AssetCode:     t.Amount.Asset(),
 	})
 }
 
@@ -37,10 +55,11 @@ func (t *TradeRecords) Confirm() {
 		FromAccountID: t.FromAccountID,
 		ToAccountID:   t.ToAccountID,
 		Amount:        t.Amount,
+		AssetCode:     t.Amount.Asset(),
 	})
 }
 
-func (t *TradeRecords) Cancel() {
+func (t *TradeRecords) Cancel(reason valueobject.CancelReason) {
 	t.Status = int32(valueobject.TccCanceled)
 	t.events = append(t.events, event.TransactionEvent{
 		TransactionID: t.TransactionID,
@@ -48,6 +67,36 @@ func (t *TradeRecords) Cancel() {
 		FromAccountID: t.FromAccountID,
 		ToAccountID:   t.ToAccountID,
 		Amount:        t.Amount,
+		AssetCode:     t.Amount.Asset(),
+		Reason:        reason.String(),
+	})
+}
+
+func (t *TradeRecords) Mint() {
+	t.settleAdjustment(valueobject.TradeTypeMint, valueobject.TradeTypeMint.String())
+}
+
+func (t *TradeRecords) Burn() {
+	t.settleAdjustment(valueobject.TradeTypeBurn, valueobject.TradeTypeBurn.String())
+}
+
+// Expire settles the burn of expired lots and emits an "expired" event.
+func (t *TradeRecords) Expire() {
+	t.settleAdjustment(valueobject.TradeTypeExpire, "expired")
+}
+
+func (t *TradeRecords) settleAdjustment(tradeType valueobject.TradeType, action string) {
+	t.TradeType = tradeType
+	t.Status = int32(valueobject.TccConfirmed)
+	t.events = append(t.events, event.TransactionEvent{
+		TransactionID:     t.TransactionID,
+		Action:            action,
+		FromAccountID:     t.FromAccountID,
+		ToAccountID:       t.ToAccountID,
+		Amount:            t.Amount,
+		AssetCode:         t.Amount.Asset(),
+		ReasonCode:        t.ReasonCode,
+		ExternalReference: t.ExternalReference,
 	})
 }
 
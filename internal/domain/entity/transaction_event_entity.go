@@ -10,4 +10,5 @@ type TransactionEvent struct {
 	EventType     string
 	Payload       string
 	CreatedAt     time.Time
+	PublishedAt   *time.Time
 }
@@ -5,11 +5,15 @@ import "points/internal/domain/valueobject"
 const TransactionEventType = "TransactionEvent"
 
 type TransactionEvent struct {
-	TransactionID string
-	Action        string
-	FromAccountID int64
-	ToAccountID   int64
-	Amount        valueobject.Money
+	TransactionID     string
+	Action            string
+	FromAccountID     int64
+	ToAccountID       int64
+	Amount            valueobject.Money
+	AssetCode         valueobject.AssetCode
+	Reason            string `json:",omitempty"`
+	ReasonCode        string `json:",omitempty"`
+	ExternalReference string `json:",omitempty"`
 }
 
 func (e TransactionEvent) EventType() string {
@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"context"
+	"points/internal/domain/entity"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entity.TransactionEvent) error
+}
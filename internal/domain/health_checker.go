@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// HealthChecker probes one dependency the service needs to serve trades.
+type HealthChecker interface {
+	Name() string
+	Check(ctx context.Context) error
+}
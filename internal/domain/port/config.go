@@ -8,4 +8,5 @@ type Config interface {
 	SetDefault(req interface{}) error
 	SetDefaultInt(key string, value int)
 	Copy(dst, src interface{}) error
+	Subscribe(fn func(ConfigChange))
 }
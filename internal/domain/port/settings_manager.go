@@ -1,9 +1,20 @@
 package port
 
+// ConfigChange lists the keys a config reload changed. RestartRequired holds
+// the changed keys that are only read at startup, and Err is set when the
+// reload failed and the previous settings were kept.
+type ConfigChange struct {
+	Keys            []string
+	RestartRequired []string
+	Err             error
+}
+
 type SettingsManager interface {
 	SetDefault(key string, value interface{})
 	GetString(key string) string
 	GetInt(key string) int
 	Sub(key string) SettingsManager
 	Unmarshal(out interface{}) error
+	Subscribe(fn func(ConfigChange))
+	WatchConfig()
 }
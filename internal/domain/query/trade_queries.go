@@ -0,0 +1,32 @@
+package query
+
+import (
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+	"time"
+)
+
+type GetTradeQuery struct {
+	From  int64
+	Nonce int64
+}
+
+type ListAccountTradesQuery struct {
+	AccountID int64
+	AssetCode *valueobject.AssetCode
+	Status    *valueobject.TccStatus
+	StartTime *time.Time
+	EndTime   *time.Time
+	Cursor    string
+	Limit     int
+}
+
+type TradeDetail struct {
+	Trade  *entity.TradeRecords
+	Events []*entity.TransactionEvent
+}
+
+type TradePage struct {
+	Trades     []*entity.TradeRecords
+	NextCursor string
+}
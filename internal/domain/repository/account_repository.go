@@ -2,13 +2,28 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"points/internal/domain/entity"
 	"points/internal/domain/valueobject"
 )
 
+// ErrStaleFencingToken is returned for a write whose ctx carries a fencing
+// token older than the last one recorded on the account.
+var ErrStaleFencingToken = errors.New("stale fencing token")
+
+// ErrAccountNotFound is returned for a balance update that matched no account.
+var ErrAccountNotFound = errors.New("account not found")
+
 type AccountRepository interface {
-	CreateAccount(ctx context.Context, userID int64) error
-	GetAccount(ctx context.Context, userID int64) (*entity.Account, error)
+	CreateAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) error
+	GetAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error)
+	// GetAccountForUpdate reads the account and locks its row until the
+	// surrounding transaction ends.
+	GetAccountForUpdate(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error)
+	ListAccounts(ctx context.Context) ([]*entity.Account, error)
+	ListUserAccounts(ctx context.Context, userID int64) ([]*entity.Account, error)
 	ReserveBalance(ctx context.Context, userID int64, amount valueobject.Money) error
 	UnreserveBalance(ctx context.Context, from, to int64, amount valueobject.Money) error
+	CreditBalance(ctx context.Context, userID int64, amount valueobject.Money) error
+	DebitBalance(ctx context.Context, userID int64, amount valueobject.Money) error
 }
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+)
+
+type LedgerRepository interface {
+	CreateLedgerEntries(ctx context.Context, entries []*entity.LedgerEntry) error
+	GetLedgerEntries(ctx context.Context, transactionID string) ([]*entity.LedgerEntry, error)
+	GetLedgerBalance(ctx context.Context, accountID int64, asset valueobject.AssetCode, bucket valueobject.LedgerBucket) (valueobject.Money, error)
+}
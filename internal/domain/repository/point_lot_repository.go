@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+	"time"
+)
+
+type PointLotRepository interface {
+	CreatePointLots(ctx context.Context, lots []*entity.PointLot) error
+	// ListPointLots returns the lots of an account that still hold a balance, soonest-expiring first.
+	ListPointLots(ctx context.Context, accountID int64, asset valueobject.AssetCode) ([]*entity.PointLot, error)
+	// ListExpiredPointLots returns lots past their expiry that still hold a balance, oldest expiry first.
+	ListExpiredPointLots(ctx context.Context, now time.Time, limit int) ([]*entity.PointLot, error)
+	ReservePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error
+	GetLotAllocations(ctx context.Context, transactionID string) ([]*entity.LotAllocation, error)
+	// ReleasePointLots returns the allocations of a canceled transfer to their lots.
+	ReleasePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error
+	// SettlePointLots drops the allocations of a confirmed transfer from their lots.
+	SettlePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error
+	ConsumePointLots(ctx context.Context, allocations []*entity.LotAllocation) error
+}
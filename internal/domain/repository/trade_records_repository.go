@@ -4,11 +4,38 @@ import (
 	"context"
 	"points/internal/domain/entity"
 	"points/internal/domain/valueobject"
+	"time"
 )
 
+type TradeRecordCursor struct {
+	CreatedAt     time.Time
+	FromAccountID int64
+	Nonce         int64
+}
+
+type TradeRecordFilter struct {
+	AccountID int64
+	AssetCode *valueobject.AssetCode
+	Status    *valueobject.TccStatus
+	StartTime *time.Time
+	EndTime   *time.Time
+	After     *TradeRecordCursor
+	Limit     int
+}
+
+// AccountAsset identifies the balance of one asset held by an account.
+type AccountAsset struct {
+	AccountID int64
+	AssetCode valueobject.AssetCode
+}
+
 type TradeRecordsRepository interface {
 	CreateTradeRecord(ctx context.Context, trans *entity.TradeRecords) error
 	CreateOrUpdateTradeRecord(ctx context.Context, trans *entity.TradeRecords) error
 	UpdateTradeRecord(ctx context.Context, trans *entity.TradeRecords) error
 	GetTradeRecord(ctx context.Context, nonce, from int64, status *valueobject.TccStatus) (*entity.TradeRecords, error)
+	GetExpiredTradeRecords(ctx context.Context, deadline time.Time, limit int) ([]*entity.TradeRecords, error)
+	ListTradeRecords(ctx context.Context, filter TradeRecordFilter) ([]*entity.TradeRecords, error)
+	SumPendingAmountsBySender(ctx context.Context) (map[AccountAsset]valueobject.Money, error)
+	GetConfirmedTradeRecordsWithoutEvent(ctx context.Context) ([]*entity.TradeRecords, error)
 }
@@ -3,8 +3,12 @@ package repository
 import (
 	"context"
 	"points/internal/domain/entity"
+	"time"
 )
 
 type TransactionEventRepository interface {
 	CreateTransactionEvent(ctx context.Context, event *entity.TransactionEvent) error
+	GetTransactionEvents(ctx context.Context, transactionID string) ([]*entity.TransactionEvent, error)
+	GetUnpublishedTransactionEvents(ctx context.Context, limit int) ([]*entity.TransactionEvent, error)
+	MarkTransactionEventPublished(ctx context.Context, id int32, publishedAt time.Time) error
 }
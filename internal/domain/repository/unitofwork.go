@@ -6,5 +6,10 @@ type UnitOfWork interface {
 	AccountRepository() AccountRepository
 	TradeRecordsRepository() TradeRecordsRepository
 	TransactionEventRepository() TransactionEventRepository
+	LedgerRepository() LedgerRepository
+	PointLotRepository() PointLotRepository
 	Transaction(context.Context, func(UnitOfWork) error) error
+	// SnapshotTransaction runs fn in a read-only REPEATABLE READ transaction,
+	// so every read inside it sees the same snapshot.
+	SnapshotTransaction(context.Context, func(UnitOfWork) error) error
 }
@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"context"
+	"points/internal/domain/query"
+)
+
+type TradeQueryUsecase interface {
+	GetTrade(ctx context.Context, req *query.GetTradeQuery) (*query.TradeDetail, error)
+	ListAccountTrades(ctx context.Context, req *query.ListAccountTradesQuery) (*query.TradePage, error)
+}
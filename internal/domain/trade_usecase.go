@@ -3,10 +3,11 @@ package domain
 import (
 	"context"
 	"points/internal/domain/command"
+	"points/internal/domain/entity"
 )
 
 type TradeUsecase interface {
-	Transfer(ctx context.Context, req *command.TransferCommand) error
-	ManualConfirm(ctx context.Context, req *command.ConfirmCommand) error
-	Cancel(ctx context.Context, req *command.CancelCommand) error
+	Transfer(ctx context.Context, req *command.TransferCommand) (*entity.TradeRecords, error)
+	ManualConfirm(ctx context.Context, req *command.ConfirmCommand) (*entity.TradeRecords, error)
+	Cancel(ctx context.Context, req *command.CancelCommand) (*entity.TradeRecords, error)
 }
@@ -0,0 +1,44 @@
+package valueobject
+
+import (
+	"fmt"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"regexp"
+	"strings"
+)
+
+// AssetCode identifies a point program, e.g. reward points or cashback credits.
+type AssetCode string
+
+// DefaultAssetCode is the asset of balances and trades that predate multi-asset support.
+const DefaultAssetCode AssetCode = "POINTS"
+
+var assetCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,15}$`)
+
+// NewAssetCode normalises s to upper case, falling back to DefaultAssetCode when empty.
+func NewAssetCode(s string) (AssetCode, error) {
+	code := AssetCode(strings.ToUpper(strings.TrimSpace(s))).OrDefault()
+	if err := code.Validate(); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+func (a AssetCode) OrDefault() AssetCode {
+	if a == "" {
+		return DefaultAssetCode
+	}
+	return a
+}
+
+func (a AssetCode) Validate() error {
+	if !assetCodePattern.MatchString(string(a)) {
+		return apperror.Wrap(errcode.ErrInvalidRequest, "invalid asset code", fmt.Errorf("asset code %q must be 1-16 upper case letters, digits or underscores", string(a)))
+	}
+	return nil
+}
+
+func (a AssetCode) String() string {
+	return string(a)
+}
@@ -0,0 +1,12 @@
+package valueobject
+
+type CancelReason string
+
+const (
+	CancelReasonClient  CancelReason = "client"
+	CancelReasonTimeout CancelReason = "timeout"
+)
+
+func (r CancelReason) String() string {
+	return string(r)
+}
@@ -0,0 +1,10 @@
+package valueobject
+
+import "time"
+
+// Now returns the current time in UTC. Deadlines and expiry times live in
+// TIMESTAMP WITHOUT TIME ZONE columns, which store the wall clock they are
+// given and are read back as UTC, so they are written and compared in UTC.
+func Now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,16 @@
+package valueobject
+
+import "context"
+
+type fencingTokenContextKey struct{}
+
+// WithFencingToken marks writes made with ctx as coming from the holder of a
+// lock with the given fencing token.
+func WithFencingToken(ctx context.Context, token int64) context.Context {
+	return context.WithValue(ctx, fencingTokenContextKey{}, token)
+}
+
+func FencingTokenFromContext(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(fencingTokenContextKey{}).(int64)
+	return token, ok && token != 0
+}
@@ -0,0 +1,30 @@
+package valueobject
+
+const SystemAccountID int64 = 0
+
+type LedgerBucket string
+
+const (
+	LedgerBucketAvailable LedgerBucket = "available"
+	LedgerBucketReserved  LedgerBucket = "reserved"
+	LedgerBucketIssuance  LedgerBucket = "issuance"
+)
+
+type LedgerEntryType string
+
+const (
+	LedgerEntryReserve LedgerEntryType = "reserve"
+	LedgerEntryRelease LedgerEntryType = "release"
+	LedgerEntryCredit  LedgerEntryType = "credit"
+	LedgerEntryDebit   LedgerEntryType = "debit"
+	LedgerEntryMint    LedgerEntryType = "mint"
+	LedgerEntryBurn    LedgerEntryType = "burn"
+	LedgerEntryExpire  LedgerEntryType = "expire"
+)
+
+type LedgerDirection string
+
+const (
+	LedgerDebit  LedgerDirection = "debit"
+	LedgerCredit LedgerDirection = "credit"
+)
@@ -1,47 +1,74 @@
 package valueobject
 
 import (
+	"fmt"
 	"points/internal/shared/apperror"
 	"points/internal/shared/errcode"
 
 	"github.com/shopspring/decimal"
 )
 
+// Money is an amount of a single asset. An empty asset is treated as DefaultAssetCode.
 type Money struct {
 	value decimal.Decimal
+	asset AssetCode
 }
 
-var Zero Money = Money{value: decimal.NewFromInt(0)}
+var Zero Money = Money{value: decimal.NewFromInt(0), asset: DefaultAssetCode}
 
 func NewMoneyFromString(s string) (Money, error) {
 	d, err := decimal.NewFromString(s)
 	if err != nil {
 		return Money{}, apperror.Wrap(errcode.ErrInvalidRequest, "invalid money value", err)
 	}
-	return Money{value: d}, nil
+	return Money{value: d, asset: DefaultAssetCode}, nil
 }
 
 func NewMoneyFromDecimal(d decimal.Decimal) Money {
-	return Money{value: d}
+	return Money{value: d, asset: DefaultAssetCode}
 }
 
-func (m Money) Add(other Money) Money {
-	return Money{value: m.value.Add(other.value)}
+func NewMoney(d decimal.Decimal, asset AssetCode) Money {
+	return Money{value: d, asset: asset.OrDefault()}
 }
-func (m Money) Sub(other Money) Money {
-	return Money{value: m.value.Sub(other.value)}
+
+func ZeroOf(asset AssetCode) Money {
+	return NewMoney(decimal.Zero, asset)
+}
+
+func (m Money) Asset() AssetCode {
+	return m.asset.OrDefault()
+}
+
+// WithAsset returns the same amount denominated in asset.
+func (m Money) WithAsset(asset AssetCode) Money {
+	return NewMoney(m.value, asset)
 }
 
-func (m Money) Subtract(other Money) Money {
-	return Money{value: m.value.Sub(other.value)}
+func (m Money) Add(other Money) (Money, error) {
+	if err := m.checkAsset(other); err != nil {
+		return Money{}, err
+	}
+	return Money{value: m.value.Add(other.value), asset: m.Asset()}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if err := m.checkAsset(other); err != nil {
+		return Money{}, err
+	}
+	return Money{value: m.value.Sub(other.value), asset: m.Asset()}, nil
+}
+
+func (m Money) Subtract(other Money) (Money, error) {
+	return m.Sub(other)
 }
 
 func (m Money) Multiply(factor decimal.Decimal) Money {
-	return Money{value: m.value.Mul(factor)}
+	return Money{value: m.value.Mul(factor), asset: m.Asset()}
 }
 
 func (m Money) Equals(other Money) bool {
-	return m.value.Equal(other.value)
+	return m.Asset() == other.Asset() && m.value.Equal(other.value)
 }
 
 func (m Money) Value() decimal.Decimal {
@@ -52,18 +79,32 @@ func (m Money) String() string {
 	return m.value.String()
 }
 
-func (m Money) Cmp(other Money) int {
-	return m.value.Cmp(other.value)
+// Cmp compares two amounts of the same asset and refuses mixed assets like
+// Add and Sub do.
+func (m Money) Cmp(other Money) (int, error) {
+	if err := m.checkAsset(other); err != nil {
+		return 0, err
+	}
+	return m.value.Cmp(other.value), nil
 }
 
+// LessThan is false when the assets differ, since they cannot be ordered.
 func (m Money) LessThan(other Money) bool {
-	return m.value.LessThan(other.value)
+	return m.Asset() == other.Asset() && m.value.LessThan(other.value)
 }
 
+// GreaterThan is false when the assets differ, since they cannot be ordered.
 func (m Money) GreaterThan(other Money) bool {
-	return m.value.GreaterThan(other.value)
+	return m.Asset() == other.Asset() && m.value.GreaterThan(other.value)
 }
 
 func (m Money) MarshalJSON() ([]byte, error) {
 	return m.value.MarshalJSON()
 }
+
+func (m Money) checkAsset(other Money) error {
+	if m.Asset() != other.Asset() {
+		return apperror.Wrap(errcode.ErrAssetMismatch, "money arithmetic", fmt.Errorf("cannot combine %s with %s", m.Asset(), other.Asset()))
+	}
+	return nil
+}
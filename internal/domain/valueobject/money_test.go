@@ -0,0 +1,80 @@
+package valueobject
+
+import (
+	"errors"
+	"testing"
+
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoneyRefusesCrossAssetArithmetic(t *testing.T) {
+	points := NewMoneyFromDecimal(decimal.NewFromInt(10))
+	cashback := NewMoney(decimal.NewFromInt(4), "CASHBACK")
+
+	sum, err := points.Add(NewMoneyFromDecimal(decimal.NewFromInt(5)))
+	assert.NoError(t, err)
+	assert.True(t, sum.Equals(NewMoneyFromDecimal(decimal.NewFromInt(15))))
+
+	_, err = points.Add(cashback)
+	var appErr *apperror.AppError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, errcode.ErrAssetMismatch, appErr.Code)
+	}
+
+	_, err = cashback.Sub(points)
+	assert.Error(t, err)
+
+	assert.False(t, points.Equals(NewMoney(decimal.NewFromInt(10), "CASHBACK")), "equal amounts of different assets differ")
+	assert.True(t, Money{}.Equals(Zero), "an unset asset is the default asset")
+}
+
+func TestMoneyComparesOnlyTheSameAsset(t *testing.T) {
+	points := NewMoneyFromDecimal(decimal.NewFromInt(10))
+	cashback := NewMoney(decimal.NewFromInt(4), "CASHBACK")
+
+	assert.True(t, points.GreaterThan(NewMoneyFromDecimal(decimal.NewFromInt(4))))
+	assert.True(t, cashback.LessThan(NewMoney(decimal.NewFromInt(10), "CASHBACK")))
+	assert.False(t, points.GreaterThan(cashback), "different assets cannot be ordered")
+	assert.False(t, cashback.LessThan(points), "different assets cannot be ordered")
+
+	cmp, err := points.Cmp(NewMoneyFromDecimal(decimal.NewFromInt(4)))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cmp)
+
+	_, err = points.Cmp(cashback)
+	var appErr *apperror.AppError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, errcode.ErrAssetMismatch, appErr.Code)
+	}
+}
+
+func TestNewAssetCode(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected AssetCode
+		wantErr  bool
+	}{
+		{input: "", expected: DefaultAssetCode},
+		{input: "cashback", expected: "CASHBACK"},
+		{input: " miles_2 ", expected: "MILES_2"},
+		{input: "1POINTS", wantErr: true},
+		{input: "CASH-BACK", wantErr: true},
+		{input: "ABCDEFGHIJKLMNOPQ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NewAssetCode(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
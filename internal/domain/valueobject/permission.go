@@ -0,0 +1,17 @@
+package valueobject
+
+type Permission string
+
+const (
+	PermissionTradeWrite     Permission = "trade:write"
+	PermissionTradeRead      Permission = "trade:read"
+	PermissionTradeCancelAny Permission = "trade:cancel_any"
+	PermissionAccountRead    Permission = "account:read"
+	PermissionAccountReadAny Permission = "account:read_any"
+	PermissionPointsMint     Permission = "points:mint"
+	PermissionPointsBurn     Permission = "points:burn"
+)
+
+func (p Permission) String() string {
+	return string(p)
+}
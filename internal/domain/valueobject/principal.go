@@ -0,0 +1,71 @@
+package valueobject
+
+import "context"
+
+type PrincipalKind string
+
+const (
+	PrincipalKindUser    PrincipalKind = "user"
+	PrincipalKindService PrincipalKind = "service"
+)
+
+// Principal is the authenticated caller of a request. Permissions stay empty
+// until the access policy has resolved the principal's roles.
+type Principal struct {
+	Subject     string
+	Kind        PrincipalKind
+	AccountID   int64
+	Roles       []string
+	Permissions []Permission
+}
+
+// RoleNames returns the principal's roles, falling back to its kind.
+func (p *Principal) RoleNames() []string {
+	if len(p.Roles) == 0 {
+		return []string{string(p.Kind)}
+	}
+	return p.Roles
+}
+
+func (p *Principal) HasPermission(permission Permission) bool {
+	if p == nil {
+		return false
+	}
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// WithPermissions returns a copy of the principal holding permissions.
+func (p *Principal) WithPermissions(permissions []Permission) *Principal {
+	resolved := *p
+	resolved.Permissions = permissions
+	return &resolved
+}
+
+// CanActFor reports whether the principal may move points out of accountID
+// or read its balance and trades.
+// Service principals may act on any account, users only on their own.
+func (p *Principal) CanActFor(accountID int64) bool {
+	if p == nil {
+		return false
+	}
+	if p.Kind == PrincipalKindService {
+		return true
+	}
+	return p.AccountID != 0 && p.AccountID == accountID
+}
+
+type principalContextKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
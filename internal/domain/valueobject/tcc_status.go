@@ -1,5 +1,11 @@
 package valueobject
 
+import (
+	"fmt"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+)
+
 type TccStatus int32
 
 const (
@@ -21,6 +27,19 @@ func (s TccStatus) String() string {
 	}
 }
 
+func ParseTccStatus(s string) (TccStatus, error) {
+	switch s {
+	case TccPending.String():
+		return TccPending, nil
+	case TccConfirmed.String():
+		return TccConfirmed, nil
+	case TccCanceled.String():
+		return TccCanceled, nil
+	default:
+		return 0, apperror.Wrap(errcode.ErrInvalidRequest, "invalid tcc status", fmt.Errorf("unknown status %q", s))
+	}
+}
+
 func (s TccStatus) Ptr() *TccStatus {
 	return &s
 }
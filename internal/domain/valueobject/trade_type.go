@@ -0,0 +1,14 @@
+package valueobject
+
+type TradeType string
+
+const (
+	TradeTypeTransfer TradeType = "transfer"
+	TradeTypeMint     TradeType = "mint"
+	TradeTypeBurn     TradeType = "burn"
+	TradeTypeExpire   TradeType = "expire"
+)
+
+func (t TradeType) String() string {
+	return string(t)
+}
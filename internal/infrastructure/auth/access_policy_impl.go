@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/valueobject"
+	"strings"
+)
+
+// RBACConfig maps role names to the permissions they grant.
+type RBACConfig struct {
+	Roles map[string][]string `mapstructure:"RBAC_ROLES"`
+}
+
+type accessPolicyImpl struct {
+	roles map[string][]valueobject.Permission
+}
+
+var _ domain.AccessPolicy = (*accessPolicyImpl)(nil)
+
+func NewAccessPolicy(config port.Config) (domain.AccessPolicy, error) {
+	var cfg RBACConfig
+	if v := config.Sub("rbac"); v != nil {
+		if err := v.Unmarshal(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rbac config: %w", err)
+		}
+	}
+	return newAccessPolicy(cfg), nil
+}
+
+// newAccessPolicy lower-cases role names because viper does the same for
+// map keys read from YAML.
+func newAccessPolicy(cfg RBACConfig) *accessPolicyImpl {
+	policy := &accessPolicyImpl{roles: make(map[string][]valueobject.Permission, len(cfg.Roles))}
+	for role, permissions := range cfg.Roles {
+		granted := make([]valueobject.Permission, 0, len(permissions))
+		for _, permission := range permissions {
+			granted = append(granted, valueobject.Permission(strings.TrimSpace(permission)))
+		}
+		policy.roles[strings.ToLower(role)] = granted
+	}
+	return policy
+}
+
+// Permissions unions the permissions of every role the principal holds;
+// unknown roles grant nothing.
+func (p *accessPolicyImpl) Permissions(principal *valueobject.Principal) []valueobject.Permission {
+	seen := make(map[valueobject.Permission]struct{})
+	permissions := make([]valueobject.Permission, 0)
+	for _, role := range principal.RoleNames() {
+		for _, permission := range p.roles[strings.ToLower(role)] {
+			if _, ok := seen[permission]; ok {
+				continue
+			}
+			seen[permission] = struct{}{}
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"testing"
+
+	"points/internal/domain/valueobject"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessPolicyPermissions(t *testing.T) {
+	policy := newAccessPolicy(RBACConfig{Roles: map[string][]string{
+		"user":    {"trade:write", "trade:read"},
+		"auditor": {"trade:read", "account:read"},
+		"Admin":   {"points:mint"},
+	}})
+
+	tests := []struct {
+		name      string
+		principal *valueobject.Principal
+		expected  []valueobject.Permission
+	}{
+		{
+			name:      "falls back to the kind role",
+			principal: &valueobject.Principal{Kind: valueobject.PrincipalKindUser},
+			expected:  []valueobject.Permission{valueobject.PermissionTradeWrite, valueobject.PermissionTradeRead},
+		},
+		{
+			name:      "unions roles without duplicates",
+			principal: &valueobject.Principal{Kind: valueobject.PrincipalKindUser, Roles: []string{"user", "auditor"}},
+			expected:  []valueobject.Permission{valueobject.PermissionTradeWrite, valueobject.PermissionTradeRead, valueobject.PermissionAccountRead},
+		},
+		{
+			name:      "role names ignore case",
+			principal: &valueobject.Principal{Kind: valueobject.PrincipalKindService, Roles: []string{"ADMIN"}},
+			expected:  []valueobject.Permission{valueobject.PermissionPointsMint},
+		},
+		{
+			name:      "unknown role grants nothing",
+			principal: &valueobject.Principal{Kind: valueobject.PrincipalKindService},
+			expected:  []valueobject.Permission{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Permissions(tt.principal))
+		})
+	}
+}
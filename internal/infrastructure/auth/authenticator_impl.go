@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type AuthConfig struct {
+	JWTAlgorithm string         `mapstructure:"AUTH_JWT_ALGORITHM" validate:"omitempty,oneof=HS256 HS384 HS512 RS256 RS384 RS512"`
+	JWTSecret    string         `mapstructure:"AUTH_JWT_SECRET"`
+	JWTPublicKey string         `mapstructure:"AUTH_JWT_PUBLIC_KEY"`
+	JWTIssuer    string         `mapstructure:"AUTH_JWT_ISSUER"`
+	JWTAudience  string         `mapstructure:"AUTH_JWT_AUDIENCE"`
+	APIKeys      []APIKeyConfig `mapstructure:"AUTH_API_KEYS" validate:"dive"`
+}
+
+type APIKeyConfig struct {
+	Key       string   `mapstructure:"KEY" validate:"required"`
+	Subject   string   `mapstructure:"SUBJECT" validate:"required"`
+	Kind      string   `mapstructure:"KIND" validate:"omitempty,oneof=user service"`
+	AccountID int64    `mapstructure:"ACCOUNT_ID"`
+	Roles     []string `mapstructure:"ROLES"`
+}
+
+// claims carries the principal of a bearer token. Tokens without account_id
+// use a numeric subject as the account.
+type claims struct {
+	jwt.RegisteredClaims
+	Kind      string   `json:"kind,omitempty"`
+	AccountID int64    `json:"account_id,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+type authenticatorImpl struct {
+	method    jwt.SigningMethod
+	key       interface{}
+	parseOpts []jwt.ParserOption
+	apiKeys   map[[sha256.Size]byte]*valueobject.Principal
+}
+
+var _ domain.Authenticator = (*authenticatorImpl)(nil)
+
+func NewAuthenticator(config port.Config) (domain.Authenticator, error) {
+	var cfg AuthConfig
+	if v := config.Sub("auth"); v != nil {
+		if err := v.Unmarshal(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal auth config: %w", err)
+		}
+	}
+	if err := validator.New().Struct(cfg); err != nil {
+		return nil, fmt.Errorf("failed to validate auth config: %w", err)
+	}
+	return newAuthenticator(cfg)
+}
+
+func newAuthenticator(cfg AuthConfig) (*authenticatorImpl, error) {
+	a := &authenticatorImpl{apiKeys: make(map[[sha256.Size]byte]*valueobject.Principal, len(cfg.APIKeys))}
+
+	if cfg.JWTAlgorithm != "" {
+		a.method = jwt.GetSigningMethod(cfg.JWTAlgorithm)
+		switch a.method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if cfg.JWTSecret == "" {
+				return nil, errors.New("auth config: AUTH_JWT_SECRET is required for " + cfg.JWTAlgorithm)
+			}
+			a.key = []byte(cfg.JWTSecret)
+		case *jwt.SigningMethodRSA:
+			key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(cfg.JWTPublicKey))
+			if err != nil {
+				return nil, fmt.Errorf("auth config: invalid AUTH_JWT_PUBLIC_KEY: %w", err)
+			}
+			a.key = key
+		}
+		a.parseOpts = []jwt.ParserOption{jwt.WithValidMethods([]string{cfg.JWTAlgorithm}), jwt.WithExpirationRequired()}
+		if cfg.JWTIssuer != "" {
+			a.parseOpts = append(a.parseOpts, jwt.WithIssuer(cfg.JWTIssuer))
+		}
+		if cfg.JWTAudience != "" {
+			a.parseOpts = append(a.parseOpts, jwt.WithAudience(cfg.JWTAudience))
+		}
+	}
+
+	for _, apiKey := range cfg.APIKeys {
+		a.apiKeys[sha256.Sum256([]byte(apiKey.Key))] = &valueobject.Principal{
+			Subject:   apiKey.Subject,
+			Kind:      principalKind(apiKey.Kind),
+			AccountID: apiKey.AccountID,
+			Roles:     apiKey.Roles,
+		}
+	}
+	return a, nil
+}
+
+func (a *authenticatorImpl) AuthenticateToken(ctx context.Context, token string) (*valueobject.Principal, error) {
+	if a.method == nil {
+		return nil, unauthorized("bearer tokens are not enabled")
+	}
+
+	var c claims
+	if _, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return a.key, nil
+	}, a.parseOpts...); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUnauthorized, "authenticate token", err)
+	}
+
+	principal := &valueobject.Principal{
+		Subject:   c.Subject,
+		Kind:      principalKind(c.Kind),
+		AccountID: c.AccountID,
+		Roles:     c.Roles,
+	}
+	if principal.AccountID == 0 && principal.Kind == valueobject.PrincipalKindUser {
+		accountID, err := strconv.ParseInt(c.Subject, 10, 64)
+		if err != nil {
+			return nil, unauthorized("token does not name an account")
+		}
+		principal.AccountID = accountID
+	}
+	return principal, nil
+}
+
+// AuthenticateAPIKey looks keys up by their hash so the lookup time reveals
+// nothing about the configured keys.
+func (a *authenticatorImpl) AuthenticateAPIKey(ctx context.Context, key string) (*valueobject.Principal, error) {
+	principal, ok := a.apiKeys[sha256.Sum256([]byte(key))]
+	if key == "" || !ok {
+		return nil, unauthorized("invalid api key")
+	}
+	return principal, nil
+}
+
+func principalKind(kind string) valueobject.PrincipalKind {
+	if strings.EqualFold(kind, string(valueobject.PrincipalKindService)) {
+		return valueobject.PrincipalKindService
+	}
+	return valueobject.PrincipalKindUser
+}
+
+func unauthorized(msg string) error {
+	return apperror.Wrap(errcode.ErrUnauthorized, "authenticate", errors.New(msg))
+}
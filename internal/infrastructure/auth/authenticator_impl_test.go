@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustNoError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, c claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, c).SignedString(key)
+	mustNoError(t, err)
+	return token
+}
+
+func userClaims(subject string, expiresIn time.Duration) claims {
+	return claims{RegisteredClaims: jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    "points-test",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiresIn)),
+	}}
+}
+
+func assertUnauthorized(t *testing.T, err error) {
+	t.Helper()
+	appErr, ok := err.(*apperror.AppError)
+	if assert.True(t, ok, "expected an AppError, got %v", err) {
+		assert.Equal(t, errcode.ErrUnauthorized, appErr.Code)
+	}
+}
+
+func TestAuthenticateToken_HMAC(t *testing.T) {
+	authenticator, err := newAuthenticator(AuthConfig{JWTAlgorithm: "HS256", JWTSecret: "secret", JWTIssuer: "points-test"})
+	mustNoError(t, err)
+	ctx := context.Background()
+
+	principal, err := authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodHS256, []byte("secret"), userClaims("42", time.Minute)))
+	mustNoError(t, err)
+	assert.Equal(t, &valueobject.Principal{Subject: "42", Kind: valueobject.PrincipalKindUser, AccountID: 42}, principal)
+
+	serviceClaims := userClaims("billing", time.Minute)
+	serviceClaims.Kind = "service"
+	principal, err = authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodHS256, []byte("secret"), serviceClaims))
+	mustNoError(t, err)
+	assert.Equal(t, valueobject.PrincipalKindService, principal.Kind)
+
+	_, err = authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodHS256, []byte("other"), userClaims("42", time.Minute)))
+	assertUnauthorized(t, err)
+
+	_, err = authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodHS256, []byte("secret"), userClaims("42", -time.Minute)))
+	assertUnauthorized(t, err)
+
+	_, err = authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodHS256, []byte("secret"), userClaims("alice", time.Minute)))
+	assertUnauthorized(t, err)
+
+	wrongIssuer := userClaims("42", time.Minute)
+	wrongIssuer.Issuer = "someone-else"
+	_, err = authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodHS256, []byte("secret"), wrongIssuer))
+	assertUnauthorized(t, err)
+}
+
+func TestAuthenticateToken_RSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	mustNoError(t, err)
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	mustNoError(t, err)
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})
+
+	authenticator, err := newAuthenticator(AuthConfig{JWTAlgorithm: "RS256", JWTPublicKey: string(publicPEM)})
+	mustNoError(t, err)
+	ctx := context.Background()
+
+	principal, err := authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodRS256, privateKey, userClaims("7", time.Minute)))
+	mustNoError(t, err)
+	assert.Equal(t, int64(7), principal.AccountID)
+
+	_, err = authenticator.AuthenticateToken(ctx, signToken(t, jwt.SigningMethodHS256, publicPEM, userClaims("7", time.Minute)))
+	assertUnauthorized(t, err)
+}
+
+func TestAuthenticateToken_Disabled(t *testing.T) {
+	authenticator, err := newAuthenticator(AuthConfig{})
+	mustNoError(t, err)
+
+	_, err = authenticator.AuthenticateToken(context.Background(), "anything")
+	assertUnauthorized(t, err)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	authenticator, err := newAuthenticator(AuthConfig{APIKeys: []APIKeyConfig{
+		{Key: "service-key", Subject: "billing", Kind: "service"},
+		{Key: "user-key", Subject: "alice", AccountID: 3},
+	}})
+	mustNoError(t, err)
+	ctx := context.Background()
+
+	principal, err := authenticator.AuthenticateAPIKey(ctx, "service-key")
+	mustNoError(t, err)
+	assert.Equal(t, &valueobject.Principal{Subject: "billing", Kind: valueobject.PrincipalKindService}, principal)
+
+	principal, err = authenticator.AuthenticateAPIKey(ctx, "user-key")
+	mustNoError(t, err)
+	assert.Equal(t, &valueobject.Principal{Subject: "alice", Kind: valueobject.PrincipalKindUser, AccountID: 3}, principal)
+
+	_, err = authenticator.AuthenticateAPIKey(ctx, "unknown")
+	assertUnauthorized(t, err)
+}
+
+func TestNewAuthenticator_RejectsIncompleteConfig(t *testing.T) {
+	_, err := newAuthenticator(AuthConfig{JWTAlgorithm: "HS256"})
+	assert.Error(t, err)
+
+	_, err = newAuthenticator(AuthConfig{JWTAlgorithm: "RS256", JWTPublicKey: "not a key"})
+	assert.Error(t, err)
+}
@@ -47,3 +47,7 @@ func (c *ConfigImpl) SetDefaultInt(key string, value int) {
 func (c *ConfigImpl) Copy(dst, src interface{}) error {
 	return c.copier.Copy(dst, src)
 }
+
+func (c *ConfigImpl) Subscribe(fn func(port.ConfigChange)) {
+	c.seetingManager.Subscribe(fn)
+}
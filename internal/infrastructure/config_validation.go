@@ -0,0 +1,79 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"points/internal/domain/port"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ConfigSection names a config section and the struct it decodes into.
+type ConfigSection struct {
+	Name     string
+	Target   interface{}
+	Required bool
+}
+
+// ValidateConfig decodes and validates every section and reports all missing
+// or invalid keys together instead of stopping at the first one.
+func ValidateConfig(config port.Config, sections ...ConfigSection) error {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.SplitN(field.Tag.Get("mapstructure"), ",", 2)[0]
+	})
+
+	var errs []error
+	for _, section := range sections {
+		sub := config.Sub(section.Name)
+		if sub == nil {
+			if section.Required {
+				errs = append(errs, fmt.Errorf("%s: section is missing", section.Name))
+			}
+			continue
+		}
+		if err := sub.Unmarshal(section.Target); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", section.Name, err))
+			continue
+		}
+
+		err := validate.Struct(section.Target)
+		var validationErrs validator.ValidationErrors
+		if !errors.As(err, &validationErrs) {
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", section.Name, err))
+			}
+			continue
+		}
+		for _, fieldErr := range validationErrs {
+			errs = append(errs, fmt.Errorf("%s.%s: %s", section.Name, fieldPath(fieldErr), describe(fieldErr)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fieldPath drops the struct name from the namespace, leaving config keys
+// such as AUTH_API_KEYS[0].KEY.
+func fieldPath(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func describe(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s], got %v", fieldErr.Param(), fieldErr.Value())
+	default:
+		if fieldErr.Param() != "" {
+			return fmt.Sprintf("failed %s=%s, got %v", fieldErr.Tag(), fieldErr.Param(), fieldErr.Value())
+		}
+		return fmt.Sprintf("failed %s, got %v", fieldErr.Tag(), fieldErr.Value())
+	}
+}
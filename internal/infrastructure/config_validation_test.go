@@ -0,0 +1,58 @@
+package infrastructure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPostgresConfig struct {
+	User     string `mapstructure:"POSTGRES_USER" validate:"required"`
+	Password string `mapstructure:"POSTGRES_PASSWORD" validate:"required"`
+	SSLMode  string `mapstructure:"POSTGRES_SSLMODE" validate:"omitempty,oneof=disable require"`
+}
+
+type testRedisConfig struct {
+	Host string `mapstructure:"REDIS_HOST" validate:"required"`
+}
+
+func TestValidateConfig_ReportsEveryProblem(t *testing.T) {
+	t.Setenv("POINTS_CONFIG_PATH", writeTestConfig(t, `
+postgres:
+  POSTGRES_USER: ""
+  POSTGRES_SSLMODE: maybe
+`))
+	settings, err := NewViperImpl("test")
+	assert.NoError(t, err)
+	config := NewConfigImpl(settings, nil, nil)
+
+	err = ValidateConfig(config,
+		ConfigSection{Name: "postgres", Target: &testPostgresConfig{}, Required: true},
+		ConfigSection{Name: "redis", Target: &testRedisConfig{}, Required: true},
+		ConfigSection{Name: "tracing", Target: &testRedisConfig{}},
+	)
+
+	assert.EqualError(t, err, "postgres.POSTGRES_USER: is required\n"+
+		"postgres.POSTGRES_PASSWORD: is required\n"+
+		"postgres.POSTGRES_SSLMODE: must be one of [disable require], got maybe\n"+
+		"redis: section is missing")
+}
+
+type testTccConfig struct {
+	PendingTimeout *int `mapstructure:"TCC_PENDING_TIMEOUT" validate:"omitempty,min=1"`
+	SweepInterval  *int `mapstructure:"TCC_SWEEP_INTERVAL" validate:"omitempty,min=1"`
+}
+
+func TestValidateConfig_ChecksOptionalKeysOnlyWhenSet(t *testing.T) {
+	t.Setenv("POINTS_CONFIG_PATH", writeTestConfig(t, `
+tcc:
+  TCC_SWEEP_INTERVAL: 0
+`))
+	settings, err := NewViperImpl("test")
+	assert.NoError(t, err)
+	config := NewConfigImpl(settings, nil, nil)
+
+	err = ValidateConfig(config, ConfigSection{Name: "tcc", Target: &testTccConfig{}})
+
+	assert.EqualError(t, err, "tcc.TCC_SWEEP_INTERVAL: failed min=1, got 0")
+}
@@ -3,6 +3,8 @@ package dbconnection
 import (
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"points/internal/domain/port"
 	"time"
 
@@ -33,21 +35,26 @@ func NewPostgresConnection(config port.Config) *PostgresConnection {
 	return &PostgresConnection{config: config, validate: validator.New()}
 }
 
-func (d *PostgresConnection) InitPostgresDatabase() (*gorm.DB, error) {
-	cfg, err := d.getPostgresConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get postgres config: %w", err)
+// URL builds a connection URL with the given scheme, e.g. "postgres" for the
+// app or "pgx5" for the migration runner.
+func (c *PostgresConfig) URL(scheme string) string {
+	u := url.URL{
+		Scheme:   scheme,
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, c.Port),
+		Path:     "/" + c.Database,
+		RawQuery: "sslmode=" + url.QueryEscape(c.SSLMode),
 	}
+	return u.String()
+}
 
-	err = d.validate.Struct(cfg)
+func (d *PostgresConnection) InitPostgresDatabase() (*gorm.DB, error) {
+	cfg, err := d.LoadPostgresConfig()
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate postgres config: %w", err)
+		return nil, err
 	}
 
-	dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
-		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Database, cfg.SSLMode)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	db, err := gorm.Open(postgres.Open(cfg.URL("postgres")), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
 	})
 
@@ -89,6 +96,18 @@ func (d *PostgresConnection) MustClose(db *gorm.DB) {
 	}
 }
 
+// LoadPostgresConfig reads and validates the postgres section.
+func (d *PostgresConnection) LoadPostgresConfig() (*PostgresConfig, error) {
+	cfg, err := d.getPostgresConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postgres config: %w", err)
+	}
+	if err := d.validate.Struct(cfg); err != nil {
+		return nil, fmt.Errorf("failed to validate postgres config: %w", err)
+	}
+	return cfg, nil
+}
+
 func (d *PostgresConnection) getPostgresConfig() (*PostgresConfig, error) {
 	var cfg PostgresConfig
 	v := d.config.Sub("postgres")
@@ -0,0 +1,70 @@
+package distributedlock
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// FencingTokenSource issues the fencing tokens of every lock backend. Tokens
+// are recorded on the accounts they guard, so they must keep growing across
+// restarts and backend changes.
+type FencingTokenSource interface {
+	Next(ctx context.Context) (int64, error)
+}
+
+// PostgresFencingTokenSource draws tokens from lock_fencing_token_seq, which
+// lives next to the accounts and so survives whatever the lock backend loses.
+type PostgresFencingTokenSource struct {
+	db *gorm.DB
+}
+
+var _ FencingTokenSource = (*PostgresFencingTokenSource)(nil)
+
+func NewPostgresFencingTokenSource(db *gorm.DB) *PostgresFencingTokenSource {
+	return &PostgresFencingTokenSource{
+		db: db,
+	}
+}
+
+func (s *PostgresFencingTokenSource) Next(ctx context.Context) (int64, error) {
+	var token int64
+	if err := s.db.WithContext(ctx).Raw("SELECT nextval('public.lock_fencing_token_seq')").Scan(&token).Error; err != nil {
+		return 0, err
+	}
+	return token, nil
+}
+
+// Resync moves the sequence past the newest token recorded on any account.
+// It only matters if the sequence was reset, e.g. by a restore that did not
+// include it, since every write would otherwise be rejected as stale.
+func (s *PostgresFencingTokenSource) Resync(ctx context.Context) error {
+	err := s.db.WithContext(ctx).Exec(`
+SELECT setval('public.lock_fencing_token_seq', max_token)
+FROM (SELECT MAX(fencing_token) AS max_token FROM public.account) AS recorded
+WHERE max_token >= (SELECT last_value FROM public.lock_fencing_token_seq)`).Error
+	if err != nil {
+		return fmt.Errorf("failed to resync fencing tokens: %w", err)
+	}
+	return nil
+}
+
+// issueToken draws a token for a lock that has just been acquired and then
+// checks it is still held, releasing it if not. A holder whose lock expired
+// before it got its token could otherwise get a newer one than its successor.
+func issueToken(ctx context.Context, tokens FencingTokenSource, held func(ctx context.Context) (bool, error), release func(ctx context.Context) error) (int64, error) {
+	token, err := tokens.Next(ctx)
+	if err == nil {
+		var stillHeld bool
+		stillHeld, err = held(ctx)
+		if err == nil && !stillHeld {
+			err = ErrLockNotHeld
+		}
+	}
+	if err != nil {
+		_ = release(ctx)
+		return 0, fmt.Errorf("failed to issue fencing token: %w", err)
+	}
+	return token, nil
+}
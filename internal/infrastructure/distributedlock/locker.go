@@ -0,0 +1,72 @@
+package distributedlock
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrNotObtained is returned when the lock is held elsewhere and retries
+	// are disabled.
+	ErrNotObtained = errors.New("lock not obtained")
+	// ErrLockNotHeld is returned when renewing or releasing a lock that has
+	// been released or has expired.
+	ErrLockNotHeld = errors.New("lock not held")
+)
+
+// lockKeys sorts and dedupes keys so every caller takes a set of locks in the
+// same order.
+func lockKeys(keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no lock keys given")
+	}
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+
+	unique := sorted[:1]
+	for _, key := range sorted[1:] {
+		if key != unique[len(unique)-1] {
+			unique = append(unique, key)
+		}
+	}
+	return unique, nil
+}
+
+// retryLinear calls try until it succeeds, fails or ctx is done, waiting
+// retryInterval between attempts like redislock.LinearBackoff. Without a ctx
+// deadline it gives up after lockDuration, and a zero retryInterval means a
+// single attempt.
+func retryLinear(ctx context.Context, lockDuration, retryInterval time.Duration, try func(ctx context.Context) (bool, error)) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, lockDuration)
+		defer cancel()
+	}
+
+	var ticker *time.Ticker
+	for {
+		obtained, err := try(ctx)
+		if err != nil {
+			return err
+		}
+		if obtained {
+			return nil
+		}
+
+		if retryInterval < 1 {
+			return ErrNotObtained
+		}
+		if ticker == nil {
+			ticker = time.NewTicker(retryInterval)
+			defer ticker.Stop()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package distributedlock
+
+import (
+	"context"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// counterTokens is an in-process FencingTokenSource. onNext, if set, runs
+// before each token is issued.
+type counterTokens struct {
+	last   atomic.Int64
+	onNext func()
+}
+
+func (c *counterTokens) Next(ctx context.Context) (int64, error) {
+	if c.onNext != nil {
+		c.onNext()
+	}
+	return c.last.Add(1), nil
+}
+
+func TestLockKeys(t *testing.T) {
+	keys, err := lockKeys([]string{"b", "a", "b", "c"})
+	if err != nil {
+		t.Fatalf("lockKeys failed: %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("got %v, want %v", keys, want)
+	}
+
+	if _, err := lockKeys(nil); err == nil {
+		t.Errorf("expected an error for no keys")
+	}
+}
@@ -0,0 +1,182 @@
+package distributedlock
+
+import (
+	"context"
+	"fmt"
+	"points/internal/domain"
+	"sync"
+	"time"
+)
+
+// MemoryLocker keeps locks in process memory. It only serializes callers
+// within one instance, so it suits single-node deployments and tests.
+type MemoryLocker struct {
+	mu     sync.Mutex
+	locks  map[string]*memoryLockEntry
+	next   int64
+	tokens FencingTokenSource
+}
+
+// memoryLockEntry is owned by the MemoryLock acquired under the same owner
+// number.
+type memoryLockEntry struct {
+	owner     int64
+	expiresAt time.Time
+	released  chan struct{}
+}
+
+var _ domain.Locker = (*MemoryLocker)(nil)
+
+// NewMemoryLocker takes fencing tokens from tokens, so they keep growing
+// across restarts.
+func NewMemoryLocker(tokens FencingTokenSource) domain.Locker {
+	return &MemoryLocker{
+		locks:  make(map[string]*memoryLockEntry),
+		tokens: tokens,
+	}
+}
+
+func (m *MemoryLocker) Acquire(ctx context.Context, key string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	return m.AcquireAll(ctx, []string{key}, lockDuration, retryInterval)
+}
+
+// AcquireAll waits until none of the keys is held or ctx is done, woken when
+// a blocking holder releases or its TTL runs out. Without a ctx deadline it
+// gives up after lockDuration, and a zero retryInterval means a single
+// attempt, as with redislock.
+func (m *MemoryLocker) AcquireAll(ctx context.Context, keys []string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	keys, err := lockKeys(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, lockDuration)
+		defer cancel()
+	}
+
+	for {
+		lock, held := m.tryAcquire(keys, lockDuration)
+		if lock != nil {
+			lock.token, err = issueToken(ctx, m.tokens, lock.held, lock.Release)
+			if err != nil {
+				return nil, err
+			}
+			return lock, nil
+		}
+		if retryInterval < 1 {
+			return nil, fmt.Errorf("failed to acquire lock: %w", ErrNotObtained)
+		}
+
+		timer := time.NewTimer(time.Until(held.expiresAt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("failed to acquire lock: %w", ctx.Err())
+		case <-held.released:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// tryAcquire takes all keys under a new owner number if none is held, and
+// otherwise returns the first entry in the way.
+func (m *MemoryLocker) tryAcquire(keys []string, ttl time.Duration) (*MemoryLock, *memoryLockEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, key := range keys {
+		if held, ok := m.locks[key]; ok && now.Before(held.expiresAt) {
+			return nil, held
+		}
+	}
+
+	m.next++
+	for _, key := range keys {
+		if expired, ok := m.locks[key]; ok {
+			close(expired.released)
+		}
+		m.locks[key] = &memoryLockEntry{
+			owner:     m.next,
+			expiresAt: now.Add(ttl),
+			released:  make(chan struct{}),
+		}
+	}
+	return &MemoryLock{locker: m, keys: keys, owner: m.next}, nil
+}
+
+type MemoryLock struct {
+	locker *MemoryLocker
+	keys   []string
+	owner  int64
+	token  int64
+}
+
+// held reports whether the lock still owns every key.
+func (l *MemoryLock) held(ctx context.Context) (bool, error) {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+
+	now := time.Now()
+	for _, key := range l.keys {
+		held, ok := l.locker.locks[key]
+		if !ok || held.owner != l.owner || !now.Before(held.expiresAt) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+var _ domain.Lock = (*MemoryLock)(nil)
+
+// Release frees every key still owned by the lock, including expired ones
+// nobody has taken over yet, and reports ErrLockNotHeld if any had been lost.
+func (l *MemoryLock) Release(ctx context.Context) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+
+	now := time.Now()
+	var lost bool
+	for _, key := range l.keys {
+		held, ok := l.locker.locks[key]
+		if !ok || held.owner != l.owner {
+			lost = true
+			continue
+		}
+		delete(l.locker.locks, key)
+		close(held.released)
+		if !now.Before(held.expiresAt) {
+			lost = true
+		}
+	}
+	if lost {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Renew extends every key, or none if any of them has been lost.
+func (l *MemoryLock) Renew(ctx context.Context, ttl time.Duration) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+
+	now := time.Now()
+	entries := make([]*memoryLockEntry, 0, len(l.keys))
+	for _, key := range l.keys {
+		held, ok := l.locker.locks[key]
+		if !ok || held.owner != l.owner || !now.Before(held.expiresAt) {
+			return ErrLockNotHeld
+		}
+		entries = append(entries, held)
+	}
+	for _, held := range entries {
+		held.expiresAt = now.Add(ttl)
+	}
+	return nil
+}
+
+func (l *MemoryLock) Token() int64 {
+	return l.token
+}
@@ -0,0 +1,135 @@
+package distributedlock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryAcquireSuccess(t *testing.T) {
+	locker := NewMemoryLocker(&counterTokens{})
+
+	lock, err := locker.Acquire(context.Background(), "test-key", 5*time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if err := lock.Release(context.Background()); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+}
+
+func TestMemoryAcquireFailure(t *testing.T) {
+	locker := NewMemoryLocker(&counterTokens{})
+	if _, err := locker.Acquire(context.Background(), "test-key", 5*time.Second, 10*time.Millisecond); err != nil {
+		t.Fatalf("first Acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := locker.Acquire(ctx, "test-key", 5*time.Second, 10*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := locker.Acquire(context.Background(), "test-key", 5*time.Second, 0); !errors.Is(err, ErrNotObtained) {
+		t.Errorf("expected ErrNotObtained without retries, got %v", err)
+	}
+}
+
+func TestMemoryAcquireWaitsForRelease(t *testing.T) {
+	locker := NewMemoryLocker(&counterTokens{})
+	lock, err := locker.Acquire(context.Background(), "test-key", 5*time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("first Acquire failed: %v", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = lock.Release(context.Background())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := locker.Acquire(ctx, "test-key", 5*time.Second, 10*time.Millisecond); err != nil {
+		t.Errorf("expected Acquire to succeed after release, got %v", err)
+	}
+}
+
+func TestMemoryLockExpiry(t *testing.T) {
+	locker := NewMemoryLocker(&counterTokens{})
+	lock, err := locker.Acquire(context.Background(), "test-key", 20*time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("first Acquire failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := locker.Acquire(ctx, "test-key", 5*time.Second, 10*time.Millisecond); err != nil {
+		t.Fatalf("expected Acquire to succeed after expiry, got %v", err)
+	}
+
+	if err := lock.Renew(context.Background(), time.Second); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld on Renew, got %v", err)
+	}
+	if err := lock.Release(context.Background()); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld on Release, got %v", err)
+	}
+}
+
+func TestMemoryLockRenew(t *testing.T) {
+	locker := NewMemoryLocker(&counterTokens{})
+	lock, err := locker.Acquire(context.Background(), "test-key", 30*time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if err := lock.Renew(context.Background(), 5*time.Second); err != nil {
+		t.Fatalf("Renew failed: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if _, err := locker.Acquire(context.Background(), "test-key", 5*time.Second, 0); !errors.Is(err, ErrNotObtained) {
+		t.Errorf("expected the renewed lock to still be held, got %v", err)
+	}
+}
+
+func TestMemoryLockFencingTokenIncreases(t *testing.T) {
+	locker := NewMemoryLocker(&counterTokens{})
+
+	first, err := locker.Acquire(context.Background(), "test-key", 5*time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	second, err := locker.Acquire(context.Background(), "other-key", 5*time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	if second.Token() <= first.Token() {
+		t.Errorf("expected increasing fencing tokens, got %d then %d", first.Token(), second.Token())
+	}
+}
+
+func TestMemoryAcquireAllIsAllOrNothing(t *testing.T) {
+	locker := NewMemoryLocker(&counterTokens{})
+	held, err := locker.Acquire(context.Background(), "key-b", 5*time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	if _, err := locker.AcquireAll(context.Background(), []string{"key-a", "key-b"}, 5*time.Second, 0); !errors.Is(err, ErrNotObtained) {
+		t.Fatalf("expected ErrNotObtained while key-b is held, got %v", err)
+	}
+	if _, err := locker.Acquire(context.Background(), "key-a", 5*time.Second, 0); err != nil {
+		t.Errorf("expected key-a to stay free, got %v", err)
+	}
+
+	if err := held.Release(context.Background()); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	lock, err := locker.AcquireAll(context.Background(), []string{"key-b", "key-c"}, 5*time.Second, 0)
+	if err != nil {
+		t.Fatalf("AcquireAll failed: %v", err)
+	}
+	if err := lock.Release(context.Background()); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+}
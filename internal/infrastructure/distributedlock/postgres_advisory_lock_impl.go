@@ -0,0 +1,265 @@
+package distributedlock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"points/internal/domain"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostgresLocker takes session-level advisory locks on hashed keys, all on
+// one dedicated connection, so holding locks never costs the unit of work
+// more than that connection. Postgres drops the locks by itself if the
+// instance dies or the connection is lost.
+//
+// Advisory locks are re-entrant within a session, so the locker also tracks
+// which lock ids it holds and keeps callers of the same instance apart.
+type PostgresLocker struct {
+	db     *gorm.DB
+	tokens FencingTokenSource
+
+	mu   sync.Mutex
+	conn *sql.Conn
+	// held maps each advisory lock id taken on conn to its owner number.
+	held map[int64]int64
+	next int64
+}
+
+var _ domain.Locker = (*PostgresLocker)(nil)
+
+// sessionStatementTimeout bounds every statement on the session, so a hung
+// one cannot block later acquires and releases for good.
+const sessionStatementTimeout = 5 * time.Second
+
+func NewPostgresLocker(db *gorm.DB, tokens FencingTokenSource) *PostgresLocker {
+	return &PostgresLocker{
+		db:     db,
+		tokens: tokens,
+		held:   make(map[int64]int64),
+	}
+}
+
+func (p *PostgresLocker) Acquire(ctx context.Context, key string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	return p.AcquireAll(ctx, []string{key}, lockDuration, retryInterval)
+}
+
+// AcquireAll tries the keys in sorted order. If any of them is taken the
+// ones already obtained are unlocked again, and the whole set is retried
+// every retryInterval, like redislock.LinearBackoff.
+func (p *PostgresLocker) AcquireAll(ctx context.Context, keys []string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	keys, err := lockKeys(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	ids := make([]int64, len(keys))
+	for i, key := range keys {
+		ids[i] = advisoryLockID(key)
+	}
+
+	var lock *PostgresLock
+	err = retryLinear(ctx, lockDuration, retryInterval, func(acquireCtx context.Context) (bool, error) {
+		var err error
+		lock, err = p.tryAdvisoryLocks(acquireCtx, ids)
+		return lock != nil, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	// Advisory locks have no TTL, so the lock is still held once the token
+	// is drawn unless the connection was lost, which Renew reports.
+	lock.token, err = p.tokens.Next(ctx)
+	if err != nil {
+		_ = lock.Release(ctx)
+		return nil, fmt.Errorf("failed to issue fencing token: %w", err)
+	}
+	return lock, nil
+}
+
+// tryAdvisoryLocks takes every id or none, and returns nil if one of them is
+// held by this or another instance.
+//
+// Statements on the session ignore the caller's cancellation: a statement
+// cut short leaves the state of its lock unknown, and closing the session to
+// recover from that would drop every other holder's locks too. Only
+// sessionStatementTimeout ends them early.
+func (p *PostgresLocker) tryAdvisoryLocks(ctx context.Context, ids []int64) (*PostgresLock, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, id := range ids {
+		if _, ok := p.held[id]; ok {
+			return nil, nil
+		}
+	}
+	conn, err := p.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		var obtained bool
+		err := withStatementTimeout(ctx, func(ctx context.Context) error {
+			return conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", id).Scan(&obtained)
+		})
+		if err == nil && obtained {
+			continue
+		}
+		if err != nil {
+			p.resetSession()
+			return nil, err
+		}
+		if err := p.unlock(ctx, ids[:i]); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	p.next++
+	for _, id := range ids {
+		p.held[id] = p.next
+	}
+	return &PostgresLock{locker: p, ids: ids, owner: p.next}, nil
+}
+
+// session returns the connection every lock is taken on, opening it first if
+// needed. The caller holds mu.
+func (p *PostgresLocker) session(ctx context.Context) (*sql.Conn, error) {
+	if p.conn != nil {
+		return p.conn, nil
+	}
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	var conn *sql.Conn
+	err = withStatementTimeout(ctx, func(ctx context.Context) error {
+		conn, err = sqlDB.Conn(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.conn = conn
+	return conn, nil
+}
+
+// Close ends the session, releasing every lock still held on it.
+func (p *PostgresLocker) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.resetSession()
+	return nil
+}
+
+// resetSession ends the session after an error or on Close, which releases
+// every advisory lock on it, and forgets them so their holders see them as
+// lost. Returning driver.ErrBadConn from Raw makes database/sql close the
+// connection rather than pool it with the locks still held. The caller holds
+// mu.
+func (p *PostgresLocker) resetSession() {
+	if p.conn != nil {
+		_ = p.conn.Raw(func(any) error { return driver.ErrBadConn })
+		_ = p.conn.Close()
+		p.conn = nil
+	}
+	p.held = make(map[int64]int64)
+}
+
+// withStatementTimeout runs one statement on the session, detached from the
+// caller's cancellation but bounded by sessionStatementTimeout.
+func withStatementTimeout(ctx context.Context, statement func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sessionStatementTimeout)
+	defer cancel()
+	return statement(ctx)
+}
+
+// unlock releases ids on the session. The caller holds mu.
+func (p *PostgresLocker) unlock(ctx context.Context, ids []int64) error {
+	for _, id := range ids {
+		delete(p.held, id)
+		err := withStatementTimeout(ctx, func(ctx context.Context) error {
+			_, err := p.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", id)
+			return err
+		})
+		if err != nil {
+			p.resetSession()
+			return err
+		}
+	}
+	return nil
+}
+
+// owns reports whether every id is still held under owner. The caller holds
+// mu.
+func (p *PostgresLocker) owns(ids []int64, owner int64) bool {
+	for _, id := range ids {
+		if p.held[id] != owner {
+			return false
+		}
+	}
+	return true
+}
+
+// advisoryLockID maps a lock key such as account_lock:<id> onto the bigint
+// key space of Postgres advisory locks.
+func advisoryLockID(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+type PostgresLock struct {
+	locker *PostgresLocker
+	ids    []int64
+	owner  int64
+	token  int64
+}
+
+var _ domain.Lock = (*PostgresLock)(nil)
+
+// Release unlocks the ids still owned by the lock. Releasing twice, or after
+// the connection was lost, is a no-op.
+func (l *PostgresLock) Release(ctx context.Context) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+
+	owned := make([]int64, 0, len(l.ids))
+	for _, id := range l.ids {
+		if l.locker.held[id] == l.owner {
+			owned = append(owned, id)
+		}
+	}
+	if len(owned) == 0 {
+		return nil
+	}
+	return l.locker.unlock(ctx, owned)
+}
+
+// Renew does not extend anything, since an advisory lock has no TTL and is
+// held until Release. It checks the session is still alive and reports
+// whether the lock has been released or lost with it.
+func (l *PostgresLock) Renew(ctx context.Context, ttl time.Duration) error {
+	l.locker.mu.Lock()
+	defer l.locker.mu.Unlock()
+
+	if !l.locker.owns(l.ids, l.owner) {
+		return ErrLockNotHeld
+	}
+	if err := withStatementTimeout(ctx, l.locker.conn.PingContext); err != nil {
+		l.locker.resetSession()
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+func (l *PostgresLock) Token() int64 {
+	return l.token
+}
@@ -0,0 +1,193 @@
+package distributedlock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newSqliteDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	return db
+}
+
+// fakeAdvisoryLocks stands in for the Postgres advisory lock functions. Ids
+// in elsewhere are held by another instance.
+type fakeAdvisoryLocks struct {
+	mu        sync.Mutex
+	elsewhere map[int64]bool
+	unlocked  []int64
+}
+
+var (
+	advisoryLocks        = &fakeAdvisoryLocks{elsewhere: make(map[int64]bool)}
+	registerAdvisoryOnce sync.Once
+)
+
+// newAdvisorySqliteDB opens sqlite with pg_try_advisory_lock and
+// pg_advisory_unlock backed by advisoryLocks.
+func newAdvisorySqliteDB(t *testing.T) *gorm.DB {
+	registerAdvisoryOnce.Do(func() {
+		sql.Register("sqlite3_advisory", &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				if err := conn.RegisterFunc("pg_try_advisory_lock", func(id int64) bool {
+					advisoryLocks.mu.Lock()
+					defer advisoryLocks.mu.Unlock()
+					return !advisoryLocks.elsewhere[id]
+				}, false); err != nil {
+					return err
+				}
+				return conn.RegisterFunc("pg_advisory_unlock", func(id int64) bool {
+					advisoryLocks.mu.Lock()
+					defer advisoryLocks.mu.Unlock()
+					advisoryLocks.unlocked = append(advisoryLocks.unlocked, id)
+					return true
+				}, false)
+			},
+		})
+	})
+	advisoryLocks.mu.Lock()
+	advisoryLocks.elsewhere = make(map[int64]bool)
+	advisoryLocks.unlocked = nil
+	advisoryLocks.mu.Unlock()
+
+	db, err := gorm.Open(sqlite.Dialector{DriverName: "sqlite3_advisory", DSN: ":memory:"}, &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	return db
+}
+
+func TestAdvisoryLockID(t *testing.T) {
+	if advisoryLockID("account_lock:1") != advisoryLockID("account_lock:1") {
+		t.Errorf("expected the same key to map to the same lock id")
+	}
+	if advisoryLockID("account_lock:1") == advisoryLockID("account_lock:2") {
+		t.Errorf("expected different keys to map to different lock ids")
+	}
+}
+
+func TestPostgresAcquireQueryFailure(t *testing.T) {
+	// sqlite has no advisory lock functions, so the first attempt fails.
+	db := newSqliteDB(t)
+	locker := NewPostgresLocker(db, NewPostgresFencingTokenSource(db))
+
+	_, err := locker.Acquire(context.Background(), "test-key", time.Second, 10*time.Millisecond)
+	if err == nil {
+		t.Fatalf("expected Acquire to fail")
+	}
+	if !strings.Contains(err.Error(), "failed to acquire lock") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPostgresLocksShareOneConnection(t *testing.T) {
+	db := newAdvisorySqliteDB(t)
+	locker := NewPostgresLocker(db, &counterTokens{})
+
+	first, err := locker.Acquire(context.Background(), "key-a", time.Second, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	second, err := locker.Acquire(context.Background(), "key-b", time.Second, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if second.Token() <= first.Token() {
+		t.Errorf("expected increasing fencing tokens, got %d then %d", first.Token(), second.Token())
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	if inUse := sqlDB.Stats().InUse; inUse != 1 {
+		t.Errorf("expected both locks to share one connection, got %d in use", inUse)
+	}
+
+	// The session would grant key-a again, so the locker has to refuse it.
+	if _, err := locker.Acquire(context.Background(), "key-a", time.Second, 0); !errors.Is(err, ErrNotObtained) {
+		t.Errorf("expected ErrNotObtained while key-a is held in this instance, got %v", err)
+	}
+	if err := first.Release(context.Background()); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, err := locker.Acquire(context.Background(), "key-a", time.Second, 0); err != nil {
+		t.Errorf("expected key-a to be free after Release, got %v", err)
+	}
+}
+
+func TestPostgresAcquireAllIsAllOrNothing(t *testing.T) {
+	db := newAdvisorySqliteDB(t)
+	locker := NewPostgresLocker(db, &counterTokens{})
+
+	advisoryLocks.elsewhere[advisoryLockID("key-b")] = true
+	if _, err := locker.AcquireAll(context.Background(), []string{"key-a", "key-b"}, time.Second, 0); !errors.Is(err, ErrNotObtained) {
+		t.Fatalf("expected ErrNotObtained while key-b is held elsewhere, got %v", err)
+	}
+	if len(advisoryLocks.unlocked) != 1 || advisoryLocks.unlocked[0] != advisoryLockID("key-a") {
+		t.Errorf("expected key-a to be unlocked again, got %v", advisoryLocks.unlocked)
+	}
+	if _, err := locker.Acquire(context.Background(), "key-a", time.Second, 0); err != nil {
+		t.Errorf("expected key-a to stay free, got %v", err)
+	}
+}
+
+func TestPostgresLockRenewAndRelease(t *testing.T) {
+	db := newAdvisorySqliteDB(t)
+	locker := NewPostgresLocker(db, &counterTokens{})
+
+	lock, err := locker.AcquireAll(context.Background(), []string{"key-a", "key-b"}, time.Second, 0)
+	if err != nil {
+		t.Fatalf("AcquireAll failed: %v", err)
+	}
+
+	if err := lock.Renew(context.Background(), time.Second); err != nil {
+		t.Errorf("Renew on a held lock failed: %v", err)
+	}
+	if err := lock.Release(context.Background()); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+	if err := lock.Release(context.Background()); err != nil {
+		t.Errorf("second Release failed: %v", err)
+	}
+	if err := lock.Renew(context.Background(), time.Second); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld after Release, got %v", err)
+	}
+}
+
+func TestPostgresLockerCloseEndsTheSession(t *testing.T) {
+	db := newAdvisorySqliteDB(t)
+	locker := NewPostgresLocker(db, &counterTokens{})
+
+	lock, err := locker.Acquire(context.Background(), "key-a", time.Second, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if err := locker.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	// A pooled connection would keep the session, and its locks, alive.
+	if open := sqlDB.Stats().OpenConnections; open != 0 {
+		t.Errorf("expected the session connection to be closed, got %d open", open)
+	}
+	if err := lock.Renew(context.Background(), time.Second); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld after Close, got %v", err)
+	}
+}
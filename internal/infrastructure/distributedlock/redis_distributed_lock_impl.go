@@ -2,6 +2,9 @@ package distributedlock
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"points/internal/domain"
 	"time"
@@ -10,15 +13,67 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// obtainAll sets every lock key or none.
+var obtainAll = redis.NewScript(`
+for i = 1, #KEYS do
+	if redis.call("exists", KEYS[i]) == 1 then
+		return 0
+	end
+end
+for i = 1, #KEYS do
+	redis.call("set", KEYS[i], ARGV[1], "px", ARGV[2])
+end
+return 1
+`)
+
+// heldAll reports whether every key still holds the lock's value.
+var heldAll = redis.NewScript(`
+for i = 1, #KEYS do
+	if redis.call("get", KEYS[i]) ~= ARGV[1] then
+		return 0
+	end
+end
+return 1
+`)
+
+// releaseAll deletes the keys still holding the lock's value and returns how
+// many it deleted.
+var releaseAll = redis.NewScript(`
+local released = 0
+for i = 1, #KEYS do
+	if redis.call("get", KEYS[i]) == ARGV[1] then
+		released = released + redis.call("del", KEYS[i])
+	end
+end
+return released
+`)
+
+// refreshAll extends every key, or none if any of them has been lost.
+var refreshAll = redis.NewScript(`
+for i = 1, #KEYS do
+	if redis.call("get", KEYS[i]) ~= ARGV[1] then
+		return 0
+	end
+end
+for i = 1, #KEYS do
+	redis.call("pexpire", KEYS[i], ARGV[2])
+end
+return 1
+`)
+
 type RedisLocker struct {
 	client *redis.Client
+	tokens FencingTokenSource
 }
 
 var _ domain.Locker = (*RedisLocker)(nil)
 
-func NewRedisLocker(client *redis.Client) domain.Locker {
+// NewRedisLocker takes fencing tokens from tokens rather than a Redis
+// counter, so a Redis restart or flush cannot set them back.
+func NewRedisLocker(client *redis.Client, tokens FencingTokenSource) domain.Locker {
 	return &RedisLocker{
 		client: client,
+		tokens: tokens,
 	}
 }
 
@@ -32,13 +87,72 @@ func (r *RedisLocker) Acquire(ctx context.Context, key string, lockDuration, ret
 		return nil, fmt.Errorf("failed to acquire lock: %w", err)
 	}
 
+	token, err := issueToken(ctx, r.tokens, func(ctx context.Context) (bool, error) {
+		return r.held(ctx, []string{key}, lock.Token())
+	}, lock.Release)
+	if err != nil {
+		return nil, err
+	}
+
 	return &RedisLock{
-		lock: lock,
+		lock:  lock,
+		token: token,
 	}, nil
 }
 
+// AcquireAll retries the all-or-nothing set every retryInterval, like
+// redislock.LinearBackoff, so holders of overlapping sets cannot deadlock.
+func (r *RedisLocker) AcquireAll(ctx context.Context, keys []string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	keys, err := lockKeys(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	value, err := randomLockValue()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	err = retryLinear(ctx, lockDuration, retryInterval, func(ctx context.Context) (bool, error) {
+		obtained, err := obtainAll.Run(ctx, r.client, keys, value, lockDuration.Milliseconds()).Int()
+		return obtained == 1, err
+	})
+	if errors.Is(err, ErrNotObtained) {
+		err = redislock.ErrNotObtained
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+
+	lock := &RedisMultiLock{
+		client: r.client,
+		keys:   keys,
+		value:  value,
+	}
+	lock.token, err = issueToken(ctx, r.tokens, func(ctx context.Context) (bool, error) {
+		return r.held(ctx, keys, value)
+	}, lock.Release)
+	if err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+func (r *RedisLocker) held(ctx context.Context, keys []string, value string) (bool, error) {
+	held, err := heldAll.Run(ctx, r.client, keys, value).Int()
+	return held == 1, err
+}
+
+func randomLockValue() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 type RedisLock struct {
-	lock *redislock.Lock
+	lock  *redislock.Lock
+	token int64
 }
 
 var _ domain.Lock = (*RedisLock)(nil)
@@ -50,3 +164,43 @@ func (l *RedisLock) Release(ctx context.Context) error {
 func (l *RedisLock) Renew(ctx context.Context, ttl time.Duration) error {
 	return l.lock.Refresh(ctx, ttl, nil)
 }
+
+func (l *RedisLock) Token() int64 {
+	return l.token
+}
+
+// RedisMultiLock holds several keys set to the same random value.
+type RedisMultiLock struct {
+	client *redis.Client
+	keys   []string
+	value  string
+	token  int64
+}
+
+var _ domain.Lock = (*RedisMultiLock)(nil)
+
+func (l *RedisMultiLock) Release(ctx context.Context) error {
+	released, err := releaseAll.Run(ctx, l.client, l.keys, l.value).Int()
+	if err != nil {
+		return err
+	}
+	if released != len(l.keys) {
+		return redislock.ErrLockNotHeld
+	}
+	return nil
+}
+
+func (l *RedisMultiLock) Renew(ctx context.Context, ttl time.Duration) error {
+	refreshed, err := refreshAll.Run(ctx, l.client, l.keys, l.value, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if refreshed == 0 {
+		return redislock.ErrNotObtained
+	}
+	return nil
+}
+
+func (l *RedisMultiLock) Token() int64 {
+	return l.token
+}
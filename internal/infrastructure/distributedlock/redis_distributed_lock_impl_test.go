@@ -2,6 +2,7 @@ package distributedlock
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -20,7 +21,7 @@ func TestAcquireSuccess(t *testing.T) {
 		Addr: s.Addr(),
 	})
 
-	locker := NewRedisLocker(client)
+	locker := NewRedisLocker(client, &counterTokens{})
 	lockDuration := 5 * time.Second
 	retryInterval := 100 * time.Millisecond
 
@@ -54,7 +55,7 @@ func TestAcquireFailure(t *testing.T) {
 	client := redis.NewClient(&redis.Options{
 		Addr: s.Addr(),
 	})
-	locker := NewRedisLocker(client)
+	locker := NewRedisLocker(client, &counterTokens{})
 	lockDuration := 5 * time.Second
 	retryInterval := 100 * time.Millisecond
 
@@ -74,7 +75,7 @@ func TestReleaseSuccess(t *testing.T) {
 	client := redis.NewClient(&redis.Options{
 		Addr: s.Addr(),
 	})
-	locker := NewRedisLocker(client)
+	locker := NewRedisLocker(client, &counterTokens{})
 	lockDuration := 5 * time.Second
 	retryInterval := 100 * time.Millisecond
 
@@ -102,7 +103,7 @@ func TestReleaseFailure(t *testing.T) {
 	client := redis.NewClient(&redis.Options{
 		Addr: s.Addr(),
 	})
-	locker := NewRedisLocker(client)
+	locker := NewRedisLocker(client, &counterTokens{})
 	lockDuration := 5 * time.Second
 	retryInterval := 100 * time.Millisecond
 
@@ -129,7 +130,7 @@ func TestRenewSuccess(t *testing.T) {
 	client := redis.NewClient(&redis.Options{
 		Addr: s.Addr(),
 	})
-	locker := NewRedisLocker(client)
+	locker := NewRedisLocker(client, &counterTokens{})
 	lockDuration := 5 * time.Second
 	retryInterval := 100 * time.Millisecond
 
@@ -162,7 +163,7 @@ func TestRenewFailure(t *testing.T) {
 	client := redis.NewClient(&redis.Options{
 		Addr: s.Addr(),
 	})
-	locker := NewRedisLocker(client)
+	locker := NewRedisLocker(client, &counterTokens{})
 	lockDuration := 5 * time.Second
 	retryInterval := 100 * time.Millisecond
 
@@ -179,3 +180,151 @@ func TestRenewFailure(t *testing.T) {
 		t.Fatalf("expected Renew to fail due to token mismatch")
 	}
 }
+
+func TestAcquireFencingTokenIncreases(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+	locker := NewRedisLocker(client, &counterTokens{})
+	lockDuration := 5 * time.Second
+	retryInterval := 100 * time.Millisecond
+
+	first, err := locker.Acquire(context.Background(), "test-key", lockDuration, retryInterval)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if err := first.Release(context.Background()); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	s.FlushAll()
+
+	second, err := locker.Acquire(context.Background(), "other-key", lockDuration, retryInterval)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	if first.Token() == 0 || second.Token() <= first.Token() {
+		t.Errorf("expected increasing fencing tokens, got %d then %d", first.Token(), second.Token())
+	}
+}
+
+func TestAcquireAllIsAllOrNothing(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+	locker := NewRedisLocker(client, &counterTokens{})
+	lockDuration := 5 * time.Second
+
+	s.Set("key-b", "other-token")
+	_, err = locker.AcquireAll(context.Background(), []string{"key-a", "key-b"}, lockDuration, 0)
+	if err == nil {
+		t.Fatalf("expected AcquireAll to fail while key-b is held")
+	}
+	if s.Exists("key-a") {
+		t.Errorf("expected key-a to stay free when the set could not be obtained")
+	}
+
+	s.Del("key-b")
+	lock, err := locker.AcquireAll(context.Background(), []string{"key-b", "key-a", "key-b"}, lockDuration, 0)
+	if err != nil {
+		t.Fatalf("AcquireAll failed: %v", err)
+	}
+	if !s.Exists("key-a") || !s.Exists("key-b") {
+		t.Errorf("expected both keys to be held")
+	}
+	if lock.Token() == 0 {
+		t.Errorf("expected a fencing token")
+	}
+
+	if err := lock.Renew(context.Background(), 10*time.Second); err != nil {
+		t.Fatalf("Renew failed: %v", err)
+	}
+	if ttl := s.TTL("key-a"); ttl < 9*time.Second {
+		t.Errorf("unexpected TTL after Renew: %v", ttl)
+	}
+
+	if err := lock.Release(context.Background()); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if s.Exists("key-a") || s.Exists("key-b") {
+		t.Errorf("expected both keys to be removed after Release")
+	}
+}
+
+func TestAcquireAllReleaseFailure(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+	locker := NewRedisLocker(client, &counterTokens{})
+
+	lock, err := locker.AcquireAll(context.Background(), []string{"key-a", "key-b"}, 5*time.Second, 0)
+	if err != nil {
+		t.Fatalf("AcquireAll failed: %v", err)
+	}
+
+	s.Set("key-b", "different-token")
+
+	if err := lock.Renew(context.Background(), 10*time.Second); err == nil {
+		t.Errorf("expected Renew to fail due to token mismatch")
+	}
+	if err := lock.Release(context.Background()); err == nil {
+		t.Errorf("expected Release to fail due to token mismatch")
+	}
+	if s.Exists("key-a") {
+		t.Errorf("expected key-a to be released anyway")
+	}
+}
+
+func TestAcquireReleasesLockLostBeforeItsToken(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+	tokens := &counterTokens{}
+	locker := NewRedisLocker(client, tokens)
+
+	// The lock is taken over while its holder waits for a token.
+	tokens.onNext = func() { s.Set("key-b", "successor-token") }
+
+	if _, err := locker.Acquire(context.Background(), "key-b", 5*time.Second, 0); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld from Acquire, got %v", err)
+	}
+	if _, err := locker.AcquireAll(context.Background(), []string{"key-a", "key-b"}, 5*time.Second, 0); err == nil {
+		t.Errorf("expected AcquireAll to fail while key-b is held")
+	}
+
+	s.Del("key-b")
+	tokens.onNext = func() { s.Set("key-b", "successor-token") }
+	if _, err := locker.AcquireAll(context.Background(), []string{"key-a", "key-b"}, 5*time.Second, 0); !errors.Is(err, ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld from AcquireAll, got %v", err)
+	}
+	if s.Exists("key-a") {
+		t.Errorf("expected key-a to be released")
+	}
+	if got, _ := s.Get("key-b"); got != "successor-token" {
+		t.Errorf("expected the successor to keep key-b, got %q", got)
+	}
+}
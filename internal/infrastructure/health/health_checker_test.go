@@ -0,0 +1,65 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"points/internal/infrastructure/migration"
+	"points/internal/infrastructure/persistence/gorm/model"
+	"points/test"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestMigrationChecker(t *testing.T) {
+	latestVersion, err := migration.LatestVersion()
+	assert.NoError(t, err)
+	latest := int64(latestVersion)
+
+	testCases := []struct {
+		name        string
+		migration   *model.SchemaMigration
+		expectedErr string
+	}{
+		{name: "Current", migration: &model.SchemaMigration{Version: latest}},
+		{name: "Ahead", migration: &model.SchemaMigration{Version: latest + 1}},
+		{name: "Behind", migration: &model.SchemaMigration{Version: latest - 1}, expectedErr: fmt.Sprintf("schema version %d is behind %d", latest-1, latest)},
+		{name: "Dirty", migration: &model.SchemaMigration{Version: latest, Dirty: true}, expectedErr: fmt.Sprintf("schema version %d is dirty", latest)},
+		{name: "Not Migrated", expectedErr: "failed to read schema version"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			assert.NoError(t, err)
+			assert.NoError(t, db.AutoMigrate(&model.SchemaMigration{}))
+			if tc.migration != nil {
+				assert.NoError(t, db.Create(tc.migration).Error)
+			}
+
+			checker := NewMigrationChecker(db)
+			postgres := NewPostgresChecker(db)
+
+			assert.NoError(t, postgres.Check(context.Background()))
+			err = checker.Check(context.Background())
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRedisChecker(t *testing.T) {
+	mr, client := test.NewDummyRedis(t)
+	checker := NewRedisChecker(client)
+
+	assert.NoError(t, checker.Check(context.Background()))
+
+	mr.Close()
+	assert.Error(t, checker.Check(context.Background()))
+}
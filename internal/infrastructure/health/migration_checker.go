@@ -0,0 +1,44 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/infrastructure/migration"
+	"points/internal/infrastructure/persistence/gorm/model"
+
+	"gorm.io/gorm"
+)
+
+type migrationChecker struct {
+	db *gorm.DB
+}
+
+// NewMigrationChecker reports the schema as not ready while schema_migrations
+// is dirty or behind the newest migration embedded in this build.
+func NewMigrationChecker(db *gorm.DB) domain.HealthChecker {
+	return &migrationChecker{db: db}
+}
+
+func (c *migrationChecker) Name() string {
+	return "migrations"
+}
+
+func (c *migrationChecker) Check(ctx context.Context) error {
+	expected, err := migration.LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	var current model.SchemaMigration
+	if err := c.db.WithContext(ctx).Order("version DESC").First(&current).Error; err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if current.Dirty {
+		return fmt.Errorf("schema version %d is dirty", current.Version)
+	}
+	if current.Version < int64(expected) {
+		return fmt.Errorf("schema version %d is behind %d", current.Version, expected)
+	}
+	return nil
+}
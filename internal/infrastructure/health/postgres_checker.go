@@ -0,0 +1,28 @@
+package health
+
+import (
+	"context"
+	"points/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type postgresChecker struct {
+	db *gorm.DB
+}
+
+func NewPostgresChecker(db *gorm.DB) domain.HealthChecker {
+	return &postgresChecker{db: db}
+}
+
+func (c *postgresChecker) Name() string {
+	return "postgres"
+}
+
+func (c *postgresChecker) Check(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
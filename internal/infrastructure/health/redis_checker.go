@@ -0,0 +1,24 @@
+package health
+
+import (
+	"context"
+	"points/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type redisChecker struct {
+	client *redis.Client
+}
+
+func NewRedisChecker(client *redis.Client) domain.HealthChecker {
+	return &redisChecker{client: client}
+}
+
+func (c *redisChecker) Name() string {
+	return "redis"
+}
+
+func (c *redisChecker) Check(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
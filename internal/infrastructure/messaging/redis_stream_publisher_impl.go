@@ -0,0 +1,49 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/entity"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisStreamPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+var _ domain.EventPublisher = (*RedisStreamPublisher)(nil)
+
+func NewRedisStreamPublisher(client *redis.Client, stream string, maxLen int64) domain.EventPublisher {
+	return &RedisStreamPublisher{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (p *RedisStreamPublisher) Publish(ctx context.Context, event *entity.TransactionEvent) error {
+	args := &redis.XAddArgs{
+		Stream: p.stream,
+		Values: map[string]interface{}{
+			"event_id":       event.ID,
+			"transaction_id": event.TransactionID,
+			"event_type":     event.EventType,
+			"payload":        event.Payload,
+			"created_at":     event.CreatedAt.Format(time.RFC3339Nano),
+		},
+	}
+	if p.maxLen > 0 {
+		args.MaxLen = p.maxLen
+		args.Approx = true
+	}
+
+	if err := p.client.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("failed to publish event to stream %s: %w", p.stream, err)
+	}
+	return nil
+}
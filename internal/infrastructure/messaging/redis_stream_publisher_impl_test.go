@@ -0,0 +1,72 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"points/internal/domain/entity"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestPublishSuccess(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+
+	publisher := NewRedisStreamPublisher(client, "test-stream", 100)
+	event := &entity.TransactionEvent{
+		ID:            7,
+		TransactionID: "tx-123",
+		EventType:     "pending",
+		Payload:       `{"Action":"pending"}`,
+		CreatedAt:     time.Now(),
+	}
+
+	if err := publisher.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	entries, err := client.XRange(context.Background(), "test-stream", "-", "+").Result()
+	if err != nil {
+		t.Fatalf("failed to read stream: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 stream entry, got %d", len(entries))
+	}
+	if entries[0].Values["event_id"] != "7" {
+		t.Errorf("expected event_id 7, got %v", entries[0].Values["event_id"])
+	}
+	if entries[0].Values["transaction_id"] != "tx-123" {
+		t.Errorf("expected transaction_id tx-123, got %v", entries[0].Values["transaction_id"])
+	}
+	if entries[0].Values["payload"] != event.Payload {
+		t.Errorf("expected payload %s, got %v", event.Payload, entries[0].Values["payload"])
+	}
+}
+
+func TestPublishFailure(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr: s.Addr(),
+	})
+	s.Close()
+
+	publisher := NewRedisStreamPublisher(client, "test-stream", 0)
+	err = publisher.Publish(context.Background(), &entity.TransactionEvent{ID: 1})
+	if err == nil {
+		t.Fatalf("expected Publish to fail when redis is unavailable")
+	}
+}
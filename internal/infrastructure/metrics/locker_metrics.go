@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"points/internal/domain"
+	"time"
+)
+
+type lockerMetrics struct {
+	next domain.Locker
+}
+
+// InstrumentLocker records how long callers wait for locks and how often
+// the locks they hold fail to renew.
+func InstrumentLocker(next domain.Locker) domain.Locker {
+	return &lockerMetrics{next: next}
+}
+
+func (m *lockerMetrics) Acquire(ctx context.Context, key string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	start := time.Now()
+	lock, err := m.next.Acquire(ctx, key, lockDuration, retryInterval)
+	return observeAcquire(start, lock, err)
+}
+
+func (m *lockerMetrics) AcquireAll(ctx context.Context, keys []string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	start := time.Now()
+	lock, err := m.next.AcquireAll(ctx, keys, lockDuration, retryInterval)
+	return observeAcquire(start, lock, err)
+}
+
+func observeAcquire(start time.Time, lock domain.Lock, err error) (domain.Lock, error) {
+	result := "acquired"
+	if err != nil {
+		result = "failed"
+	}
+	lockAcquireWait.WithLabelValues(result).Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, err
+	}
+	return &lockMetrics{Lock: lock}, nil
+}
+
+type lockMetrics struct {
+	domain.Lock
+}
+
+func (l *lockMetrics) Renew(ctx context.Context, ttl time.Duration) error {
+	err := l.Lock.Renew(ctx, ttl)
+	if err != nil && ctx.Err() == nil {
+		lockRenewFailures.Inc()
+	}
+	return err
+}
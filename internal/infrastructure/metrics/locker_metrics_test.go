@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentLocker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	next := mock.NewMockLocker(ctrl)
+	lock := mock.NewMockLock(ctrl)
+	locker := InstrumentLocker(next)
+	ctx := context.Background()
+
+	acquiredBefore := histogramCount(t, "acquired")
+	failedBefore := histogramCount(t, "failed")
+	failuresBefore := testutil.ToFloat64(lockRenewFailures)
+
+	next.EXPECT().Acquire(ctx, "transfer_lock:1:2", time.Second, time.Millisecond).Return(lock, nil).Times(1)
+	next.EXPECT().Acquire(ctx, "transfer_lock:1:3", time.Second, time.Millisecond).Return(nil, errors.New("busy")).Times(1)
+	lock.EXPECT().Renew(ctx, time.Second).Return(errors.New("lost")).Times(1)
+	lock.EXPECT().Release(ctx).Return(nil).Times(1)
+
+	held, err := locker.Acquire(ctx, "transfer_lock:1:2", time.Second, time.Millisecond)
+	assert.NoError(t, err)
+	_, err = locker.Acquire(ctx, "transfer_lock:1:3", time.Second, time.Millisecond)
+	assert.Error(t, err)
+
+	assert.Error(t, held.Renew(ctx, time.Second))
+	assert.NoError(t, held.Release(ctx))
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	lock.EXPECT().Renew(canceled, time.Second).Return(context.Canceled).Times(1)
+	assert.Error(t, held.Renew(canceled, time.Second))
+
+	assert.Equal(t, acquiredBefore+1, histogramCount(t, "acquired"))
+	assert.Equal(t, failedBefore+1, histogramCount(t, "failed"))
+	assert.Equal(t, failuresBefore+1, testutil.ToFloat64(lockRenewFailures), "renewals stopped by the caller are not failures")
+}
+
+func histogramCount(t *testing.T, result string) uint64 {
+	t.Helper()
+	var m dto.Metric
+	if err := lockAcquireWait.WithLabelValues(result).(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"errors"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "points"
+
+var (
+	tradeOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trade_operations_total",
+		Help:      "Transfer, confirm and cancel calls by outcome error code.",
+	}, []string{"operation", "code"})
+
+	tradeOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "trade_operation_duration_seconds",
+		Help:      "Duration of transfer, confirm and cancel calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"operation", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency per route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	lockAcquireWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "lock_acquire_wait_seconds",
+		Help:      "Time spent waiting for a distributed lock.",
+		Buckets:   []float64{.001, .005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"result"})
+
+	lockRenewFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lock_renew_failures_total",
+		Help:      "Distributed lock renewals that failed.",
+	})
+)
+
+// ObserveHTTPRequest records a finished request; route is the matched route
+// template so that path parameters do not create new series.
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	if route == "" {
+		route = "unmatched"
+	}
+	httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func observeTradeOperation(operation string, err error, duration time.Duration) {
+	code := errorCode(err).String()
+	tradeOperations.WithLabelValues(operation, code).Inc()
+	tradeOperationDuration.WithLabelValues(operation, code).Observe(duration.Seconds())
+}
+
+// errorCode reports the errcode an error would be answered with.
+func errorCode(err error) errcode.ErrorCode {
+	if err == nil {
+		return errcode.ErrOK
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return errcode.ErrInternal
+}
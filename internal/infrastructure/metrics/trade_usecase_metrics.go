@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+	"points/internal/domain"
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+	"time"
+)
+
+type tradeUsecaseMetrics struct {
+	next domain.TradeUsecase
+}
+
+// InstrumentTradeUsecase counts the outcome and duration of every trade call.
+func InstrumentTradeUsecase(next domain.TradeUsecase) domain.TradeUsecase {
+	return &tradeUsecaseMetrics{next: next}
+}
+
+func (m *tradeUsecaseMetrics) Transfer(ctx context.Context, req *command.TransferCommand) (*entity.TradeRecords, error) {
+	start := time.Now()
+	trans, err := m.next.Transfer(ctx, req)
+	observeTradeOperation("transfer", err, time.Since(start))
+	return trans, err
+}
+
+func (m *tradeUsecaseMetrics) ManualConfirm(ctx context.Context, req *command.ConfirmCommand) (*entity.TradeRecords, error) {
+	start := time.Now()
+	trans, err := m.next.ManualConfirm(ctx, req)
+	observeTradeOperation("confirm", err, time.Since(start))
+	return trans, err
+}
+
+func (m *tradeUsecaseMetrics) Cancel(ctx context.Context, req *command.CancelCommand) (*entity.TradeRecords, error) {
+	start := time.Now()
+	trans, err := m.next.Cancel(ctx, req)
+	observeTradeOperation("cancel", err, time.Since(start))
+	return trans, err
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"points/internal/domain/command"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentTradeUsecase_CountsOutcomesByErrorCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	next := mock.NewMockTradeUsecase(ctrl)
+	usecase := InstrumentTradeUsecase(next)
+	ctx := context.Background()
+
+	okBefore := testutil.ToFloat64(tradeOperations.WithLabelValues("transfer", errcode.ErrOK.String()))
+	insufficientBefore := testutil.ToFloat64(tradeOperations.WithLabelValues("transfer", errcode.ErrInsufficientBalance.String()))
+	internalBefore := testutil.ToFloat64(tradeOperations.WithLabelValues("cancel", errcode.ErrInternal.String()))
+
+	next.EXPECT().Transfer(ctx, gomock.Any()).Return(nil, nil).Times(1)
+	next.EXPECT().Transfer(ctx, gomock.Any()).Return(nil, apperror.Wrap(errcode.ErrInsufficientBalance, "try phase", nil)).Times(1)
+	next.EXPECT().Cancel(ctx, gomock.Any()).Return(nil, errors.New("boom")).Times(1)
+
+	_, _ = usecase.Transfer(ctx, &command.TransferCommand{})
+	_, _ = usecase.Transfer(ctx, &command.TransferCommand{})
+	_, err := usecase.Cancel(ctx, &command.CancelCommand{})
+
+	assert.EqualError(t, err, "boom", "errors should pass through unchanged")
+	assert.Equal(t, okBefore+1, testutil.ToFloat64(tradeOperations.WithLabelValues("transfer", errcode.ErrOK.String())))
+	assert.Equal(t, insufficientBefore+1, testutil.ToFloat64(tradeOperations.WithLabelValues("transfer", errcode.ErrInsufficientBalance.String())))
+	assert.Equal(t, internalBefore+1, testutil.ToFloat64(tradeOperations.WithLabelValues("cancel", errcode.ErrInternal.String())))
+}
@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestVersion_MatchesNewestMigrationFile(t *testing.T) {
+	files, err := filepath.Glob("../../../migrations/*.up.sql")
+	assert.NoError(t, err)
+
+	var newest uint64
+	for _, file := range files {
+		version, err := strconv.ParseUint(strings.SplitN(filepath.Base(file), "_", 2)[0], 10, 64)
+		assert.NoError(t, err)
+		newest = max(newest, version)
+	}
+
+	version, err := LatestVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, uint(newest), version)
+}
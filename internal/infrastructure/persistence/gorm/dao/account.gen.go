@@ -31,6 +31,7 @@ func newAccount(db *gorm.DB, opts ...gen.DOOption) account {
 	_account.AvailableBalance = field.NewField(tableName, "available_balance")
 	_account.ReservedBalance = field.NewField(tableName, "reserved_balance")
 	_account.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_account.AssetCode = field.NewString(tableName, "asset_code")
 
 	_account.fillFieldMap()
 
@@ -45,6 +46,7 @@ type account struct {
 	AvailableBalance field.Field
 	ReservedBalance  field.Field
 	UpdatedAt        field.Time
+	AssetCode        field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -65,6 +67,7 @@ func (a *account) updateTableName(table string) *account {
 	a.AvailableBalance = field.NewField(table, "available_balance")
 	a.ReservedBalance = field.NewField(table, "reserved_balance")
 	a.UpdatedAt = field.NewTime(table, "updated_at")
+	a.AssetCode = field.NewString(table, "asset_code")
 
 	a.fillFieldMap()
 
@@ -81,11 +84,12 @@ func (a *account) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (a *account) fillFieldMap() {
-	a.fieldMap = make(map[string]field.Expr, 4)
+	a.fieldMap = make(map[string]field.Expr, 5)
 	a.fieldMap["user_id"] = a.UserID
 	a.fieldMap["available_balance"] = a.AvailableBalance
 	a.fieldMap["reserved_balance"] = a.ReservedBalance
 	a.fieldMap["updated_at"] = a.UpdatedAt
+	a.fieldMap["asset_code"] = a.AssetCode
 }
 
 func (a account) clone(db *gorm.DB) account {
@@ -35,6 +35,11 @@ func newTradeRecord(db *gorm.DB, opts ...gen.DOOption) tradeRecord {
 	_tradeRecord.Status = field.NewInt32(tableName, "status")
 	_tradeRecord.CreatedAt = field.NewTime(tableName, "created_at")
 	_tradeRecord.UpdatedAt = field.NewTime(tableName, "updated_at")
+	_tradeRecord.ExpiredAt = field.NewTime(tableName, "expired_at")
+	_tradeRecord.TradeType = field.NewString(tableName, "trade_type")
+	_tradeRecord.ReasonCode = field.NewString(tableName, "reason_code")
+	_tradeRecord.ExternalReference = field.NewString(tableName, "external_reference")
+	_tradeRecord.AssetCode = field.NewString(tableName, "asset_code")
 
 	_tradeRecord.fillFieldMap()
 
@@ -44,15 +49,20 @@ func newTradeRecord(db *gorm.DB, opts ...gen.DOOption) tradeRecord {
 type tradeRecord struct {
 	tradeRecordDo
 
-	ALL           field.Asterisk
-	TransactionID field.String
-	Nonce         field.Int64
-	FromAccountID field.Int64
-	ToAccountID   field.Int64
-	Amount        field.Field
-	Status        field.Int32
-	CreatedAt     field.Time
-	UpdatedAt     field.Time
+	ALL               field.Asterisk
+	TransactionID     field.String
+	Nonce             field.Int64
+	FromAccountID     field.Int64
+	ToAccountID       field.Int64
+	Amount            field.Field
+	Status            field.Int32
+	CreatedAt         field.Time
+	UpdatedAt         field.Time
+	ExpiredAt         field.Time
+	TradeType         field.String
+	ReasonCode        field.String
+	ExternalReference field.String
+	AssetCode         field.String
 
 	fieldMap map[string]field.Expr
 }
@@ -77,6 +87,11 @@ func (t *tradeRecord) updateTableName(table string) *tradeRecord {
 	t.Status = field.NewInt32(table, "status")
 	t.CreatedAt = field.NewTime(table, "created_at")
 	t.UpdatedAt = field.NewTime(table, "updated_at")
+	t.ExpiredAt = field.NewTime(table, "expired_at")
+	t.TradeType = field.NewString(table, "trade_type")
+	t.ReasonCode = field.NewString(table, "reason_code")
+	t.ExternalReference = field.NewString(table, "external_reference")
+	t.AssetCode = field.NewString(table, "asset_code")
 
 	t.fillFieldMap()
 
@@ -93,7 +108,7 @@ func (t *tradeRecord) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (t *tradeRecord) fillFieldMap() {
-	t.fieldMap = make(map[string]field.Expr, 8)
+	t.fieldMap = make(map[string]field.Expr, 13)
 	t.fieldMap["transaction_id"] = t.TransactionID
 	t.fieldMap["nonce"] = t.Nonce
 	t.fieldMap["from_account_id"] = t.FromAccountID
@@ -102,6 +117,11 @@ func (t *tradeRecord) fillFieldMap() {
 	t.fieldMap["status"] = t.Status
 	t.fieldMap["created_at"] = t.CreatedAt
 	t.fieldMap["updated_at"] = t.UpdatedAt
+	t.fieldMap["expired_at"] = t.ExpiredAt
+	t.fieldMap["trade_type"] = t.TradeType
+	t.fieldMap["reason_code"] = t.ReasonCode
+	t.fieldMap["external_reference"] = t.ExternalReference
+	t.fieldMap["asset_code"] = t.AssetCode
 }
 
 func (t tradeRecord) clone(db *gorm.DB) tradeRecord {
@@ -32,6 +32,7 @@ func newTransactionEvent(db *gorm.DB, opts ...gen.DOOption) transactionEvent {
 	_transactionEvent.EventType = field.NewString(tableName, "event_type")
 	_transactionEvent.Payload = field.NewString(tableName, "payload")
 	_transactionEvent.CreatedAt = field.NewTime(tableName, "created_at")
+	_transactionEvent.PublishedAt = field.NewTime(tableName, "published_at")
 
 	_transactionEvent.fillFieldMap()
 
@@ -47,6 +48,7 @@ type transactionEvent struct {
 	EventType     field.String
 	Payload       field.String
 	CreatedAt     field.Time
+	PublishedAt   field.Time
 
 	fieldMap map[string]field.Expr
 }
@@ -68,6 +70,7 @@ func (t *transactionEvent) updateTableName(table string) *transactionEvent {
 	t.EventType = field.NewString(table, "event_type")
 	t.Payload = field.NewString(table, "payload")
 	t.CreatedAt = field.NewTime(table, "created_at")
+	t.PublishedAt = field.NewTime(table, "published_at")
 
 	t.fillFieldMap()
 
@@ -84,12 +87,13 @@ func (t *transactionEvent) GetFieldByName(fieldName string) (field.OrderExpr, bo
 }
 
 func (t *transactionEvent) fillFieldMap() {
-	t.fieldMap = make(map[string]field.Expr, 5)
+	t.fieldMap = make(map[string]field.Expr, 6)
 	t.fieldMap["id"] = t.ID
 	t.fieldMap["transaction_id"] = t.TransactionID
 	t.fieldMap["event_type"] = t.EventType
 	t.fieldMap["payload"] = t.Payload
 	t.fieldMap["created_at"] = t.CreatedAt
+	t.fieldMap["published_at"] = t.PublishedAt
 }
 
 func (t transactionEvent) clone(db *gorm.DB) transactionEvent {
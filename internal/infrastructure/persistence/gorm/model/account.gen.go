@@ -18,6 +18,8 @@ type Account struct {
 	AvailableBalance decimal.Decimal `gorm:"column:available_balance;not null" json:"available_balance"`
 	ReservedBalance  decimal.Decimal `gorm:"column:reserved_balance;not null" json:"reserved_balance"`
 	UpdatedAt        time.Time       `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	AssetCode        string          `gorm:"column:asset_code;primaryKey;default:POINTS" json:"asset_code"`
+	FencingToken     int64           `gorm:"column:fencing_token;not null" json:"fencing_token"`
 }
 
 // TableName Account's table name
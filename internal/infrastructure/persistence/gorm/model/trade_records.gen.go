@@ -14,14 +14,19 @@ const TableNameTradeRecord = "trade_records"
 
 // TradeRecord mapped from table <trade_records>
 type TradeRecord struct {
-	TransactionID string          `gorm:"column:transaction_id;not null;default:gen_random_uuid()" json:"transaction_id"`
-	Nonce         int64           `gorm:"column:nonce;primaryKey" json:"nonce"`
-	FromAccountID int64           `gorm:"column:from_account_id;primaryKey" json:"from_account_id"`
-	ToAccountID   int64           `gorm:"column:to_account_id;not null" json:"to_account_id"`
-	Amount        decimal.Decimal `gorm:"column:amount;not null" json:"amount"`
-	Status        int32           `gorm:"column:status;not null" json:"status"`
-	CreatedAt     time.Time       `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt     time.Time       `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	TransactionID     string          `gorm:"column:transaction_id;not null;default:gen_random_uuid()" json:"transaction_id"`
+	Nonce             int64           `gorm:"column:nonce;primaryKey" json:"nonce"`
+	FromAccountID     int64           `gorm:"column:from_account_id;primaryKey" json:"from_account_id"`
+	ToAccountID       int64           `gorm:"column:to_account_id;not null" json:"to_account_id"`
+	Amount            decimal.Decimal `gorm:"column:amount;not null" json:"amount"`
+	Status            int32           `gorm:"column:status;not null" json:"status"`
+	CreatedAt         time.Time       `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time       `gorm:"column:updated_at;not null;default:CURRENT_TIMESTAMP" json:"updated_at"`
+	ExpiredAt         *time.Time      `gorm:"column:expired_at" json:"expired_at"`
+	TradeType         string          `gorm:"column:trade_type;not null;default:transfer" json:"trade_type"`
+	ReasonCode        string          `gorm:"column:reason_code;not null" json:"reason_code"`
+	ExternalReference string          `gorm:"column:external_reference;not null" json:"external_reference"`
+	AssetCode         string          `gorm:"column:asset_code;not null;default:POINTS" json:"asset_code"`
 }
 
 // TableName TradeRecord's table name
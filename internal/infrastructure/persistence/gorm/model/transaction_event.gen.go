@@ -12,11 +12,12 @@ const TableNameTransactionEvent = "transaction_event"
 
 // TransactionEvent mapped from table <transaction_event>
 type TransactionEvent struct {
-	ID            int32     `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
-	TransactionID string    `gorm:"column:transaction_id;not null" json:"transaction_id"`
-	EventType     string    `gorm:"column:event_type;not null" json:"event_type"`
-	Payload       string    `gorm:"column:payload" json:"payload"`
-	CreatedAt     time.Time `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	ID            int32      `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
+	TransactionID string     `gorm:"column:transaction_id;not null" json:"transaction_id"`
+	EventType     string     `gorm:"column:event_type;not null" json:"event_type"`
+	Payload       string     `gorm:"column:payload" json:"payload"`
+	CreatedAt     time.Time  `gorm:"column:created_at;not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	PublishedAt   *time.Time `gorm:"column:published_at" json:"published_at"`
 }
 
 // TableName TransactionEvent's table name
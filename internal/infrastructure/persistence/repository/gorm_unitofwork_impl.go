@@ -2,12 +2,17 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"points/internal/domain/port"
 	"points/internal/domain/repository"
+	"points/internal/shared/telemetry"
 
+	"go.opentelemetry.io/otel"
 	"gorm.io/gorm"
 )
 
+var tracer = otel.Tracer("points/internal/infrastructure/persistence/repository")
+
 type gormUnitOfWorkImpl struct {
 	db                *gorm.DB
 	tx                *gorm.DB
@@ -15,6 +20,8 @@ type gormUnitOfWorkImpl struct {
 	accountRepository repository.AccountRepository
 	transactionRepo   repository.TradeRecordsRepository
 	eventRepository   repository.TransactionEventRepository
+	ledgerRepository  repository.LedgerRepository
+	lotRepository     repository.PointLotRepository
 	config            port.Config
 }
 
@@ -28,6 +35,8 @@ func NewGormUnitOfWorkImpl(db *gorm.DB, config port.Config) repository.UnitOfWor
 		accountRepository: nil,
 		transactionRepo:   nil,
 		eventRepository:   nil,
+		ledgerRepository:  nil,
+		lotRepository:     nil,
 	}
 }
 
@@ -52,11 +61,43 @@ func (u *gormUnitOfWorkImpl) TransactionEventRepository() repository.Transaction
 	return u.eventRepository
 }
 
-func (u *gormUnitOfWorkImpl) Transaction(ctx context.Context, fn func(uow repository.UnitOfWork) error) error {
+func (u *gormUnitOfWorkImpl) LedgerRepository() repository.LedgerRepository {
+	if u.ledgerRepository == nil {
+		u.ledgerRepository = NewLedgerRepo(u.getCurrentDB(), u.config)
+	}
+	return u.ledgerRepository
+}
+
+func (u *gormUnitOfWorkImpl) PointLotRepository() repository.PointLotRepository {
+	if u.lotRepository == nil {
+		u.lotRepository = NewPointLotRepo(u.getCurrentDB(), u.config)
+	}
+	return u.lotRepository
+}
+
+func (u *gormUnitOfWorkImpl) Transaction(ctx context.Context, fn func(uow repository.UnitOfWork) error) (err error) {
 	if u.isTransaction {
 		return fn(u)
 	}
 
+	ctx, span := tracer.Start(ctx, "uow.Transaction")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return u.transaction(ctx, fn)
+}
+
+func (u *gormUnitOfWorkImpl) SnapshotTransaction(ctx context.Context, fn func(uow repository.UnitOfWork) error) (err error) {
+	if u.isTransaction {
+		return fn(u)
+	}
+
+	ctx, span := tracer.Start(ctx, "uow.SnapshotTransaction")
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	return u.transaction(ctx, fn, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
+
+func (u *gormUnitOfWorkImpl) transaction(ctx context.Context, fn func(uow repository.UnitOfWork) error, opts ...*sql.TxOptions) error {
 	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		uow := &gormUnitOfWorkImpl{
 			tx:                tx,
@@ -64,10 +105,12 @@ func (u *gormUnitOfWorkImpl) Transaction(ctx context.Context, fn func(uow reposi
 			accountRepository: nil,
 			transactionRepo:   nil,
 			eventRepository:   nil,
+			ledgerRepository:  nil,
+			lotRepository:     nil,
 			config:            u.config,
 		}
 		return fn(uow)
-	})
+	}, opts...)
 }
 
 func (u *gormUnitOfWorkImpl) DB(ctx context.Context) *gorm.DB {
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"points/internal/domain/entity"
 	"points/internal/domain/port"
 	"points/internal/domain/repository"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ repository.AccountRepository = (*accountRepo)(nil)
@@ -24,55 +26,189 @@ func NewAccountRepo(tx *gorm.DB, config port.Config) repository.AccountRepositor
 	return &accountRepo{tx: tx, config: config}
 }
 
-func (r *accountRepo) CreateAccount(ctx context.Context, userID int64) error {
-	return r.tx.WithContext(ctx).Create(&model.Account{
+// CreateAccount leaves an account created concurrently by another caller as
+// it is, since the system account is created on first use without a lock.
+func (r *accountRepo) CreateAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) error {
+	ctx, span := tracer.Start(ctx, "accountRepo.CreateAccount")
+	defer span.End()
+
+	return r.tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Account{
 		UserID:           userID,
+		AssetCode:        asset.OrDefault().String(),
 		AvailableBalance: decimal.Zero,
 		ReservedBalance:  decimal.Zero,
 	}).Error
 }
 
-func (r *accountRepo) GetAccount(ctx context.Context, userID int64) (*entity.Account, error) {
+func (r *accountRepo) GetAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "accountRepo.GetAccount")
+	defer span.End()
+
 	var account model.Account
 	err := r.tx.WithContext(ctx).
-		Where(&model.Account{UserID: userID}).
+		Where("user_id = ? AND asset_code = ?", userID, asset.OrDefault().String()).
 		First(&account).Error
 	if err != nil {
 		return nil, err
 	}
 
-	domainAccount, err := mapper.MapStruct[entity.Account](r.config, &account)
+	return r.toDomainModel(&account)
+}
+
+// GetAccountForUpdate uses SELECT ... FOR UPDATE, so concurrent balance
+// changes to the account queue behind the calling unit of work.
+func (r *accountRepo) GetAccountForUpdate(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "accountRepo.GetAccountForUpdate")
+	defer span.End()
+
+	var account model.Account
+	err := r.tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND asset_code = ?", userID, asset.OrDefault().String()).
+		First(&account).Error
 	if err != nil {
 		return nil, err
 	}
 
-	return domainAccount, nil
+	return r.toDomainModel(&account)
+}
+
+func (r *accountRepo) ListAccounts(ctx context.Context) ([]*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "accountRepo.ListAccounts")
+	defer span.End()
+
+	var accounts []model.Account
+	if err := r.tx.WithContext(ctx).Order("user_id ASC, asset_code ASC").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	return r.toDomainModels(accounts)
+}
+
+func (r *accountRepo) ListUserAccounts(ctx context.Context, userID int64) ([]*entity.Account, error) {
+	ctx, span := tracer.Start(ctx, "accountRepo.ListUserAccounts")
+	defer span.End()
+
+	var accounts []model.Account
+	err := r.tx.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("asset_code ASC").
+		Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainModels(accounts)
 }
 
 func (r *accountRepo) ReserveBalance(ctx context.Context, userID int64, amount valueobject.Money) error {
-	return r.tx.WithContext(ctx).Model(&model.Account{}).
-		Where(&model.Account{UserID: userID}).
-		Updates(map[string]interface{}{
-			"available_balance": gorm.Expr("available_balance - ?", amount.Value()),
-			"reserved_balance":  gorm.Expr("reserved_balance + ?", amount.Value()),
-		}).Error
+	ctx, span := tracer.Start(ctx, "accountRepo.ReserveBalance")
+	defer span.End()
+
+	return r.updateBalance(ctx, userID, amount.Asset(), map[string]interface{}{
+		"available_balance": gorm.Expr("available_balance - ?", amount.Value()),
+		"reserved_balance":  gorm.Expr("reserved_balance + ?", amount.Value()),
+	})
 }
 
 func (r *accountRepo) UnreserveBalance(ctx context.Context, from, to int64, amount valueobject.Money) error {
-	err := r.tx.WithContext(ctx).Model(&model.Account{}).
-		Where(&model.Account{UserID: from}).
-		Updates(map[string]interface{}{
-			"reserved_balance": gorm.Expr("reserved_balance - ?", amount.Value()),
-		}).Error
+	ctx, span := tracer.Start(ctx, "accountRepo.UnreserveBalance")
+	defer span.End()
+
+	err := r.updateBalance(ctx, from, amount.Asset(), map[string]interface{}{
+		"reserved_balance": gorm.Expr("reserved_balance - ?", amount.Value()),
+	})
 
 	if err != nil {
 		return err
 	}
-	err = r.tx.WithContext(ctx).Model(&model.Account{}).
-		Where(&model.Account{UserID: to}).
-		Updates(map[string]interface{}{
-			"available_balance": gorm.Expr("available_balance + (?::numeric)", amount.Value()),
-		}).Error
+	err = r.updateBalance(ctx, to, amount.Asset(), map[string]interface{}{
+		"available_balance": gorm.Expr("available_balance + (?::numeric)", amount.Value()),
+	})
 
 	return err
 }
+
+func (r *accountRepo) CreditBalance(ctx context.Context, userID int64, amount valueobject.Money) error {
+	ctx, span := tracer.Start(ctx, "accountRepo.CreditBalance")
+	defer span.End()
+
+	return r.updateBalance(ctx, userID, amount.Asset(), map[string]interface{}{
+		"available_balance": gorm.Expr("available_balance + (?::numeric)", amount.Value()),
+	})
+}
+
+// DebitBalance relies on the account CHECK constraint to reject overdrafts.
+func (r *accountRepo) DebitBalance(ctx context.Context, userID int64, amount valueobject.Money) error {
+	ctx, span := tracer.Start(ctx, "accountRepo.DebitBalance")
+	defer span.End()
+
+	return r.updateBalance(ctx, userID, amount.Asset(), map[string]interface{}{
+		"available_balance": gorm.Expr("available_balance - ?", amount.Value()),
+	})
+}
+
+// updateBalance applies updates to one account row. When ctx carries a
+// fencing token the row is only written if no newer lock holder has written
+// it, and the token is recorded on it.
+func (r *accountRepo) updateBalance(ctx context.Context, userID int64, asset valueobject.AssetCode, updates map[string]interface{}) error {
+	token, fenced := valueobject.FencingTokenFromContext(ctx)
+	if !fenced {
+		result := r.tx.WithContext(ctx).Model(&model.Account{}).
+			Where("user_id = ? AND asset_code = ?", userID, asset.String()).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("account %d: %w", userID, repository.ErrAccountNotFound)
+		}
+		return nil
+	}
+
+	updates["fencing_token"] = token
+	result := r.tx.WithContext(ctx).Model(&model.Account{}).
+		Where("user_id = ? AND asset_code = ?", userID, asset.String()).
+		Where("fencing_token <= ?", token).
+		Updates(updates)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return result.Error
+	}
+
+	var newer int64
+	err := r.tx.WithContext(ctx).Model(&model.Account{}).
+		Where("user_id = ? AND asset_code = ?", userID, asset.String()).
+		Where("fencing_token > ?", token).
+		Count(&newer).Error
+	if err != nil {
+		return err
+	}
+	if newer > 0 {
+		return fmt.Errorf("account %d: %w", userID, repository.ErrStaleFencingToken)
+	}
+	return fmt.Errorf("account %d: %w", userID, repository.ErrAccountNotFound)
+}
+
+// toDomainModel binds both balances to the asset of the row they were read from.
+func (r *accountRepo) toDomainModel(account *model.Account) (*entity.Account, error) {
+	domainAccount, err := mapper.MapStruct[entity.Account](r.config, account)
+	if err != nil {
+		return nil, err
+	}
+
+	domainAccount.AvailableBalance = domainAccount.AvailableBalance.WithAsset(domainAccount.AssetCode)
+	domainAccount.ReservedBalance = domainAccount.ReservedBalance.WithAsset(domainAccount.AssetCode)
+	return domainAccount, nil
+}
+
+func (r *accountRepo) toDomainModels(accounts []model.Account) ([]*entity.Account, error) {
+	domainAccounts := make([]*entity.Account, 0, len(accounts))
+	for i := range accounts {
+		domainAccount, err := r.toDomainModel(&accounts[i])
+		if err != nil {
+			return nil, err
+		}
+		domainAccounts = append(domainAccounts, domainAccount)
+	}
+	return domainAccounts, nil
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"points/internal/domain/repository"
 	"points/internal/domain/valueobject"
 	"points/internal/infrastructure"
 	"points/internal/infrastructure/persistence/gorm/model"
 	"points/test"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -19,10 +22,14 @@ func TestCreateAccount(t *testing.T) {
 	userId := int64(1)
 	ctx := context.Background()
 
-	if err := repoImpl.CreateAccount(ctx, userId); err != nil {
+	if err := repoImpl.CreateAccount(ctx, userId, valueobject.DefaultAssetCode); err != nil {
 		t.Fatalf("CreateAccount error: %v", err)
 	}
 
+	if err := repoImpl.CreateAccount(ctx, userId, valueobject.DefaultAssetCode); err != nil {
+		t.Fatalf("CreateAccount of an existing account error: %v", err)
+	}
+
 	var gotAccount model.Account
 	if err := db.First(&gotAccount, "user_id = ?", userId).Error; err != nil {
 		t.Fatalf("failed to get account: %v", err)
@@ -50,7 +57,7 @@ func TestReserveBalance(t *testing.T) {
 		t.Fatalf("UpdateAccount error: %v", err)
 	}
 
-	updated, err := repoImpl.GetAccount(ctx, userId)
+	updated, err := repoImpl.GetAccount(ctx, userId, valueobject.DefaultAssetCode)
 	if err != nil {
 		t.Fatalf("GetAccount error: %v", err)
 	}
@@ -59,6 +66,59 @@ func TestReserveBalance(t *testing.T) {
 	}
 }
 
+func TestReserveBalanceRejectsStaleFencingToken(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewAccountRepo(db, config)
+	userId := int64(1)
+
+	account := model.Account{
+		UserID:           userId,
+		AvailableBalance: decimal.NewFromInt(100),
+		ReservedBalance:  decimal.Zero,
+	}
+	if err := db.Create(&account).Error; err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(20))
+	if err := repoImpl.ReserveBalance(valueobject.WithFencingToken(context.Background(), 10), userId, amount); err != nil {
+		t.Fatalf("ReserveBalance with newer token error: %v", err)
+	}
+
+	err := repoImpl.ReserveBalance(valueobject.WithFencingToken(context.Background(), 9), userId, amount)
+	if !errors.Is(err, repository.ErrStaleFencingToken) {
+		t.Fatalf("expected ErrStaleFencingToken, got %v", err)
+	}
+
+	updated, err := repoImpl.GetAccount(context.Background(), userId, valueobject.DefaultAssetCode)
+	if err != nil {
+		t.Fatalf("GetAccount error: %v", err)
+	}
+	if !updated.ReservedBalance.Equals(amount) {
+		t.Errorf("stale write was applied: reserved = %v", updated.ReservedBalance)
+	}
+}
+
+func TestUpdateBalanceRejectsMissingAccount(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewAccountRepo(db, config)
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(20))
+
+	err := repoImpl.CreditBalance(context.Background(), 42, amount)
+	if !errors.Is(err, repository.ErrAccountNotFound) {
+		t.Fatalf("expected ErrAccountNotFound, got %v", err)
+	}
+
+	err = repoImpl.CreditBalance(valueobject.WithFencingToken(context.Background(), 10), 42, amount)
+	if !errors.Is(err, repository.ErrAccountNotFound) {
+		t.Fatalf("expected ErrAccountNotFound with a fencing token, got %v", err)
+	}
+}
+
 func TestUnreserveBalance(t *testing.T) {
 	db := test.NewTestContainerDB(t)
 	copier := infrastructure.NewCopierImpl()
@@ -89,14 +149,14 @@ func TestUnreserveBalance(t *testing.T) {
 		t.Fatalf("UpdateAccount error: %v", err)
 	}
 
-	fromAccount, err := repoImpl.GetAccount(ctx, from)
+	fromAccount, err := repoImpl.GetAccount(ctx, from, valueobject.DefaultAssetCode)
 	if err != nil {
 		t.Fatalf("GetAccount error: %v", err)
 	}
 	if !fromAccount.ReservedBalance.Equals(valueobject.NewMoneyFromDecimal(decimal.NewFromInt(80))) || !fromAccount.AvailableBalance.Equals(valueobject.Zero) {
 		t.Errorf("account not updated correctly: available = %v, reserved = %v", fromAccount.AvailableBalance, fromAccount.ReservedBalance)
 	}
-	toAccount, err := repoImpl.GetAccount(ctx, to)
+	toAccount, err := repoImpl.GetAccount(ctx, to, valueobject.DefaultAssetCode)
 	if err != nil {
 		t.Fatalf("GetAccount error: %v", err)
 	}
@@ -122,7 +182,7 @@ func TestGetAccount(t *testing.T) {
 		t.Fatalf("failed to create account: %v", err)
 	}
 
-	gotAccount, err := repoImpl.GetAccount(ctx, userId)
+	gotAccount, err := repoImpl.GetAccount(ctx, userId, valueobject.DefaultAssetCode)
 	if err != nil {
 		t.Fatalf("GetAccount error: %v", err)
 	}
@@ -140,3 +200,127 @@ func TestGetAccount(t *testing.T) {
 		t.Errorf("expected reserved balance %v, got %v", expectedReserved, gotAccount.ReservedBalance)
 	}
 }
+
+func TestGetAccountForUpdateBlocksOtherTransactions(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	ctx := context.Background()
+	userId := int64(43)
+
+	if err := db.Create(&model.Account{UserID: userId, AvailableBalance: decimal.NewFromInt(100)}).Error; err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	holder := db.Begin()
+	if _, err := NewAccountRepo(holder, config).GetAccountForUpdate(ctx, userId, valueobject.DefaultAssetCode); err != nil {
+		t.Fatalf("GetAccountForUpdate error: %v", err)
+	}
+
+	waiter := db.Begin()
+	defer waiter.Rollback()
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewAccountRepo(waiter, config).GetAccountForUpdate(ctx, userId, valueobject.DefaultAssetCode)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		t.Fatalf("expected the second transaction to wait for the row lock, got %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := holder.Commit().Error; err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("GetAccountForUpdate error after commit: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second transaction still waiting after the first committed")
+	}
+}
+
+func TestCreditAndDebitBalance(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewAccountRepo(db, config)
+	ctx := context.Background()
+	userId := int64(1)
+
+	account := model.Account{
+		UserID:           userId,
+		AvailableBalance: decimal.NewFromInt(100),
+		ReservedBalance:  decimal.Zero,
+	}
+	if err := db.Create(&account).Error; err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	if err := repoImpl.CreditBalance(ctx, userId, valueobject.NewMoneyFromDecimal(decimal.NewFromInt(50))); err != nil {
+		t.Fatalf("CreditBalance error: %v", err)
+	}
+	if err := repoImpl.DebitBalance(ctx, userId, valueobject.NewMoneyFromDecimal(decimal.NewFromInt(30))); err != nil {
+		t.Fatalf("DebitBalance error: %v", err)
+	}
+
+	updated, err := repoImpl.GetAccount(ctx, userId, valueobject.DefaultAssetCode)
+	if err != nil {
+		t.Fatalf("GetAccount error: %v", err)
+	}
+	if !updated.AvailableBalance.Equals(valueobject.NewMoneyFromDecimal(decimal.NewFromInt(120))) {
+		t.Errorf("account not updated correctly: available = %v", updated.AvailableBalance)
+	}
+
+	if err := repoImpl.DebitBalance(ctx, userId, valueobject.NewMoneyFromDecimal(decimal.NewFromInt(500))); err == nil {
+		t.Errorf("expected overdraft to be rejected by the balance constraint")
+	}
+}
+
+func TestBalancesAreKeyedByAsset(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewAccountRepo(db, config)
+	ctx := context.Background()
+	userId := int64(1)
+	cashback := valueobject.AssetCode("CASHBACK")
+
+	if err := repoImpl.CreateAccount(ctx, userId, valueobject.DefaultAssetCode); err != nil {
+		t.Fatalf("CreateAccount error: %v", err)
+	}
+	if err := repoImpl.CreateAccount(ctx, userId, cashback); err != nil {
+		t.Fatalf("CreateAccount error: %v", err)
+	}
+	if err := repoImpl.CreditBalance(ctx, userId, valueobject.NewMoney(decimal.NewFromInt(40), cashback)); err != nil {
+		t.Fatalf("CreditBalance error: %v", err)
+	}
+
+	points, err := repoImpl.GetAccount(ctx, userId, valueobject.DefaultAssetCode)
+	if err != nil {
+		t.Fatalf("GetAccount error: %v", err)
+	}
+	if !points.AvailableBalance.Equals(valueobject.Zero) {
+		t.Errorf("default asset should be untouched: available = %v", points.AvailableBalance)
+	}
+
+	credited, err := repoImpl.GetAccount(ctx, userId, cashback)
+	if err != nil {
+		t.Fatalf("GetAccount error: %v", err)
+	}
+	if !credited.AvailableBalance.Equals(valueobject.NewMoney(decimal.NewFromInt(40), cashback)) {
+		t.Errorf("cashback not credited: available = %v %s", credited.AvailableBalance, credited.AvailableBalance.Asset())
+	}
+
+	accounts, err := repoImpl.ListUserAccounts(ctx, userId)
+	if err != nil {
+		t.Fatalf("ListUserAccounts error: %v", err)
+	}
+	if len(accounts) != 2 || accounts[0].AssetCode != cashback || accounts[1].AssetCode != valueobject.DefaultAssetCode {
+		t.Errorf("expected one balance per asset ordered by code, got %+v", accounts)
+	}
+}
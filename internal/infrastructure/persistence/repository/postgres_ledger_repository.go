@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"points/internal/domain/entity"
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure/persistence/gorm/model"
+	"points/internal/shared/mapper"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+var _ repository.LedgerRepository = (*ledgerRepo)(nil)
+
+var errUnbalancedLedgerEntries = errors.New("ledger entries are not balanced")
+
+type ledgerRepo struct {
+	tx     *gorm.DB
+	config port.Config
+}
+
+func NewLedgerRepo(tx *gorm.DB, config port.Config) repository.LedgerRepository {
+	return &ledgerRepo{tx: tx, config: config}
+}
+
+func (r *ledgerRepo) CreateLedgerEntries(ctx context.Context, entries []*entity.LedgerEntry) error {
+	ctx, span := tracer.Start(ctx, "ledgerRepo.CreateLedgerEntries")
+	defer span.End()
+
+	if !entity.IsLedgerBalanced(entries) {
+		return errUnbalancedLedgerEntries
+	}
+
+	ormModels := make([]*model.LedgerEntry, 0, len(entries))
+	for _, entry := range entries {
+		ormModel, err := mapper.MapStruct[model.LedgerEntry](r.config, entry)
+		if err != nil {
+			return err
+		}
+		ormModel.AssetCode = entry.Amount.Asset().String()
+		ormModels = append(ormModels, ormModel)
+	}
+	return r.tx.WithContext(ctx).Create(&ormModels).Error
+}
+
+func (r *ledgerRepo) GetLedgerEntries(ctx context.Context, transactionID string) ([]*entity.LedgerEntry, error) {
+	ctx, span := tracer.Start(ctx, "ledgerRepo.GetLedgerEntries")
+	defer span.End()
+
+	var entries []model.LedgerEntry
+
+	err := r.tx.WithContext(ctx).
+		Where(&model.LedgerEntry{TransactionID: transactionID}).
+		Order("id ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	domainEntries := make([]*entity.LedgerEntry, 0, len(entries))
+	for i := range entries {
+		domainEntry, err := mapper.MapStruct[entity.LedgerEntry](r.config, &entries[i])
+		if err != nil {
+			return nil, err
+		}
+		domainEntry.Amount = domainEntry.Amount.WithAsset(valueobject.AssetCode(entries[i].AssetCode))
+		domainEntries = append(domainEntries, domainEntry)
+	}
+	return domainEntries, nil
+}
+
+func (r *ledgerRepo) GetLedgerBalance(ctx context.Context, accountID int64, asset valueobject.AssetCode, bucket valueobject.LedgerBucket) (valueobject.Money, error) {
+	ctx, span := tracer.Start(ctx, "ledgerRepo.GetLedgerBalance")
+	defer span.End()
+
+	var balance decimal.Decimal
+	err := r.tx.WithContext(ctx).Model(&model.LedgerEntry{}).
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", valueobject.LedgerCredit).
+		Where("account_id = ? AND asset_code = ? AND bucket = ?", accountID, asset.OrDefault().String(), string(bucket)).
+		Scan(&balance).Error
+	if err != nil {
+		return valueobject.ZeroOf(asset), err
+	}
+	return valueobject.NewMoney(balance, asset), nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure"
+	"points/test"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateLedgerEntriesAndGetLedgerBalance(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewLedgerRepo(db, config)
+	ctx := context.Background()
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(30))
+	txID := "00000000-0000-0000-0000-000000000001"
+
+	err := repoImpl.CreateLedgerEntries(ctx, entity.NewReserveLedgerEntries(txID, 1, amount))
+	assert.NoError(t, err, "error creating reserve entries")
+	err = repoImpl.CreateLedgerEntries(ctx, entity.NewSettleLedgerEntries(txID, 1, 2, amount))
+	assert.NoError(t, err, "error creating settle entries")
+
+	entries, err := repoImpl.GetLedgerEntries(ctx, txID)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 4)
+	assert.True(t, entity.IsLedgerBalanced(entries))
+
+	reserved, err := repoImpl.GetLedgerBalance(ctx, 1, valueobject.DefaultAssetCode, valueobject.LedgerBucketReserved)
+	assert.NoError(t, err)
+	assert.True(t, reserved.Equals(valueobject.Zero), "reserved bucket should be settled")
+
+	received, err := repoImpl.GetLedgerBalance(ctx, 2, valueobject.DefaultAssetCode, valueobject.LedgerBucketAvailable)
+	assert.NoError(t, err)
+	assert.True(t, received.Equals(amount), "receiver should be credited")
+
+	system, err := repoImpl.GetLedgerBalance(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode, valueobject.LedgerBucketAvailable)
+	assert.NoError(t, err)
+	assert.True(t, system.Equals(valueobject.Zero), "the system account should not sum other accounts' entries")
+
+	err = repoImpl.CreateLedgerEntries(ctx, entity.NewReserveLedgerEntries(txID, 1, amount)[:1])
+	assert.Error(t, err, "unbalanced entries should be rejected")
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"points/internal/domain/entity"
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure/persistence/gorm/model"
+	"points/internal/shared/mapper"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+var _ repository.PointLotRepository = (*pointLotRepo)(nil)
+
+type pointLotRepo struct {
+	tx     *gorm.DB
+	config port.Config
+}
+
+func NewPointLotRepo(tx *gorm.DB, config port.Config) repository.PointLotRepository {
+	return &pointLotRepo{tx: tx, config: config}
+}
+
+func (r *pointLotRepo) CreatePointLots(ctx context.Context, lots []*entity.PointLot) error {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.CreatePointLots")
+	defer span.End()
+
+	if len(lots) == 0 {
+		return nil
+	}
+
+	ormModels := make([]*model.PointLot, 0, len(lots))
+	for _, lot := range lots {
+		ormModel, err := mapper.MapStruct[model.PointLot](r.config, lot)
+		if err != nil {
+			return err
+		}
+		ormModel.AssetCode = lot.Amount.Asset().String()
+		ormModels = append(ormModels, ormModel)
+	}
+	if err := r.tx.WithContext(ctx).Create(&ormModels).Error; err != nil {
+		return err
+	}
+
+	for i, lot := range lots {
+		lot.ID = ormModels[i].ID
+	}
+	return nil
+}
+
+func (r *pointLotRepo) ListPointLots(ctx context.Context, accountID int64, asset valueobject.AssetCode) ([]*entity.PointLot, error) {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.ListPointLots")
+	defer span.End()
+
+	var lots []model.PointLot
+	err := r.tx.WithContext(ctx).
+		Where("account_id = ? AND asset_code = ?", accountID, asset.OrDefault().String()).
+		Where("remaining_amount > 0").
+		Order("expires_at ASC NULLS LAST, id ASC").
+		Find(&lots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainModels(lots)
+}
+
+func (r *pointLotRepo) ListExpiredPointLots(ctx context.Context, now time.Time, limit int) ([]*entity.PointLot, error) {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.ListExpiredPointLots")
+	defer span.End()
+
+	var lots []model.PointLot
+	err := r.tx.WithContext(ctx).
+		Where("expires_at <= ? AND remaining_amount > 0", now).
+		Order("expires_at ASC, id ASC").
+		Limit(limit).
+		Find(&lots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainModels(lots)
+}
+
+func (r *pointLotRepo) ReservePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.ReservePointLots")
+	defer span.End()
+
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	rows := make([]*model.PointLotAllocation, 0, len(allocations))
+	for _, allocation := range allocations {
+		err := r.tx.WithContext(ctx).Model(&model.PointLot{}).
+			Where(&model.PointLot{ID: allocation.LotID}).
+			Updates(map[string]interface{}{
+				"remaining_amount": gorm.Expr("remaining_amount - ?", allocation.Amount.Value()),
+				"reserved_amount":  gorm.Expr("reserved_amount + ?", allocation.Amount.Value()),
+			}).Error
+		if err != nil {
+			return err
+		}
+		rows = append(rows, &model.PointLotAllocation{
+			TransactionID: transactionID,
+			LotID:         allocation.LotID,
+			Amount:        allocation.Amount.Value(),
+		})
+	}
+	return r.tx.WithContext(ctx).Create(&rows).Error
+}
+
+func (r *pointLotRepo) GetLotAllocations(ctx context.Context, transactionID string) ([]*entity.LotAllocation, error) {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.GetLotAllocations")
+	defer span.End()
+
+	var rows []struct {
+		LotID     int64
+		Amount    decimal.Decimal
+		AssetCode string
+		ExpiresAt *time.Time
+	}
+	err := r.tx.WithContext(ctx).Table(model.TableNamePointLotAllocation+" AS a").
+		Select("a.lot_id, a.amount, l.asset_code, l.expires_at").
+		Joins("JOIN "+model.TableNamePointLot+" AS l ON l.id = a.lot_id").
+		Where("a.transaction_id = ?", transactionID).
+		Order("a.lot_id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := make([]*entity.LotAllocation, 0, len(rows))
+	for _, row := range rows {
+		allocations = append(allocations, &entity.LotAllocation{
+			LotID:     row.LotID,
+			Amount:    valueobject.NewMoney(row.Amount, valueobject.AssetCode(row.AssetCode)),
+			ExpiresAt: row.ExpiresAt,
+		})
+	}
+	return allocations, nil
+}
+
+func (r *pointLotRepo) ReleasePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.ReleasePointLots")
+	defer span.End()
+
+	return r.closeAllocations(ctx, transactionID, allocations, true)
+}
+
+func (r *pointLotRepo) SettlePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.SettlePointLots")
+	defer span.End()
+
+	return r.closeAllocations(ctx, transactionID, allocations, false)
+}
+
+// ConsumePointLots takes burned or expired amounts straight off the lots.
+func (r *pointLotRepo) ConsumePointLots(ctx context.Context, allocations []*entity.LotAllocation) error {
+	ctx, span := tracer.Start(ctx, "pointLotRepo.ConsumePointLots")
+	defer span.End()
+
+	for _, allocation := range allocations {
+		err := r.tx.WithContext(ctx).Model(&model.PointLot{}).
+			Where(&model.PointLot{ID: allocation.LotID}).
+			Update("remaining_amount", gorm.Expr("remaining_amount - ?", allocation.Amount.Value())).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeAllocations removes the reservation of a transfer from its lots,
+// handing it back to the lot when restore is set.
+func (r *pointLotRepo) closeAllocations(ctx context.Context, transactionID string, allocations []*entity.LotAllocation, restore bool) error {
+	for _, allocation := range allocations {
+		updates := map[string]interface{}{
+			"reserved_amount": gorm.Expr("reserved_amount - ?", allocation.Amount.Value()),
+		}
+		if restore {
+			updates["remaining_amount"] = gorm.Expr("remaining_amount + ?", allocation.Amount.Value())
+		}
+		err := r.tx.WithContext(ctx).Model(&model.PointLot{}).
+			Where(&model.PointLot{ID: allocation.LotID}).
+			Updates(updates).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return r.tx.WithContext(ctx).
+		Where(&model.PointLotAllocation{TransactionID: transactionID}).
+		Delete(&model.PointLotAllocation{}).Error
+}
+
+// toDomainModels binds every lot amount to the asset of the row it was read from.
+func (r *pointLotRepo) toDomainModels(lots []model.PointLot) ([]*entity.PointLot, error) {
+	domainLots := make([]*entity.PointLot, 0, len(lots))
+	for i := range lots {
+		domainLot, err := mapper.MapStruct[entity.PointLot](r.config, &lots[i])
+		if err != nil {
+			return nil, err
+		}
+		asset := valueobject.AssetCode(lots[i].AssetCode)
+		domainLot.Amount = domainLot.Amount.WithAsset(asset)
+		domainLot.RemainingAmount = domainLot.RemainingAmount.WithAsset(asset)
+		domainLot.ReservedAmount = domainLot.ReservedAmount.WithAsset(asset)
+		domainLots = append(domainLots, domainLot)
+	}
+	return domainLots, nil
+}
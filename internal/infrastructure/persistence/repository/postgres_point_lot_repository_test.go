@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+	"points/internal/infrastructure"
+	"points/test"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointLotLifecycle(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewPointLotRepo(db, config)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	soon, past := now.Add(time.Hour), now.Add(-time.Hour)
+	money := func(v int64) valueobject.Money { return valueobject.NewMoneyFromDecimal(decimal.NewFromInt(v)) }
+	mintID := "00000000-0000-0000-0000-000000000001"
+	transferID := "00000000-0000-0000-0000-000000000002"
+
+	lots := []*entity.PointLot{
+		entity.NewPointLot(1, mintID, money(50), nil),
+		entity.NewPointLot(1, mintID, money(30), &soon),
+		entity.NewPointLot(1, mintID, money(20), &past),
+	}
+	assert.NoError(t, repoImpl.CreatePointLots(ctx, lots))
+	assert.NotZero(t, lots[0].ID, "created lots should get their ids back")
+
+	listed, err := repoImpl.ListPointLots(ctx, 1, valueobject.DefaultAssetCode)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 3)
+	assert.Equal(t, lots[2].ID, listed[0].ID, "soonest expiry should come first")
+	assert.Equal(t, lots[0].ID, listed[2].ID, "lots without expiry should come last")
+
+	expired, err := repoImpl.ListExpiredPointLots(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, lots[2].ID, expired[0].ID)
+
+	allocations := []*entity.LotAllocation{{LotID: lots[1].ID, Amount: money(30)}, {LotID: lots[0].ID, Amount: money(10)}}
+	assert.NoError(t, repoImpl.ReservePointLots(ctx, transferID, allocations))
+
+	stored, err := repoImpl.GetLotAllocations(ctx, transferID)
+	assert.NoError(t, err)
+	assert.Len(t, stored, 2)
+	assert.True(t, entity.SumLotAllocations(stored).Equals(money(40)))
+
+	assert.NoError(t, repoImpl.ReleasePointLots(ctx, transferID, stored))
+	released, err := repoImpl.GetLotAllocations(ctx, transferID)
+	assert.NoError(t, err)
+	assert.Empty(t, released, "released allocations should be removed")
+
+	assert.NoError(t, repoImpl.ConsumePointLots(ctx, []*entity.LotAllocation{{LotID: lots[2].ID, Amount: money(20)}}))
+	expired, err = repoImpl.ListExpiredPointLots(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, expired, "consumed lots should no longer be listed")
+
+	listed, err = repoImpl.ListPointLots(ctx, 1, valueobject.DefaultAssetCode)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 2)
+	assert.True(t, listed[0].RemainingAmount.Equals(money(30)), "released amount should be spendable again")
+}
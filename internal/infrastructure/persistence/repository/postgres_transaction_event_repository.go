@@ -7,6 +7,7 @@ import (
 	"points/internal/domain/repository"
 	"points/internal/infrastructure/persistence/gorm/model"
 	"points/internal/shared/mapper"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -23,9 +24,69 @@ func NewTransactionEventRepo(tx *gorm.DB, config port.Config) repository.Transac
 }
 
 func (r *transactionEventRepo) CreateTransactionEvent(ctx context.Context, event *entity.TransactionEvent) error {
+	ctx, span := tracer.Start(ctx, "transactionEventRepo.CreateTransactionEvent")
+	defer span.End()
+
 	ormModel, err := mapper.MapStruct[model.TransactionEvent](r.config, event)
 	if err != nil {
 		return err
 	}
 	return r.tx.WithContext(ctx).Create(ormModel).Error
 }
+
+func (r *transactionEventRepo) GetTransactionEvents(ctx context.Context, transactionID string) ([]*entity.TransactionEvent, error) {
+	ctx, span := tracer.Start(ctx, "transactionEventRepo.GetTransactionEvents")
+	defer span.End()
+
+	var events []model.TransactionEvent
+
+	err := r.tx.WithContext(ctx).
+		Where(&model.TransactionEvent{TransactionID: transactionID}).
+		Order("id ASC").
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainEvents(events)
+}
+
+func (r *transactionEventRepo) GetUnpublishedTransactionEvents(ctx context.Context, limit int) ([]*entity.TransactionEvent, error) {
+	ctx, span := tracer.Start(ctx, "transactionEventRepo.GetUnpublishedTransactionEvents")
+	defer span.End()
+
+	var events []model.TransactionEvent
+
+	err := r.tx.WithContext(ctx).
+		Where("published_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainEvents(events)
+}
+
+func (r *transactionEventRepo) MarkTransactionEventPublished(ctx context.Context, id int32, publishedAt time.Time) error {
+	ctx, span := tracer.Start(ctx, "transactionEventRepo.MarkTransactionEventPublished")
+	defer span.End()
+
+	return r.tx.WithContext(ctx).Model(&model.TransactionEvent{}).
+		Where(&model.TransactionEvent{ID: id}).
+		Where("published_at IS NULL").
+		Updates(map[string]interface{}{"published_at": publishedAt}).Error
+}
+
+func (r *transactionEventRepo) toDomainEvents(events []model.TransactionEvent) ([]*entity.TransactionEvent, error) {
+	domainEvents := make([]*entity.TransactionEvent, 0, len(events))
+	for i := range events {
+		domainEvent, err := mapper.MapStruct[entity.TransactionEvent](r.config, &events[i])
+		if err != nil {
+			return nil, err
+		}
+		domainEvents = append(domainEvents, domainEvent)
+	}
+	return domainEvents, nil
+}
@@ -7,6 +7,7 @@ import (
 	"points/internal/infrastructure/persistence/gorm/model"
 	"points/test"
 	"testing"
+	"time"
 )
 
 func TestCreateTransactionEvent(t *testing.T) {
@@ -32,3 +33,42 @@ func TestCreateTransactionEvent(t *testing.T) {
 		t.Errorf("expected event type 'try', got %v", gotEvent.EventType)
 	}
 }
+
+func TestGetUnpublishedAndMarkTransactionEventPublished(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewTransactionEventRepo(db, config)
+	ctx := context.Background()
+
+	for _, eventType := range []string{"pending", "confirmed"} {
+		event := entity.TransactionEvent{
+			TransactionID: "test-uuid",
+			EventType:     eventType,
+			Payload:       `{"Action":"` + eventType + `"}`,
+		}
+		if err := repoImpl.CreateTransactionEvent(ctx, &event); err != nil {
+			t.Fatalf("CreateTransactionEvent error: %v", err)
+		}
+	}
+
+	unpublished, err := repoImpl.GetUnpublishedTransactionEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetUnpublishedTransactionEvents error: %v", err)
+	}
+	if len(unpublished) != 2 || unpublished[0].EventType != "pending" || unpublished[1].EventType != "confirmed" {
+		t.Fatalf("expected 2 unpublished events in insertion order, got %+v", unpublished)
+	}
+
+	if err := repoImpl.MarkTransactionEventPublished(ctx, unpublished[0].ID, time.Now()); err != nil {
+		t.Fatalf("MarkTransactionEventPublished error: %v", err)
+	}
+
+	remaining, err := repoImpl.GetUnpublishedTransactionEvents(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetUnpublishedTransactionEvents error: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != unpublished[1].ID {
+		t.Errorf("expected only the confirmed event to remain unpublished, got %+v", remaining)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"points/internal/domain/valueobject"
 	"points/internal/infrastructure/persistence/gorm/model"
 	"points/internal/shared/mapper"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -25,7 +27,10 @@ func NewTradeRecordsRepo(tx *gorm.DB, config port.Config) repository.TradeRecord
 }
 
 func (r *tradeRecordsRepo) CreateTradeRecord(ctx context.Context, trans *entity.TradeRecords) error {
-	ormModel, err := mapper.MapStruct[model.TradeRecord](r.config, trans)
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.CreateTradeRecord")
+	defer span.End()
+
+	ormModel, err := r.toOrmModel(trans)
 	if err != nil {
 		return err
 	}
@@ -34,7 +39,10 @@ func (r *tradeRecordsRepo) CreateTradeRecord(ctx context.Context, trans *entity.
 }
 
 func (r *tradeRecordsRepo) CreateOrUpdateTradeRecord(ctx context.Context, trans *entity.TradeRecords) error {
-	ormModel, err := mapper.MapStruct[model.TradeRecord](r.config, trans)
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.CreateOrUpdateTradeRecord")
+	defer span.End()
+
+	ormModel, err := r.toOrmModel(trans)
 	if err != nil {
 		return err
 	}
@@ -45,19 +53,21 @@ func (r *tradeRecordsRepo) CreateOrUpdateTradeRecord(ctx context.Context, trans
 }
 
 func (r *tradeRecordsRepo) UpdateTradeRecord(ctx context.Context, trans *entity.TradeRecords) error {
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.UpdateTradeRecord")
+	defer span.End()
+
 	return r.tx.WithContext(ctx).Model(&model.TradeRecord{}).
-		Where(&model.TradeRecord{
-			TransactionID: trans.TransactionID,
-			FromAccountID: trans.FromAccountID,
-			Nonce:         trans.Nonce,
-		}).
+		Where("transaction_id = ? AND from_account_id = ? AND nonce = ?", trans.TransactionID, trans.FromAccountID, trans.Nonce).
 		Updates(map[string]interface{}{"status": trans.Status}).Error
 }
 
 func (r *tradeRecordsRepo) GetTradeRecord(ctx context.Context, nonce, from int64, status *valueobject.TccStatus) (*entity.TradeRecords, error) {
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.GetTradeRecord")
+	defer span.End()
+
 	var trans model.TradeRecord
 
-	q := r.tx.WithContext(ctx).Where(&model.TradeRecord{FromAccountID: from, Nonce: nonce})
+	q := r.tx.WithContext(ctx).Where("from_account_id = ? AND nonce = ?", from, nonce)
 	if status != nil {
 		q = q.Where("status = ?", *status)
 	}
@@ -66,9 +76,136 @@ func (r *tradeRecordsRepo) GetTradeRecord(ctx context.Context, nonce, from int64
 		return nil, err
 	}
 
-	domainModel, err := mapper.MapStruct[entity.TradeRecords](r.config, &trans)
+	return r.toDomainModel(&trans)
+}
+
+func (r *tradeRecordsRepo) GetExpiredTradeRecords(ctx context.Context, deadline time.Time, limit int) ([]*entity.TradeRecords, error) {
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.GetExpiredTradeRecords")
+	defer span.End()
+
+	var records []model.TradeRecord
+
+	err := r.tx.WithContext(ctx).
+		Where("status = ? AND expired_at IS NOT NULL AND expired_at <= ?", valueobject.TccPending, deadline).
+		Order("expired_at ASC").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainModels(records)
+}
+
+func (r *tradeRecordsRepo) ListTradeRecords(ctx context.Context, filter repository.TradeRecordFilter) ([]*entity.TradeRecords, error) {
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.ListTradeRecords")
+	defer span.End()
+
+	var records []model.TradeRecord
+
+	q := r.tx.WithContext(ctx).
+		Where("(from_account_id = ? OR to_account_id = ?)", filter.AccountID, filter.AccountID)
+	if filter.AssetCode != nil {
+		q = q.Where("asset_code = ?", filter.AssetCode.String())
+	}
+	if filter.Status != nil {
+		q = q.Where("status = ?", *filter.Status)
+	}
+	if filter.StartTime != nil {
+		q = q.Where("created_at >= ?", *filter.StartTime)
+	}
+	if filter.EndTime != nil {
+		q = q.Where("created_at < ?", *filter.EndTime)
+	}
+	if filter.After != nil {
+		q = q.Where("(created_at, from_account_id, nonce) < (?, ?, ?)",
+			filter.After.CreatedAt, filter.After.FromAccountID, filter.After.Nonce)
+	}
+
+	err := q.Order("created_at DESC, from_account_id DESC, nonce DESC").
+		Limit(filter.Limit).
+		Find(&records).Error
 	if err != nil {
 		return nil, err
 	}
+
+	return r.toDomainModels(records)
+}
+
+func (r *tradeRecordsRepo) SumPendingAmountsBySender(ctx context.Context) (map[repository.AccountAsset]valueobject.Money, error) {
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.SumPendingAmountsBySender")
+	defer span.End()
+
+	var rows []struct {
+		FromAccountID int64
+		AssetCode     string
+		Total         decimal.Decimal
+	}
+
+	err := r.tx.WithContext(ctx).Model(&model.TradeRecord{}).
+		Select("from_account_id, asset_code, SUM(amount) AS total").
+		Where("status = ?", valueobject.TccPending).
+		Group("from_account_id, asset_code").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[repository.AccountAsset]valueobject.Money, len(rows))
+	for _, row := range rows {
+		asset := valueobject.AssetCode(row.AssetCode)
+		totals[repository.AccountAsset{AccountID: row.FromAccountID, AssetCode: asset}] = valueobject.NewMoney(row.Total, asset)
+	}
+	return totals, nil
+}
+
+func (r *tradeRecordsRepo) GetConfirmedTradeRecordsWithoutEvent(ctx context.Context) ([]*entity.TradeRecords, error) {
+	ctx, span := tracer.Start(ctx, "tradeRecordsRepo.GetConfirmedTradeRecordsWithoutEvent")
+	defer span.End()
+
+	var records []model.TradeRecord
+
+	err := r.tx.WithContext(ctx).
+		Where("status = ? AND trade_type = ?", valueobject.TccConfirmed, valueobject.TradeTypeTransfer).
+		Where("NOT EXISTS (SELECT 1 FROM transaction_event e WHERE e.transaction_id = trade_records.transaction_id AND e.event_type = ?)",
+			valueobject.TccConfirmed.String()).
+		Order("created_at ASC").
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomainModels(records)
+}
+
+func (r *tradeRecordsRepo) toDomainModels(records []model.TradeRecord) ([]*entity.TradeRecords, error) {
+	domainModels := make([]*entity.TradeRecords, 0, len(records))
+	for i := range records {
+		domainModel, err := r.toDomainModel(&records[i])
+		if err != nil {
+			return nil, err
+		}
+		domainModels = append(domainModels, domainModel)
+	}
+	return domainModels, nil
+}
+
+func (r *tradeRecordsRepo) toDomainModel(record *model.TradeRecord) (*entity.TradeRecords, error) {
+	domainModel, err := mapper.MapStruct[entity.TradeRecords](r.config, record)
+	if err != nil {
+		return nil, err
+	}
+
+	domainModel.Amount = domainModel.Amount.WithAsset(valueobject.AssetCode(record.AssetCode))
 	return domainModel, nil
 }
+
+func (r *tradeRecordsRepo) toOrmModel(trans *entity.TradeRecords) (*model.TradeRecord, error) {
+	ormModel, err := mapper.MapStruct[model.TradeRecord](r.config, trans)
+	if err != nil {
+		return nil, err
+	}
+
+	ormModel.AssetCode = trans.Amount.Asset().String()
+	return ormModel, nil
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"points/internal/domain/entity"
+	"points/internal/domain/repository"
 	"points/internal/domain/valueobject"
 	"points/internal/infrastructure"
 	"points/internal/infrastructure/persistence/gorm/model"
 	"points/test"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -154,3 +156,103 @@ func TestGetTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExpiredTransactions(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewTradeRecordsRepo(db, config)
+	ctx := context.Background()
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	records := []model.TradeRecord{
+		{Nonce: 1, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccPending), ExpiredAt: &past},
+		{Nonce: 2, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccPending), ExpiredAt: &future},
+		{Nonce: 3, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccConfirmed), ExpiredAt: &past},
+		{Nonce: 4, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccPending)},
+	}
+	if err := db.Create(&records).Error; err != nil {
+		t.Fatalf("failed to create test transactions: %v", err)
+	}
+
+	got, err := repoImpl.GetExpiredTradeRecords(ctx, now, 10)
+	assert.NoError(t, err, "GetExpiredTradeRecords returned error")
+	assert.Len(t, got, 1, "only pending transactions past their deadline should be returned")
+	assert.Equal(t, int64(1), got[0].Nonce)
+}
+
+func TestListTransactions(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewTradeRecordsRepo(db, config)
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	records := []model.TradeRecord{
+		{Nonce: 1, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccConfirmed), CreatedAt: base.Add(1 * time.Minute)},
+		{Nonce: 1, FromAccountID: 2, ToAccountID: 1, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccConfirmed), CreatedAt: base.Add(2 * time.Minute)},
+		{Nonce: 2, FromAccountID: 1, ToAccountID: 3, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccPending), CreatedAt: base.Add(3 * time.Minute)},
+		{Nonce: 1, FromAccountID: 3, ToAccountID: 2, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccConfirmed), CreatedAt: base.Add(4 * time.Minute)},
+	}
+	if err := db.Create(&records).Error; err != nil {
+		t.Fatalf("failed to create test transactions: %v", err)
+	}
+
+	all, err := repoImpl.ListTradeRecords(ctx, repository.TradeRecordFilter{AccountID: 1, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, all, 3, "incoming and outgoing transactions of account 1 should be listed")
+	assert.Equal(t, int64(2), all[0].Nonce, "newest transaction should come first")
+
+	confirmed := valueobject.TccConfirmed
+	filtered, err := repoImpl.ListTradeRecords(ctx, repository.TradeRecordFilter{AccountID: 1, Status: &confirmed, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, filtered, 2)
+
+	page, err := repoImpl.ListTradeRecords(ctx, repository.TradeRecordFilter{
+		AccountID: 1,
+		After: &repository.TradeRecordCursor{
+			CreatedAt:     all[0].CreatedAt,
+			FromAccountID: all[0].FromAccountID,
+			Nonce:         all[0].Nonce,
+		},
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, page, 2, "cursor should skip already returned transactions")
+}
+
+func TestReconciliationQueries(t *testing.T) {
+	db := test.NewTestContainerDB(t)
+	copier := infrastructure.NewCopierImpl()
+	config := infrastructure.NewConfigImpl(nil, nil, copier)
+	repoImpl := NewTradeRecordsRepo(db, config)
+	ctx := context.Background()
+
+	records := []model.TradeRecord{
+		{Nonce: 1, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(10), Status: int32(valueobject.TccPending)},
+		{Nonce: 2, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(15), Status: int32(valueobject.TccPending)},
+		{Nonce: 3, FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(20), Status: int32(valueobject.TccConfirmed)},
+		{Nonce: 1, FromAccountID: 2, ToAccountID: 1, Amount: decimal.NewFromInt(5), Status: int32(valueobject.TccConfirmed)},
+	}
+	if err := db.Create(&records).Error; err != nil {
+		t.Fatalf("failed to create test transactions: %v", err)
+	}
+	event := model.TransactionEvent{TransactionID: records[2].TransactionID, EventType: valueobject.TccConfirmed.String(), Payload: "{}"}
+	if err := db.Create(&event).Error; err != nil {
+		t.Fatalf("failed to create test event: %v", err)
+	}
+
+	pending, err := repoImpl.SumPendingAmountsBySender(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	key := repository.AccountAsset{AccountID: 1, AssetCode: valueobject.DefaultAssetCode}
+	assert.True(t, pending[key].Equals(valueobject.NewMoneyFromDecimal(decimal.NewFromInt(25))))
+
+	missing, err := repoImpl.GetConfirmedTradeRecordsWithoutEvent(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, missing, 1, "only the confirmed transfer without an event should be reported")
+	assert.Equal(t, int64(2), missing[0].FromAccountID)
+}
@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type tracedAccount struct {
+	UserID int64 `gorm:"primaryKey"`
+}
+
+func TestGormPlugin_RecordsStatementsUnderCallerSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&tracedAccount{}))
+	assert.NoError(t, db.Use(GormPlugin{TracerProvider: provider}))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "accountRepo.GetAccount")
+	var account tracedAccount
+	err = db.WithContext(ctx).Where("user_id = ?", 404).First(&account).Error
+	parent.End()
+
+	assert.Error(t, err)
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 2) {
+		return
+	}
+	statement := spans[0]
+	assert.Equal(t, "gorm.query", statement.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), statement.Parent().SpanID())
+	assert.Equal(t, "Unset", statement.Status().Code.String(), "record not found is not a span error")
+	assert.Contains(t, attributeValue(statement, "db.statement"), "traced_accounts")
+}
+
+func attributeValue(span sdktrace.ReadOnlySpan, key string) string {
+	for _, attr := range span.Attributes() {
+		if string(attr.Key) == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
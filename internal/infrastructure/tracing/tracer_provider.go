@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"points/internal/domain/port"
+
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+type TracingConfig struct {
+	Exporter     string  `mapstructure:"TRACING_EXPORTER" validate:"omitempty,oneof=none stdout otlp"`
+	ServiceName  string  `mapstructure:"TRACING_SERVICE_NAME"`
+	SampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO" validate:"min=0,max=1"`
+	OTLPEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+}
+
+// NewTracerProvider installs the global tracer provider and the W3C trace
+// context propagator. The "none" exporter keeps propagating trace context but
+// records nothing.
+func NewTracerProvider(config port.Config) (*sdktrace.TracerProvider, error) {
+	cfg := TracingConfig{Exporter: "none", ServiceName: "points", SampleRatio: 1}
+	if v := config.Sub("tracing"); v != nil {
+		if err := v.Unmarshal(&cfg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tracing config: %w", err)
+		}
+	}
+	if err := validator.New().Struct(cfg); err != nil {
+		return nil, fmt.Errorf("failed to validate tracing config: %w", err)
+	}
+	return newTracerProvider(cfg)
+}
+
+func newTracerProvider(cfg TracingConfig) (*sdktrace.TracerProvider, error) {
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	switch cfg.Exporter {
+	case "", "none":
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout trace exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithSyncer(exporter))
+	case "otlp":
+		clientOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			clientOptions = append(clientOptions, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(context.Background(), clientOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %s", cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider, nil
+}
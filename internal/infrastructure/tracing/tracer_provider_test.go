@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTracerProvider(t *testing.T) {
+	tests := []struct {
+		name        string
+		exporter    string
+		expectError bool
+	}{
+		{name: "none", exporter: "none"},
+		{name: "empty falls back to none", exporter: ""},
+		{name: "stdout", exporter: "stdout"},
+		{name: "unsupported", exporter: "zipkin", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := newTracerProvider(TracingConfig{Exporter: tt.exporter, ServiceName: "points", SampleRatio: 1})
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, provider)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, provider.Shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"points/internal/domain"
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+	"points/internal/shared/telemetry"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("points/internal/infrastructure/tracing")
+
+type tradeUsecaseTracing struct {
+	next domain.TradeUsecase
+}
+
+// InstrumentTradeUsecase opens a span around every trade call so the lock,
+// unit of work and SQL spans below it share one parent.
+func InstrumentTradeUsecase(next domain.TradeUsecase) domain.TradeUsecase {
+	return &tradeUsecaseTracing{next: next}
+}
+
+func (t *tradeUsecaseTracing) Transfer(ctx context.Context, req *command.TransferCommand) (*entity.TradeRecords, error) {
+	ctx, span := startTradeSpan(ctx, "tradeUsecase.Transfer", req.BaseCommand)
+	trans, err := t.next.Transfer(ctx, req)
+	telemetry.EndSpan(span, err)
+	return trans, err
+}
+
+func (t *tradeUsecaseTracing) ManualConfirm(ctx context.Context, req *command.ConfirmCommand) (*entity.TradeRecords, error) {
+	ctx, span := startTradeSpan(ctx, "tradeUsecase.ManualConfirm", req.BaseCommand)
+	trans, err := t.next.ManualConfirm(ctx, req)
+	telemetry.EndSpan(span, err)
+	return trans, err
+}
+
+func (t *tradeUsecaseTracing) Cancel(ctx context.Context, req *command.CancelCommand) (*entity.TradeRecords, error) {
+	ctx, span := startTradeSpan(ctx, "tradeUsecase.Cancel", req.BaseCommand)
+	trans, err := t.next.Cancel(ctx, req)
+	telemetry.EndSpan(span, err)
+	return trans, err
+}
+
+func startTradeSpan(ctx context.Context, name string, base command.BaseCommand) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(
+		attribute.Int64("trade.from", base.From),
+		attribute.Int64("trade.to", base.To),
+		attribute.Int64("trade.nonce", base.Nonce),
+	))
+}
@@ -1,50 +1,169 @@
 package infrastructure
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"points/internal/domain/port"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
 type ViperImpl struct {
-	viper *viper.Viper
+	viper  *viper.Viper
+	reload *reloadState
+}
+
+// reloadState is shared by the root settings only; sections returned by Sub
+// are snapshots and never reload.
+type reloadState struct {
+	mu          sync.RWMutex
+	environment string
+	defaults    map[string]interface{}
+	subscribers []func(port.ConfigChange)
 }
 
 var _ port.SettingsManager = (*ViperImpl)(nil)
 
+const envPrefix = "POINTS"
+
+// structuralKeys are only read at startup, so changing them needs a restart.
+var structuralKeys = []string{
+	"postgres.",
+	"redis.",
+	"server.server_host",
+	"server.server_port",
+	"grpc.grpc_host",
+	"grpc.grpc_port",
+	"lock.lock_backend",
+}
+
+// NewViperImpl reads <environment>.yaml from the config search path. Every key
+// in the file can be overridden by an environment variable named after its
+// path, e.g. POINTS_POSTGRES_POSTGRES_PASSWORD, and secrets can be read from
+// files named by a KEY_FILE setting or a POINTS_..._FILE variable.
 func NewViperImpl(environment string) (port.SettingsManager, error) {
+	v, err := loadViper(environment)
+	if err != nil {
+		return nil, err
+	}
+	return &ViperImpl{
+		viper: v,
+		reload: &reloadState{
+			environment: environment,
+			defaults:    make(map[string]interface{}),
+		},
+	}, nil
+}
+
+func loadViper(environment string) (*viper.Viper, error) {
 	v := viper.New()
 	v.SetConfigName(environment)
 	v.SetConfigType("yaml")
-	v.AddConfigPath("./configs")
+	for _, path := range configPaths() {
+		v.AddConfigPath(path)
+	}
+	v.SetEnvPrefix(envPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
 
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("error reading config file: %w", err)
 	}
-	return &ViperImpl{
-		viper: v,
-	}, nil
+	if err := loadSecretFiles(v); err != nil {
+		return nil, fmt.Errorf("error reading secret files: %w", err)
+	}
+	return v, nil
+}
+
+// configPaths splits POINTS_CONFIG_PATH like PATH and defaults to ./configs.
+func configPaths() []string {
+	if paths := os.Getenv(envPrefix + "_CONFIG_PATH"); paths != "" {
+		return filepath.SplitList(paths)
+	}
+	return []string{"./configs"}
+}
+
+// loadSecretFiles merges the content of every referenced secret file into the
+// key it names. Environment overrides of the key itself still win.
+func loadSecretFiles(v *viper.Viper) error {
+	secrets := make(map[string]interface{})
+	var errs []error
+	for _, key := range v.AllKeys() {
+		target, path := key, os.Getenv(envKey(key)+"_FILE")
+		if strings.HasSuffix(key, "_file") {
+			target, path = strings.TrimSuffix(key, "_file"), v.GetString(key)
+		}
+		if path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target, err))
+			continue
+		}
+		setNested(secrets, strings.Split(target, "."), strings.TrimRight(string(content), "\r\n"))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return v.MergeConfigMap(secrets)
+}
+
+func envKey(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func setNested(settings map[string]interface{}, path []string, value interface{}) {
+	for _, segment := range path[:len(path)-1] {
+		next, ok := settings[segment].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			settings[segment] = next
+		}
+		settings = next
+	}
+	settings[path[len(path)-1]] = value
 }
 
 func (v *ViperImpl) SetDefault(key string, value interface{}) {
+	if v.reload == nil {
+		v.viper.SetDefault(key, value)
+		return
+	}
+	v.reload.mu.Lock()
+	defer v.reload.mu.Unlock()
+	v.reload.defaults[key] = value
 	v.viper.SetDefault(key, value)
 }
 
 func (v *ViperImpl) SetDefaultInt(key string, value int) {
-	v.viper.SetDefault(key, value)
+	v.SetDefault(key, value)
 }
 
 func (v *ViperImpl) GetInt(key string) int {
-	return v.viper.GetInt(key)
+	current, release := v.current()
+	defer release()
+	return current.GetInt(key)
 }
 
 func (v *ViperImpl) GetString(key string) string {
-	return v.viper.GetString(key)
+	current, release := v.current()
+	defer release()
+	return current.GetString(key)
 }
 
 func (v *ViperImpl) Sub(key string) port.SettingsManager {
-	sub := v.viper.Sub(key)
+	current, release := v.current()
+	defer release()
+	sub := current.Sub(key)
 	if sub == nil {
 		return nil
 	}
@@ -54,5 +173,99 @@ func (v *ViperImpl) Sub(key string) port.SettingsManager {
 }
 
 func (v *ViperImpl) Unmarshal(out interface{}) error {
-	return v.viper.Unmarshal(out)
+	current, release := v.current()
+	defer release()
+	return current.Unmarshal(out)
+}
+
+// Subscribe registers fn to run after every reload that changed a key or
+// failed. Sections returned by Sub ignore subscriptions.
+func (v *ViperImpl) Subscribe(fn func(port.ConfigChange)) {
+	if v.reload == nil {
+		return
+	}
+	v.reload.mu.Lock()
+	defer v.reload.mu.Unlock()
+	v.reload.subscribers = append(v.reload.subscribers, fn)
+}
+
+// WatchConfig reloads the settings whenever the config file changes.
+func (v *ViperImpl) WatchConfig() {
+	if v.reload == nil {
+		return
+	}
+	current, release := v.current()
+	watcher := viper.New()
+	watcher.SetConfigFile(current.ConfigFileUsed())
+	release()
+
+	watcher.OnConfigChange(func(fsnotify.Event) {
+		v.Reload()
+	})
+	watcher.WatchConfig()
+}
+
+// Reload re-reads the config file, environment and secret files, swaps them
+// in and notifies subscribers of the changed keys. A failed reload keeps the
+// previous settings and is reported through ConfigChange.Err.
+func (v *ViperImpl) Reload() {
+	if v.reload == nil {
+		return
+	}
+	next, err := loadViper(v.reload.environment)
+
+	v.reload.mu.Lock()
+	var change port.ConfigChange
+	if err != nil {
+		change.Err = err
+	} else {
+		for key, value := range v.reload.defaults {
+			next.SetDefault(key, value)
+		}
+		change = diffSettings(v.viper, next)
+		v.viper = next
+	}
+	subscribers := slices.Clone(v.reload.subscribers)
+	v.reload.mu.Unlock()
+
+	if change.Err == nil && len(change.Keys) == 0 {
+		return
+	}
+	for _, fn := range subscribers {
+		fn(change)
+	}
+}
+
+func (v *ViperImpl) current() (*viper.Viper, func()) {
+	if v.reload == nil {
+		return v.viper, func() {}
+	}
+	v.reload.mu.RLock()
+	return v.viper, v.reload.mu.RUnlock
+}
+
+func diffSettings(previous, next *viper.Viper) port.ConfigChange {
+	keys := append(previous.AllKeys(), next.AllKeys()...)
+	slices.Sort(keys)
+
+	var change port.ConfigChange
+	for _, key := range slices.Compact(keys) {
+		if reflect.DeepEqual(previous.Get(key), next.Get(key)) {
+			continue
+		}
+		change.Keys = append(change.Keys, key)
+		if isStructural(key) {
+			change.RestartRequired = append(change.RestartRequired, key)
+		}
+	}
+	return change
+}
+
+func isStructural(key string) bool {
+	for _, structural := range structuralKeys {
+		if strings.HasPrefix(key, structural) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"points/internal/domain/port"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testConfig = `
+postgres:
+  POSTGRES_USER: points
+  POSTGRES_PASSWORD: from-yaml
+  POSTGRES_HOST: localhost
+redis:
+  REDIS_HOST: localhost
+  REDIS_PASSWORD: ""
+  REDIS_PASSWORD_FILE: %s
+`
+
+func writeTestConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(content), 0o600))
+	return dir
+}
+
+func writeSecret(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNewViperImpl_Overrides(t *testing.T) {
+	redisSecret := writeSecret(t, "redis", "from-yaml-file\n")
+	hostSecret := writeSecret(t, "host", "from-env-file")
+	t.Setenv("POINTS_CONFIG_PATH", writeTestConfig(t, fmt.Sprintf(testConfig, redisSecret)))
+	t.Setenv("POINTS_POSTGRES_POSTGRES_PASSWORD", "from-env")
+	t.Setenv("POINTS_POSTGRES_POSTGRES_HOST_FILE", hostSecret)
+
+	settings, err := NewViperImpl("test")
+	assert.NoError(t, err)
+
+	var postgres struct {
+		User     string `mapstructure:"POSTGRES_USER"`
+		Password string `mapstructure:"POSTGRES_PASSWORD"`
+		Host     string `mapstructure:"POSTGRES_HOST"`
+	}
+	assert.NoError(t, settings.Sub("postgres").Unmarshal(&postgres))
+	assert.Equal(t, "points", postgres.User)
+	assert.Equal(t, "from-env", postgres.Password, "env vars override the file")
+	assert.Equal(t, "from-env-file", postgres.Host, "POINTS_..._FILE reads the secret file")
+	assert.Equal(t, "from-env", settings.GetString("postgres.POSTGRES_PASSWORD"))
+	assert.Equal(t, "from-yaml-file", settings.Sub("redis").GetString("REDIS_PASSWORD"), "KEY_FILE in the file reads the secret file")
+}
+
+func TestNewViperImpl_MissingSecretFile(t *testing.T) {
+	t.Setenv("POINTS_CONFIG_PATH", writeTestConfig(t, fmt.Sprintf(testConfig, "/does/not/exist")))
+
+	_, err := NewViperImpl("test")
+	assert.ErrorContains(t, err, "redis.redis_password")
+}
+
+func TestViperImpl_Reload(t *testing.T) {
+	dir := writeTestConfig(t, "lock:\n  LOCK_TTL: 5\npostgres:\n  POSTGRES_HOST: localhost\n")
+	t.Setenv("POINTS_CONFIG_PATH", dir)
+	settings, err := NewViperImpl("test")
+	assert.NoError(t, err)
+	settings.SetDefault("lock.LOCK_RETRY_INTERVAL", 100)
+
+	var changes []port.ConfigChange
+	settings.Subscribe(func(change port.ConfigChange) {
+		changes = append(changes, change)
+	})
+	reloader := settings.(*ViperImpl)
+
+	reloader.Reload()
+	assert.Empty(t, changes, "an unchanged file should not notify subscribers")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test.yaml"), []byte("lock:\n  LOCK_TTL: 10\npostgres:\n  POSTGRES_HOST: db\n"), 0o600))
+	reloader.Reload()
+	if assert.Len(t, changes, 1) {
+		assert.NoError(t, changes[0].Err)
+		assert.Equal(t, []string{"lock.lock_ttl", "postgres.postgres_host"}, changes[0].Keys)
+		assert.Equal(t, []string{"postgres.postgres_host"}, changes[0].RestartRequired)
+	}
+	assert.Equal(t, 10, settings.GetInt("lock.LOCK_TTL"))
+	assert.Equal(t, 100, settings.GetInt("lock.LOCK_RETRY_INTERVAL"), "defaults should survive a reload")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "test.yaml"), []byte("lock:\n  LOCK_TTL: [\n"), 0o600))
+	reloader.Reload()
+	if assert.Len(t, changes, 2) {
+		assert.Error(t, changes[1].Err)
+	}
+	assert.Equal(t, 10, settings.GetInt("lock.LOCK_TTL"), "a broken file should keep the previous settings")
+}
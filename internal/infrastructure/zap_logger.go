@@ -6,6 +6,32 @@ import (
 )
 
 func NewZapLogger(env string) (*zap.Logger, error) {
+	return NewZapLoggerWithLevel(env, newZapConfig(env).Level)
+}
+
+// NewZapLoggerWithLevel builds the logger around level so it can be changed
+// while the app runs.
+func NewZapLoggerWithLevel(env string, level zap.AtomicLevel) (*zap.Logger, error) {
+	cfg := newZapConfig(env)
+	cfg.Level = level
+
+	logger, err := cfg.Build()
+	if err != nil {
+		return nil, err
+	}
+	return logger, nil
+}
+
+// NewLogLevel parses level, falling back to the environment's default level
+// when it is empty.
+func NewLogLevel(env, level string) (zap.AtomicLevel, error) {
+	if level == "" {
+		return newZapConfig(env).Level, nil
+	}
+	return zap.ParseAtomicLevel(level)
+}
+
+func newZapConfig(env string) zap.Config {
 	var cfg zap.Config
 	if env == "production" {
 		cfg = zap.NewProductionConfig()
@@ -13,10 +39,5 @@ func NewZapLogger(env string) (*zap.Logger, error) {
 		cfg = zap.NewDevelopmentConfig()
 	}
 	cfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-
-	logger, err := cfg.Build()
-	if err != nil {
-		return nil, err
-	}
-	return logger, nil
+	return cfg
 }
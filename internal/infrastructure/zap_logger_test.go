@@ -80,3 +80,19 @@ func testableNewZapLogger(env string) (*zap.Logger, error) {
 
 	return buildFn(cfg)
 }
+
+func TestNewZapLoggerWithLevel_ChangesAtRuntime(t *testing.T) {
+	level, err := NewLogLevel("production", "")
+	assert.NoError(t, err)
+	assert.Equal(t, zap.InfoLevel, level.Level(), "empty level should use the environment default")
+
+	logger, err := NewZapLoggerWithLevel("production", level)
+	assert.NoError(t, err)
+	assert.False(t, logger.Core().Enabled(zap.DebugLevel))
+
+	level.SetLevel(zap.DebugLevel)
+	assert.True(t, logger.Core().Enabled(zap.DebugLevel))
+
+	_, err = NewLogLevel("production", "loud")
+	assert.Error(t, err)
+}
@@ -13,6 +13,8 @@ const (
 	ErrNotFound       ErrorCode = 1002
 	ErrUnauthorized   ErrorCode = 1003
 	ErrConflict       ErrorCode = 1004
+	ErrForbidden      ErrorCode = 1005
+	ErrUnavailable    ErrorCode = 1006
 
 	ErrGetAccount          ErrorCode = 2001
 	ErrCreateAccount       ErrorCode = 2002
@@ -25,11 +27,24 @@ const (
 	ErrUpdateTransaction   ErrorCode = 2009
 	ErrPayloadMarshal      ErrorCode = 2010
 	ErrCreateEvent         ErrorCode = 2011
+	ErrTransactionExpired  ErrorCode = 2012
+	ErrGetEvent            ErrorCode = 2013
+	ErrPublishEvent        ErrorCode = 2014
+	ErrUpdateEvent         ErrorCode = 2015
+	ErrTransactionNotFound ErrorCode = 2016
+	ErrCreateLedgerEntry   ErrorCode = 2017
+	ErrGetLedgerEntry      ErrorCode = 2018
+	ErrCreditBalance       ErrorCode = 2019
+	ErrDebitBalance        ErrorCode = 2020
+	ErrAssetMismatch       ErrorCode = 2021
+	ErrGetPointLot         ErrorCode = 2022
+	ErrUpdatePointLot      ErrorCode = 2023
 
 	ErrDistrubutedLockNotObtained ErrorCode = 3001
 	ErrDistrubutedLockAcquire     ErrorCode = 3002
 	ErrDistrubutedLockRelease     ErrorCode = 3003
 	ErrDistrubutedLockRenew       ErrorCode = 3004
+	ErrStaleFencingToken          ErrorCode = 3005
 )
 
 func (e ErrorCode) String() string {
@@ -50,6 +65,10 @@ func (e ErrorCode) GetMessage() string {
 		return "unauthorized"
 	case ErrConflict:
 		return "conflict"
+	case ErrForbidden:
+		return "forbidden"
+	case ErrUnavailable:
+		return "service unavailable"
 	case ErrGetAccount:
 		return "get account failed"
 	case ErrCreateAccount:
@@ -72,6 +91,30 @@ func (e ErrorCode) GetMessage() string {
 		return "payload marshal failed"
 	case ErrCreateEvent:
 		return "create event failed"
+	case ErrTransactionExpired:
+		return "transaction expired"
+	case ErrGetEvent:
+		return "get event failed"
+	case ErrPublishEvent:
+		return "publish event failed"
+	case ErrUpdateEvent:
+		return "update event failed"
+	case ErrTransactionNotFound:
+		return "transaction not found"
+	case ErrCreateLedgerEntry:
+		return "create ledger entry failed"
+	case ErrGetLedgerEntry:
+		return "get ledger entry failed"
+	case ErrCreditBalance:
+		return "credit balance failed"
+	case ErrDebitBalance:
+		return "debit balance failed"
+	case ErrAssetMismatch:
+		return "asset mismatch"
+	case ErrGetPointLot:
+		return "get point lot failed"
+	case ErrUpdatePointLot:
+		return "update point lot failed"
 	case ErrDistrubutedLockNotObtained:
 		return "distributed lock not obtained"
 	case ErrDistrubutedLockAcquire:
@@ -80,6 +123,8 @@ func (e ErrorCode) GetMessage() string {
 		return "distributed lock release failed"
 	case ErrDistrubutedLockRenew:
 		return "distributed lock renew failed"
+	case ErrStaleFencingToken:
+		return "lock lost to a newer holder, retry"
 	default:
 		return "unknown error"
 	}
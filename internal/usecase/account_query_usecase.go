@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"points/internal/domain"
+	"points/internal/domain/entity"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+
+	"gorm.io/gorm"
+)
+
+type accountQueryUsecase struct {
+	unitOfWork repository.UnitOfWork
+}
+
+func NewAccountQueryUsecase(unitOfWork repository.UnitOfWork) domain.AccountQueryUsecase {
+	return &accountQueryUsecase{
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (s *accountQueryUsecase) GetAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error) {
+	if err := authorizeCaller(ctx, userID, valueobject.PermissionAccountReadAny); err != nil {
+		return nil, err
+	}
+
+	account, err := s.unitOfWork.AccountRepository().GetAccount(ctx, userID, asset)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && account == nil) {
+		return nil, apperror.Wrap(errcode.ErrAccountNotFound, "get account", err)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetAccount, "get account", err)
+	}
+
+	return account, nil
+}
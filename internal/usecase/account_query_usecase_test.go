@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"points/internal/domain/entity"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAccountQueryUsecase_GetAccount(t *testing.T) {
+	ctx := valueobject.WithPrincipal(context.Background(), &valueobject.Principal{Subject: "1", Kind: valueobject.PrincipalKindUser, AccountID: 1})
+
+	tests := []struct {
+		name         string
+		repoAccount  *entity.Account
+		repoErr      error
+		expectedCode *errcode.ErrorCode
+	}{
+		{
+			name:        "success",
+			repoAccount: dummyAccount(1, decimal.NewFromInt(80), decimal.NewFromInt(20)),
+		},
+		{
+			name:         "not found",
+			repoErr:      gorm.ErrRecordNotFound,
+			expectedCode: errCodePtr(errcode.ErrAccountNotFound),
+		},
+		{
+			name:         "repository error",
+			repoErr:      errors.New("db error"),
+			expectedCode: errCodePtr(errcode.ErrGetAccount),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUow := mock.NewMockUnitOfWork(ctrl)
+			mockAccRepo := mock.NewMockAccountRepository(ctrl)
+			mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
+			mockAccRepo.EXPECT().GetAccount(ctx, int64(1), valueobject.DefaultAssetCode).Return(tt.repoAccount, tt.repoErr).Times(1)
+
+			account, err := NewAccountQueryUsecase(mockUow).GetAccount(ctx, 1, valueobject.DefaultAssetCode)
+			if tt.expectedCode == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.repoAccount, account)
+				assert.True(t, account.TotalBalance().Value().Equal(decimal.NewFromInt(100)))
+				return
+			}
+
+			var appErr *apperror.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, *tt.expectedCode, appErr.Code)
+			assert.Nil(t, account)
+		})
+	}
+}
+
+func TestAccountQueryUsecase_GetAccountOfOtherUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	other := &valueobject.Principal{Subject: "2", Kind: valueobject.PrincipalKindUser, AccountID: 2}
+
+	_, err := NewAccountQueryUsecase(mockUow).GetAccount(valueobject.WithPrincipal(context.Background(), other), 1, valueobject.DefaultAssetCode)
+	assertErrorCode(t, err, errcode.ErrForbidden)
+
+	_, err = NewAccountQueryUsecase(mockUow).GetAccount(context.Background(), 1, valueobject.DefaultAssetCode)
+	assertErrorCode(t, err, errcode.ErrUnauthorized)
+
+	mockAccRepo := mock.NewMockAccountRepository(ctrl)
+	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).Times(1)
+	mockAccRepo.EXPECT().GetAccount(gomock.Any(), int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+	admin := other.WithPermissions([]valueobject.Permission{valueobject.PermissionAccountReadAny})
+	_, err = NewAccountQueryUsecase(mockUow).GetAccount(valueobject.WithPrincipal(context.Background(), admin), 1, valueobject.DefaultAssetCode)
+	assert.NoError(t, err, "account:read_any should allow reading other accounts")
+}
+
+func errCodePtr(code errcode.ErrorCode) *errcode.ErrorCode {
+	return &code
+}
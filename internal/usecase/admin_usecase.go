@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"points/internal/domain"
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/usecase/locking"
+	"points/internal/usecase/transaction"
+	"time"
+)
+
+type adminUsecase struct {
+	unitOfWork         repository.UnitOfWork
+	lockService        locking.AccountLockApplicationService
+	transactionService transaction.TransactionApplicationService
+	lotLifetimeMonths  int
+	now                func() time.Time
+}
+
+func NewAdminUsecase(unitOfWork repository.UnitOfWork, locker domain.Locker, config port.Config) domain.AdminUsecase {
+	return &adminUsecase{
+		unitOfWork:         unitOfWork,
+		lockService:        locking.NewAccountLockService(locker, config),
+		transactionService: transaction.NewTransactionApplicationService(),
+		lotLifetimeMonths:  initLotLifetimeMonths(config),
+		now:                valueobject.Now,
+	}
+}
+
+func (s *adminUsecase) Mint(ctx context.Context, req *command.MintCommand) (*entity.TradeRecords, error) {
+	amount, err := adjustmentAmount(&req.AdjustmentCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the user account is locked: adjustments just append to the system
+	// account's ledger, so locking it would serialize every mint and burn.
+	var trans *entity.TradeRecords
+	err = s.lockService.WithAccountsLock(ctx, []int64{req.AccountID}, func(ctx context.Context) error {
+		return s.unitOfWork.Transaction(ctx, func(u repository.UnitOfWork) error {
+			var err error
+			trans, err = s.transactionService.MintTransaction(ctx, u, req.Nonce, req.AccountID, amount, req.ReasonCode, req.ExternalReference, s.lotExpiry())
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trans, nil
+}
+
+func (s *adminUsecase) Burn(ctx context.Context, req *command.BurnCommand) (*entity.TradeRecords, error) {
+	amount, err := adjustmentAmount(&req.AdjustmentCommand)
+	if err != nil {
+		return nil, err
+	}
+
+	var trans *entity.TradeRecords
+	err = s.lockService.WithAccountsLock(ctx, []int64{req.AccountID}, func(ctx context.Context) error {
+		return s.unitOfWork.Transaction(ctx, func(u repository.UnitOfWork) error {
+			var err error
+			trans, err = s.transactionService.BurnTransaction(ctx, u, req.Nonce, req.AccountID, amount, req.ReasonCode, req.ExternalReference)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trans, nil
+}
+
+// lotExpiry is when points minted now expire; nil keeps them forever.
+func (s *adminUsecase) lotExpiry() *time.Time {
+	if s.lotLifetimeMonths <= 0 {
+		return nil
+	}
+	expiresAt := s.now().AddDate(0, s.lotLifetimeMonths, 0)
+	return &expiresAt
+}
+
+func initLotLifetimeMonths(config port.Config) int {
+	config.SetDefaultInt("expiry.POINT_EXPIRY_MONTHS", 0)
+
+	return config.GetInt("expiry.POINT_EXPIRY_MONTHS")
+}
+
+func adjustmentAmount(req *command.AdjustmentCommand) (valueobject.Money, error) {
+	asset, err := valueobject.NewAssetCode(req.AssetCode.String())
+	if err != nil {
+		return valueobject.Money{}, err
+	}
+	return req.Amount.WithAsset(asset), nil
+}
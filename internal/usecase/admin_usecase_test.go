@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+
+	"points/internal/domain/command"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/test/mock"
+)
+
+func TestAdminMintAndBurn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockAccRepo := mock.NewMockAccountRepository(ctrl)
+	mockTxRepo := mock.NewMockTradeRecordsRepository(ctrl)
+	mockEventRepo := mock.NewMockTransactionEventRepository(ctrl)
+	mockLedgerRepo := mock.NewMockLedgerRepository(ctrl)
+	mockLocker := mock.NewMockLocker(ctrl)
+	mockLock := mock.NewMockLock(ctrl)
+	mockConfig := mock.NewMockConfig(ctrl)
+
+	mockUow.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(uow repository.UnitOfWork) error) error {
+			return fn(mockUow)
+		}).AnyTimes()
+	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
+	mockUow.EXPECT().TradeRecordsRepository().Return(mockTxRepo).AnyTimes()
+	mockUow.EXPECT().TransactionEventRepository().Return(mockEventRepo).AnyTimes()
+	mockUow.EXPECT().LedgerRepository().Return(mockLedgerRepo).AnyTimes()
+	stubPointLots(ctrl, mockUow)
+	mockLedgerRepo.EXPECT().CreateLedgerEntries(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockEventRepo.EXPECT().CreateTransactionEvent(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	mockConfig.EXPECT().SetDefaultInt("lock.LOCK_TTL", 5).Return().Times(1)
+	mockConfig.EXPECT().SetDefaultInt("lock.LOCK_RETRY_INTERVAL", 100).Return().Times(1)
+	mockConfig.EXPECT().GetInt("lock.LOCK_TTL").Return(5).Times(1)
+	mockConfig.EXPECT().GetInt("lock.LOCK_RETRY_INTERVAL").Return(100).Times(1)
+	mockConfig.EXPECT().Subscribe(gomock.Any()).Times(1)
+	mockConfig.EXPECT().SetDefaultInt("expiry.POINT_EXPIRY_MONTHS", 0).Return().Times(1)
+	mockConfig.EXPECT().GetInt("expiry.POINT_EXPIRY_MONTHS").Return(0).Times(1)
+
+	svc := NewAdminUsecase(mockUow, mockLocker, mockConfig)
+
+	mockLocker.EXPECT().AcquireAll(ctx, []string{"account_lock:7"}, gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(2)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).Times(2)
+
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, int64(1), valueobject.SystemAccountID, nil).Return(nil, nil).Times(1)
+	mockAccRepo.EXPECT().GetAccount(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode).Return(dummyAccount(valueobject.SystemAccountID, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccount(ctx, int64(7), valueobject.DefaultAssetCode).Return(dummyAccount(7, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(7), valueobject.DefaultAssetCode).Return(dummyAccount(7, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().CreditBalance(ctx, int64(7), amount).Return(nil).Times(1)
+	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+
+	minted, err := svc.Mint(ctx, &command.MintCommand{AdjustmentCommand: command.AdjustmentCommand{
+		AccountID: 7, Nonce: 1, Amount: amount, ReasonCode: "campaign",
+	}})
+	if err != nil {
+		t.Fatalf("Mint returned error: %v", err)
+	}
+	if minted.Type() != valueobject.TradeTypeMint || minted.ToAccountID != 7 {
+		t.Errorf("unexpected mint trade: %+v", minted)
+	}
+
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, int64(2), int64(7), nil).Return(nil, nil).Times(1)
+	_, err = svc.Burn(ctx, &command.BurnCommand{AdjustmentCommand: command.AdjustmentCommand{
+		AccountID: 7, Nonce: 2, Amount: amount, ReasonCode: "fraud",
+	}})
+	if err == nil {
+		t.Fatalf("expected burn beyond the available balance to fail")
+	}
+}
@@ -0,0 +1,135 @@
+package drain
+
+import (
+	"context"
+	"errors"
+	"points/internal/domain"
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"sync"
+)
+
+var _ domain.HealthChecker = (*TradeGate)(nil)
+
+// TradeGate admits trade calls until Drain is called, then waits for the
+// admitted calls to finish so no transfer is cut off mid-transaction.
+type TradeGate struct {
+	mu       sync.RWMutex
+	draining bool
+	inFlight sync.WaitGroup
+}
+
+func NewTradeGate() *TradeGate {
+	return &TradeGate{}
+}
+
+// Guard rejects trade calls with ErrUnavailable once the gate is draining.
+func (g *TradeGate) Guard(next domain.TradeUsecase) domain.TradeUsecase {
+	return &guardedTradeUsecase{next: next, gate: g}
+}
+
+// GuardAdmin does the same for mints and burns.
+func (g *TradeGate) GuardAdmin(next domain.AdminUsecase) domain.AdminUsecase {
+	return &guardedAdminUsecase{next: next, gate: g}
+}
+
+// Drain stops admitting trades and blocks until in-flight calls return or
+// ctx is done.
+func (g *TradeGate) Drain(ctx context.Context) error {
+	g.mu.Lock()
+	g.draining = true
+	g.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		g.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Name and Check let /readyz report the instance as unavailable while draining.
+func (g *TradeGate) Name() string {
+	return "trades"
+}
+
+func (g *TradeGate) Check(ctx context.Context) error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.draining {
+		return errors.New("draining in-flight trades")
+	}
+	return nil
+}
+
+func (g *TradeGate) enter() error {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if g.draining {
+		return apperror.Wrap(errcode.ErrUnavailable, "trade gate - shutting down", nil)
+	}
+	g.inFlight.Add(1)
+	return nil
+}
+
+func (g *TradeGate) leave() {
+	g.inFlight.Done()
+}
+
+type guardedTradeUsecase struct {
+	next domain.TradeUsecase
+	gate *TradeGate
+}
+
+func (u *guardedTradeUsecase) Transfer(ctx context.Context, req *command.TransferCommand) (*entity.TradeRecords, error) {
+	if err := u.gate.enter(); err != nil {
+		return nil, err
+	}
+	defer u.gate.leave()
+	return u.next.Transfer(ctx, req)
+}
+
+func (u *guardedTradeUsecase) ManualConfirm(ctx context.Context, req *command.ConfirmCommand) (*entity.TradeRecords, error) {
+	if err := u.gate.enter(); err != nil {
+		return nil, err
+	}
+	defer u.gate.leave()
+	return u.next.ManualConfirm(ctx, req)
+}
+
+func (u *guardedTradeUsecase) Cancel(ctx context.Context, req *command.CancelCommand) (*entity.TradeRecords, error) {
+	if err := u.gate.enter(); err != nil {
+		return nil, err
+	}
+	defer u.gate.leave()
+	return u.next.Cancel(ctx, req)
+}
+
+type guardedAdminUsecase struct {
+	next domain.AdminUsecase
+	gate *TradeGate
+}
+
+func (u *guardedAdminUsecase) Mint(ctx context.Context, req *command.MintCommand) (*entity.TradeRecords, error) {
+	if err := u.gate.enter(); err != nil {
+		return nil, err
+	}
+	defer u.gate.leave()
+	return u.next.Mint(ctx, req)
+}
+
+func (u *guardedAdminUsecase) Burn(ctx context.Context, req *command.BurnCommand) (*entity.TradeRecords, error) {
+	if err := u.gate.enter(); err != nil {
+		return nil, err
+	}
+	defer u.gate.leave()
+	return u.next.Burn(ctx, req)
+}
@@ -0,0 +1,129 @@
+package drain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"points/internal/domain/command"
+	"points/internal/domain/entity"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTradeGate_DrainWaitsForInFlightTrades(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	next := mock.NewMockTradeUsecase(ctrl)
+	gate := NewTradeGate()
+	usecase := gate.Guard(next)
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	next.EXPECT().Transfer(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *command.TransferCommand) (*entity.TradeRecords, error) {
+			close(started)
+			<-release
+			return &entity.TradeRecords{}, nil
+		}).Times(1)
+
+	transferErr := make(chan error, 1)
+	go func() {
+		_, err := usecase.Transfer(ctx, &command.TransferCommand{})
+		transferErr <- err
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- gate.Drain(ctx) }()
+
+	assert.Eventually(t, func() bool { return gate.Check(ctx) != nil }, time.Second, time.Millisecond, "gate should report draining")
+	_, err := usecase.Cancel(ctx, &command.CancelCommand{})
+	var appErr *apperror.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, errcode.ErrUnavailable, appErr.Code, "new trades should be rejected while draining")
+
+	select {
+	case <-drained:
+		t.Fatal("drain returned before the in-flight transfer finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-transferErr)
+	assert.NoError(t, <-drained)
+}
+
+func TestTradeGate_DrainStopsWaitingAtDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	next := mock.NewMockTradeUsecase(ctrl)
+	gate := NewTradeGate()
+	usecase := gate.Guard(next)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	next.EXPECT().ManualConfirm(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *command.ConfirmCommand) (*entity.TradeRecords, error) {
+			close(started)
+			<-release
+			return nil, nil
+		}).Times(1)
+
+	go func() { _, _ = usecase.ManualConfirm(context.Background(), &command.ConfirmCommand{}) }()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, gate.Drain(ctx), context.DeadlineExceeded)
+}
+
+func TestTradeGate_DrainWaitsForInFlightAdminCalls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	next := mock.NewMockAdminUsecase(ctrl)
+	gate := NewTradeGate()
+	usecase := gate.GuardAdmin(next)
+	ctx := context.Background()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	next.EXPECT().Mint(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, req *command.MintCommand) (*entity.TradeRecords, error) {
+			close(started)
+			<-release
+			return &entity.TradeRecords{}, nil
+		}).Times(1)
+
+	mintErr := make(chan error, 1)
+	go func() {
+		_, err := usecase.Mint(ctx, &command.MintCommand{})
+		mintErr <- err
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- gate.Drain(ctx) }()
+	assert.Eventually(t, func() bool { return gate.Check(ctx) != nil }, time.Second, time.Millisecond, "gate should report draining")
+
+	_, err := usecase.Burn(ctx, &command.BurnCommand{})
+	var appErr *apperror.AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, errcode.ErrUnavailable, appErr.Code, "new burns should be rejected while draining")
+
+	select {
+	case <-drained:
+		t.Fatal("drain returned before the in-flight mint finished")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	assert.NoError(t, <-mintErr)
+	assert.NoError(t, <-drained)
+}
@@ -0,0 +1,87 @@
+package expiry
+
+import (
+	"context"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/internal/usecase/locking"
+	"points/internal/usecase/transaction"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type PointExpiryApplicationService interface {
+	ExpirePointLots(ctx context.Context) (int, error)
+}
+
+type pointExpiryApplicationService struct {
+	unitOfWork         repository.UnitOfWork
+	lockService        locking.AccountLockApplicationService
+	transactionService transaction.TransactionApplicationService
+	batchSize          int
+	now                func() time.Time
+}
+
+func NewPointExpiryApplicationService(unitOfWork repository.UnitOfWork, locker domain.Locker, config port.Config) PointExpiryApplicationService {
+	config.SetDefaultInt("expiry.POINT_EXPIRY_BATCH_SIZE", 100)
+
+	return &pointExpiryApplicationService{
+		unitOfWork:         unitOfWork,
+		lockService:        locking.NewAccountLockService(locker, config),
+		transactionService: transaction.NewTransactionApplicationService(),
+		batchSize:          config.GetInt("expiry.POINT_EXPIRY_BATCH_SIZE"),
+		now:                valueobject.Now,
+	}
+}
+
+// ExpirePointLots burns the expired lots of every account found in the next
+// batch and returns how many accounts had points expired.
+func (s *pointExpiryApplicationService) ExpirePointLots(ctx context.Context) (int, error) {
+	now := s.now()
+	lots, err := s.unitOfWork.PointLotRepository().ListExpiredPointLots(ctx, now, s.batchSize)
+	if err != nil {
+		return 0, apperror.Wrap(errcode.ErrGetPointLot, "expire phase - get expired point lots", err)
+	}
+
+	expired := 0
+	seen := make(map[repository.AccountAsset]struct{}, len(lots))
+	for _, lot := range lots {
+		if err := ctx.Err(); err != nil {
+			return expired, err
+		}
+
+		key := repository.AccountAsset{AccountID: lot.AccountID, AssetCode: lot.Amount.Asset()}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		if err := s.expire(ctx, key, now); err != nil {
+			zap.L().Warn("failed to expire point lots",
+				zap.Int64("account_id", key.AccountID),
+				zap.String("asset_code", key.AssetCode.String()),
+				zap.Error(err),
+			)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// expire only locks the account whose lots expire, like admin burns, so
+// sweeps of different accounts run in parallel with trades and adjustments.
+func (s *pointExpiryApplicationService) expire(ctx context.Context, key repository.AccountAsset, now time.Time) error {
+	return s.lockService.WithAccountsLock(ctx, []int64{key.AccountID}, func(ctx context.Context) error {
+		return s.unitOfWork.Transaction(ctx, func(u repository.UnitOfWork) error {
+			_, err := s.transactionService.ExpirePointLots(ctx, u, key.AccountID, key.AssetCode, now)
+			return err
+		})
+	})
+}
@@ -0,0 +1,190 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"points/internal/domain/entity"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func expiredLot(id, accountID, amount int64, expiresAt time.Time) *entity.PointLot {
+	lot := entity.NewPointLot(accountID, "tx-mint", valueobject.NewMoneyFromDecimal(decimal.NewFromInt(amount)), &expiresAt)
+	lot.ID = id
+	return lot
+}
+
+func TestExpirePointLots_BurnsEachAccountOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockAccRepo := mock.NewMockAccountRepository(ctrl)
+	mockTxRepo := mock.NewMockTradeRecordsRepository(ctrl)
+	mockEventRepo := mock.NewMockTransactionEventRepository(ctrl)
+	mockLedgerRepo := mock.NewMockLedgerRepository(ctrl)
+	mockLotRepo := mock.NewMockPointLotRepository(ctrl)
+	mockLocker := mock.NewMockLocker(ctrl)
+	mockConfig := mock.NewMockConfig(ctrl)
+
+	mockUow.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(uow repository.UnitOfWork) error) error {
+			return fn(mockUow)
+		}).AnyTimes()
+	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
+	mockUow.EXPECT().TradeRecordsRepository().Return(mockTxRepo).AnyTimes()
+	mockUow.EXPECT().TransactionEventRepository().Return(mockEventRepo).AnyTimes()
+	mockUow.EXPECT().LedgerRepository().Return(mockLedgerRepo).AnyTimes()
+	mockUow.EXPECT().PointLotRepository().Return(mockLotRepo).AnyTimes()
+
+	mockConfig.EXPECT().SetDefaultInt(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	mockConfig.EXPECT().GetInt("lock.LOCK_TTL").Return(5).Times(1)
+	mockConfig.EXPECT().GetInt("lock.LOCK_RETRY_INTERVAL").Return(100).Times(1)
+	mockConfig.EXPECT().Subscribe(gomock.Any()).Times(1)
+	mockConfig.EXPECT().GetInt("expiry.POINT_EXPIRY_BATCH_SIZE").Return(10).Times(1)
+
+	svc := NewPointExpiryApplicationService(mockUow, mockLocker, mockConfig).(*pointExpiryApplicationService)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	first := expiredLot(1, 1, 20, now.Add(-2*time.Hour))
+	second := expiredLot(2, 1, 5, now.Add(-time.Hour))
+	other := expiredLot(3, 2, 10, now.Add(-time.Hour))
+	mockLotRepo.EXPECT().ListExpiredPointLots(ctx, now, 10).Return([]*entity.PointLot{first, second, other}, nil).Times(1)
+
+	mockLock := mock.NewMockLock(ctrl)
+	mockLocker.EXPECT().AcquireAll(ctx, []string{"account_lock:1"}, gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(1)
+	mockLocker.EXPECT().AcquireAll(ctx, []string{"account_lock:2"}, gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(1)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).Times(2)
+
+	account := &entity.Account{
+		UserID:           1,
+		AvailableBalance: valueobject.NewMoneyFromDecimal(decimal.NewFromInt(25)),
+		ReservedBalance:  valueobject.Zero,
+	}
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(account, nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(nil, errors.New("db error")).Times(1)
+	mockLotRepo.EXPECT().ListPointLots(ctx, int64(1), valueobject.DefaultAssetCode).Return([]*entity.PointLot{first, second}, nil).Times(1)
+	mockAccRepo.EXPECT().GetAccount(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode).Return(&entity.Account{}, nil).Times(1)
+	mockAccRepo.EXPECT().DebitBalance(ctx, int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(25))).Return(nil).Times(1)
+	mockLotRepo.EXPECT().ConsumePointLots(ctx, gomock.Len(2)).Return(nil).Times(1)
+	mockLedgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).Times(1)
+	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, evt *entity.TransactionEvent) error {
+			assert.Equal(t, "expired", evt.EventType)
+			return nil
+		}).Times(1)
+
+	expired, err := svc.ExpirePointLots(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired, "a failing account should not stop the sweep")
+}
+
+func TestExpirePointLots_TwoAssetsOfOneAccountGetDistinctNonces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockAccRepo := mock.NewMockAccountRepository(ctrl)
+	mockTxRepo := mock.NewMockTradeRecordsRepository(ctrl)
+	mockEventRepo := mock.NewMockTransactionEventRepository(ctrl)
+	mockLedgerRepo := mock.NewMockLedgerRepository(ctrl)
+	mockLotRepo := mock.NewMockPointLotRepository(ctrl)
+	mockLocker := mock.NewMockLocker(ctrl)
+	mockConfig := mock.NewMockConfig(ctrl)
+
+	mockUow.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(uow repository.UnitOfWork) error) error {
+			return fn(mockUow)
+		}).AnyTimes()
+	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
+	mockUow.EXPECT().TradeRecordsRepository().Return(mockTxRepo).AnyTimes()
+	mockUow.EXPECT().TransactionEventRepository().Return(mockEventRepo).AnyTimes()
+	mockUow.EXPECT().LedgerRepository().Return(mockLedgerRepo).AnyTimes()
+	mockUow.EXPECT().PointLotRepository().Return(mockLotRepo).AnyTimes()
+
+	mockConfig.EXPECT().SetDefaultInt(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	mockConfig.EXPECT().GetInt(gomock.Any()).Return(10).AnyTimes()
+	mockConfig.EXPECT().Subscribe(gomock.Any()).AnyTimes()
+
+	svc := NewPointExpiryApplicationService(mockUow, mockLocker, mockConfig).(*pointExpiryApplicationService)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	cashback := valueobject.AssetCode("CASHBACK")
+	points := expiredLot(1, 1, 20, now.Add(-time.Hour))
+	expiresAt := now.Add(-time.Hour)
+	bonus := entity.NewPointLot(1, "tx-mint", valueobject.NewMoneyFromDecimal(decimal.NewFromInt(5)).WithAsset(cashback), &expiresAt)
+	bonus.ID = 2
+	mockLotRepo.EXPECT().ListExpiredPointLots(ctx, now, 10).Return([]*entity.PointLot{points, bonus}, nil).Times(1)
+
+	mockLock := mock.NewMockLock(ctrl)
+	mockLocker.EXPECT().AcquireAll(ctx, []string{"account_lock:1"}, gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(2)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).Times(2)
+
+	for _, lot := range []*entity.PointLot{points, bonus} {
+		asset := lot.Amount.Asset()
+		mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), asset).Return(&entity.Account{
+			UserID:           1,
+			AssetCode:        asset,
+			AvailableBalance: lot.Amount,
+			ReservedBalance:  valueobject.ZeroOf(asset),
+		}, nil).Times(1)
+		mockLotRepo.EXPECT().ListPointLots(ctx, int64(1), asset).Return([]*entity.PointLot{lot}, nil).Times(1)
+		mockAccRepo.EXPECT().GetAccount(ctx, valueobject.SystemAccountID, asset).Return(&entity.Account{}, nil).Times(1)
+		mockAccRepo.EXPECT().DebitBalance(ctx, int64(1), lot.Amount).Return(nil).Times(1)
+	}
+	mockLotRepo.EXPECT().ConsumePointLots(ctx, gomock.Len(1)).Return(nil).Times(2)
+	mockLedgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).Times(2)
+	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(2)
+
+	nonces := make(map[int64]struct{})
+	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, trans *entity.TradeRecords) error {
+			if _, ok := nonces[trans.Nonce]; ok {
+				return errors.New("duplicate key value violates unique constraint")
+			}
+			nonces[trans.Nonce] = struct{}{}
+			return nil
+		}).Times(2)
+
+	expired, err := svc.ExpirePointLots(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, expired, "both assets of the account should expire in the same sweep")
+	assert.Len(t, nonces, 2)
+}
+
+func TestExpirePointLots_QueryFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockLotRepo := mock.NewMockPointLotRepository(ctrl)
+	mockConfig := mock.NewMockConfig(ctrl)
+	mockUow.EXPECT().PointLotRepository().Return(mockLotRepo).AnyTimes()
+	mockConfig.EXPECT().SetDefaultInt(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	mockConfig.EXPECT().GetInt(gomock.Any()).Return(10).AnyTimes()
+	mockConfig.EXPECT().Subscribe(gomock.Any()).AnyTimes()
+
+	svc := NewPointExpiryApplicationService(mockUow, mock.NewMockLocker(ctrl), mockConfig)
+	mockLotRepo.EXPECT().ListExpiredPointLots(ctx, gomock.Any(), 10).Return(nil, errors.New("db error")).Times(1)
+
+	expired, err := svc.ExpirePointLots(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db error")
+	assert.Equal(t, 0, expired)
+}
@@ -0,0 +1,81 @@
+package expiry
+
+import (
+	"context"
+	"points/internal/domain"
+	"points/internal/domain/entity"
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/internal/usecase/locking"
+	"points/internal/usecase/transaction"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type TccExpiryApplicationService interface {
+	CancelExpiredTransactions(ctx context.Context) (int, error)
+}
+
+type tccExpiryApplicationService struct {
+	unitOfWork         repository.UnitOfWork
+	lockService        locking.AccountLockApplicationService
+	transactionService transaction.TransactionApplicationService
+	batchSize          int
+	now                func() time.Time
+}
+
+func NewTccExpiryApplicationService(unitOfWork repository.UnitOfWork, locker domain.Locker, config port.Config) TccExpiryApplicationService {
+	return &tccExpiryApplicationService{
+		unitOfWork:         unitOfWork,
+		lockService:        locking.NewAccountLockService(locker, config),
+		transactionService: transaction.NewTransactionApplicationService(),
+		batchSize:          initBatchSize(config),
+		now:                valueobject.Now,
+	}
+}
+
+func (s *tccExpiryApplicationService) CancelExpiredTransactions(ctx context.Context) (int, error) {
+	records, err := s.unitOfWork.TradeRecordsRepository().GetExpiredTradeRecords(ctx, s.now(), s.batchSize)
+	if err != nil {
+		return 0, apperror.Wrap(errcode.ErrGetTransaction, "expiry phase - get expired transactions", err)
+	}
+
+	canceled := 0
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return canceled, err
+		}
+
+		if err := s.cancel(ctx, record); err != nil {
+			zap.L().Warn("failed to cancel expired transaction",
+				zap.String("transaction_id", record.TransactionID),
+				zap.Int64("from", record.FromAccountID),
+				zap.Int64("nonce", record.Nonce),
+				zap.Error(err),
+			)
+			continue
+		}
+		canceled++
+	}
+
+	return canceled, nil
+}
+
+func (s *tccExpiryApplicationService) cancel(ctx context.Context, record *entity.TradeRecords) error {
+	return s.lockService.WithAccountTradeLock(ctx, record.FromAccountID, record.ToAccountID, func(ctx context.Context) error {
+		return s.unitOfWork.Transaction(ctx, func(u repository.UnitOfWork) error {
+			_, err := s.transactionService.CancelTransaction(ctx, u, record.Nonce, record.FromAccountID, record.ToAccountID, valueobject.CancelReasonTimeout)
+			return err
+		})
+	})
+}
+
+func initBatchSize(config port.Config) int {
+	config.SetDefaultInt("tcc.TCC_SWEEP_BATCH_SIZE", 100)
+
+	return config.GetInt("tcc.TCC_SWEEP_BATCH_SIZE")
+}
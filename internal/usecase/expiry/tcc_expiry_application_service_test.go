@@ -0,0 +1,160 @@
+package expiry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"points/internal/domain/entity"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestTccExpiryService(t *testing.T) (
+	ctrl *gomock.Controller,
+	ctx context.Context,
+	mockAccRepo *mock.MockAccountRepository,
+	mockTxRepo *mock.MockTradeRecordsRepository,
+	mockEventRepo *mock.MockTransactionEventRepository,
+	mockLocker *mock.MockLocker,
+	svc *tccExpiryApplicationService,
+) {
+	ctrl = gomock.NewController(t)
+	ctx = context.Background()
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockAccRepo = mock.NewMockAccountRepository(ctrl)
+	mockTxRepo = mock.NewMockTradeRecordsRepository(ctrl)
+	mockEventRepo = mock.NewMockTransactionEventRepository(ctrl)
+	mockLocker = mock.NewMockLocker(ctrl)
+	mockConfig := mock.NewMockConfig(ctrl)
+
+	mockUow.EXPECT().Transaction(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(uow repository.UnitOfWork) error) error {
+			return fn(mockUow)
+		}).AnyTimes()
+	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
+	mockUow.EXPECT().TradeRecordsRepository().Return(mockTxRepo).AnyTimes()
+	mockUow.EXPECT().TransactionEventRepository().Return(mockEventRepo).AnyTimes()
+	mockLedgerRepo := mock.NewMockLedgerRepository(ctrl)
+	mockLedgerRepo.EXPECT().CreateLedgerEntries(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockUow.EXPECT().LedgerRepository().Return(mockLedgerRepo).AnyTimes()
+	stubPointLots(ctrl, mockUow)
+
+	mockConfig.EXPECT().SetDefaultInt(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	mockConfig.EXPECT().GetInt("lock.LOCK_TTL").Return(5).Times(1)
+	mockConfig.EXPECT().GetInt("lock.LOCK_RETRY_INTERVAL").Return(100).Times(1)
+	mockConfig.EXPECT().Subscribe(gomock.Any()).Times(1)
+	mockConfig.EXPECT().GetInt("tcc.TCC_SWEEP_BATCH_SIZE").Return(10).Times(1)
+
+	svc = NewTccExpiryApplicationService(mockUow, mockLocker, mockConfig).(*tccExpiryApplicationService)
+	return
+}
+
+// stubPointLots serves accounts without lots and accepts every lot update.
+func stubPointLots(ctrl *gomock.Controller, uow *mock.MockUnitOfWork) *mock.MockPointLotRepository {
+	lotRepo := mock.NewMockPointLotRepository(ctrl)
+	uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+	lotRepo.EXPECT().ListPointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	lotRepo.EXPECT().GetLotAllocations(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	lotRepo.EXPECT().CreatePointLots(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ReservePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ReleasePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().SettlePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ConsumePointLots(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return lotRepo
+}
+
+func expiredRecord(txID string, nonce int64, expiredAt time.Time) *entity.TradeRecords {
+	return &entity.TradeRecords{
+		TransactionID: txID,
+		Nonce:         nonce,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(50)),
+		Status:        int32(valueobject.TccPending),
+		ExpiredAt:     &expiredAt,
+	}
+}
+
+func TestCancelExpiredTransactions_Success(t *testing.T) {
+	ctrl, ctx, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, svc := setupTestTccExpiryService(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	record := expiredRecord("tx-expired", 1, now.Add(-time.Minute))
+
+	mockLock := mock.NewMockLock(ctrl)
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(1)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).Times(1)
+
+	mockTxRepo.EXPECT().GetExpiredTradeRecords(ctx, now, 10).Return([]*entity.TradeRecords{record}, nil).Times(1)
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, int64(1), int64(1), valueobject.TccPending.Ptr()).Return(record, nil).Times(2)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(&entity.Account{UserID: 1}, nil).Times(1)
+	mockAccRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(1), record.Amount).Return(nil).Times(1)
+	mockTxRepo.EXPECT().UpdateTradeRecord(ctx, record).Return(nil).Times(1)
+	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, evt *entity.TransactionEvent) error {
+			var payload map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(evt.Payload), &payload))
+			assert.Equal(t, valueobject.TccCanceled.String(), evt.EventType)
+			assert.Equal(t, valueobject.CancelReasonTimeout.String(), payload["Reason"])
+			return nil
+		}).Times(1)
+
+	canceled, err := svc.CancelExpiredTransactions(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, canceled)
+	assert.Equal(t, int32(valueobject.TccCanceled), record.Status)
+}
+
+func TestCancelExpiredTransactions_ContinuesOnRecordFailure(t *testing.T) {
+	ctrl, ctx, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, svc := setupTestTccExpiryService(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	confirmedMeanwhile := expiredRecord("tx-confirmed", 1, now.Add(-time.Minute))
+	stillPending := expiredRecord("tx-pending", 2, now.Add(-time.Second))
+
+	mockLock := mock.NewMockLock(ctrl)
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(2)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).Times(2)
+
+	mockTxRepo.EXPECT().GetExpiredTradeRecords(ctx, now, 10).
+		Return([]*entity.TradeRecords{confirmedMeanwhile, stillPending}, nil).Times(1)
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, int64(1), int64(1), valueobject.TccPending.Ptr()).
+		Return(nil, errors.New("record not found")).Times(1)
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, int64(2), int64(1), valueobject.TccPending.Ptr()).
+		Return(stillPending, nil).Times(2)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(&entity.Account{UserID: 1}, nil).Times(1)
+	mockAccRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(1), stillPending.Amount).Return(nil).Times(1)
+	mockTxRepo.EXPECT().UpdateTradeRecord(ctx, stillPending).Return(nil).Times(1)
+	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
+
+	canceled, err := svc.CancelExpiredTransactions(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, canceled)
+}
+
+func TestCancelExpiredTransactions_QueryFailure(t *testing.T) {
+	ctrl, ctx, _, mockTxRepo, _, _, svc := setupTestTccExpiryService(t)
+	defer ctrl.Finish()
+
+	mockTxRepo.EXPECT().GetExpiredTradeRecords(ctx, gomock.Any(), 10).Return(nil, errors.New("db error")).Times(1)
+
+	canceled, err := svc.CancelExpiredTransactions(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "db error")
+	assert.Equal(t, 0, canceled)
+}
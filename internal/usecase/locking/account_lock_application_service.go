@@ -2,44 +2,92 @@ package locking
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"points/internal/domain"
 	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
 	"points/internal/shared/apperror"
 	"points/internal/shared/errcode"
+	"points/internal/shared/telemetry"
+	"sort"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+var tracer = otel.Tracer("points/internal/usecase/locking")
+
 type AccountLockApplicationService interface {
-	WithAccountTradeLock(ctx context.Context, from, to int64, fn func() error) error
+	WithAccountTradeLock(ctx context.Context, from, to int64, fn func(ctx context.Context) error) error
+	WithAccountsLock(ctx context.Context, accountIDs []int64, fn func(ctx context.Context) error) error
+	WithTradeLock(ctx context.Context, key string, operation func(ctx context.Context) error) error
 }
 
 type accountLockApplicationService struct {
 	locker        domain.Locker
-	lockDuration  time.Duration
-	retryInterval time.Duration
+	lockDuration  atomic.Int64
+	retryInterval atomic.Int64
 	config        port.Config
 }
 
+// NewAccountLockService reads the lock timing once and again after every
+// config reload, so new locks pick up changed values.
 func NewAccountLockService(locker domain.Locker, config port.Config) AccountLockApplicationService {
-	lockDuration, retryInterval := initTTL(config)
-	return &accountLockApplicationService{
-		locker:        locker,
-		lockDuration:  lockDuration,
-		retryInterval: retryInterval,
-		config:        config,
+	service := &accountLockApplicationService{
+		locker: locker,
+		config: config,
 	}
+	service.setTTL(initTTL(config))
+	config.Subscribe(func(port.ConfigChange) {
+		service.setTTL(readTTL(config))
+	})
+	return service
+}
+
+func (a *accountLockApplicationService) setTTL(lockDuration, retryInterval time.Duration) {
+	a.lockDuration.Store(int64(lockDuration))
+	a.retryInterval.Store(int64(retryInterval))
+}
+
+func (a *accountLockApplicationService) WithAccountTradeLock(ctx context.Context, from, to int64, fn func(ctx context.Context) error) error {
+	return a.WithAccountsLock(ctx, []int64{from, to}, fn)
+}
+
+// WithAccountsLock locks each account on its own, all at once, so transfers
+// sharing one account serialize while unrelated ones run in parallel.
+func (a *accountLockApplicationService) WithAccountsLock(ctx context.Context, accountIDs []int64, fn func(ctx context.Context) error) error {
+	keys := getLockKeys(accountIDs)
+	return a.withLock(ctx, keys, func(lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+		return a.locker.AcquireAll(ctx, keys, lockDuration, retryInterval)
+	}, fn)
 }
 
-func (a *accountLockApplicationService) WithAccountTradeLock(ctx context.Context, from, to int64, fn func() error) error {
-	lockKey := getLockKey(from, to)
-	return a.WithTradeLock(ctx, lockKey, fn)
+func (a *accountLockApplicationService) WithTradeLock(ctx context.Context, key string, operation func(ctx context.Context) error) error {
+	return a.withLock(ctx, []string{key}, func(lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+		return a.locker.Acquire(ctx, key, lockDuration, retryInterval)
+	}, operation)
 }
 
-func (a *accountLockApplicationService) WithTradeLock(ctx context.Context, key string, operation func() error) error {
-	lock, err := a.locker.Acquire(ctx, key, a.lockDuration, a.retryInterval)
+// withLock runs operation under the lock. Its ctx carries the lock's
+// fencing token, so repositories refuse its writes once a newer holder has
+// written the same account, which is reported as retryable.
+func (a *accountLockApplicationService) withLock(ctx context.Context, keys []string, acquire func(lockDuration, retryInterval time.Duration) (domain.Lock, error), operation func(ctx context.Context) error) (err error) {
+	// The spans only time the lock; the locker and operation keep the caller's ctx.
+	spanCtx, span := tracer.Start(ctx, "lock.WithTradeLock", trace.WithAttributes(attribute.StringSlice("lock.keys", keys)))
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	lockDuration := time.Duration(a.lockDuration.Load())
+	retryInterval := time.Duration(a.retryInterval.Load())
+
+	_, acquireSpan := tracer.Start(spanCtx, "lock.Acquire")
+	lock, err := acquire(lockDuration, retryInterval)
+	telemetry.EndSpan(acquireSpan, err)
 	if err != nil {
 		return apperror.Wrap(errcode.ErrDistrubutedLockAcquire, "failed to acquire lock", err)
 	}
@@ -55,7 +103,7 @@ func (a *accountLockApplicationService) WithTradeLock(ctx context.Context, key s
 	errCh := make(chan error, 1)
 
 	go func() {
-		ticker := time.NewTicker(a.lockDuration / 2)
+		ticker := time.NewTicker(lockDuration / 2)
 		defer ticker.Stop()
 
 		for {
@@ -63,7 +111,7 @@ func (a *accountLockApplicationService) WithTradeLock(ctx context.Context, key s
 			case <-renewCtx.Done():
 				return
 			case <-ticker.C:
-				if err := lock.Renew(renewCtx, a.lockDuration); err != nil {
+				if err := lock.Renew(renewCtx, lockDuration); err != nil {
 					errCh <- apperror.Wrap(errcode.ErrDistrubutedLockRenew, "failed to renew lock", err)
 					return
 				}
@@ -71,9 +119,14 @@ func (a *accountLockApplicationService) WithTradeLock(ctx context.Context, key s
 		}
 	}()
 
+	opCtx := ctx
+	if token := lock.Token(); token != 0 {
+		opCtx = valueobject.WithFencingToken(ctx, token)
+	}
+
 	opCh := make(chan error, 1)
 	go func() {
-		opCh <- operation()
+		opCh <- operation(opCtx)
 	}()
 
 	select {
@@ -82,8 +135,11 @@ func (a *accountLockApplicationService) WithTradeLock(ctx context.Context, key s
 		case renewErr := <-errCh:
 			return renewErr
 		default:
-			return opErr
 		}
+		if errors.Is(opErr, repository.ErrStaleFencingToken) {
+			return apperror.Wrap(errcode.ErrStaleFencingToken, "lock taken over by a newer holder", opErr)
+		}
+		return opErr
 	case renewErr := <-errCh:
 		cancel()
 		return renewErr
@@ -93,18 +149,31 @@ func (a *accountLockApplicationService) WithTradeLock(ctx context.Context, key s
 }
 
 func initTTL(config port.Config) (time.Duration, time.Duration) {
-	config.SetDefaultInt("LOCK_DURATION", 5)
-	config.SetDefaultInt("RETRY_INTERVAL", 100)
+	config.SetDefaultInt("lock.LOCK_TTL", 5)
+	config.SetDefaultInt("lock.LOCK_RETRY_INTERVAL", 100)
+	return readTTL(config)
+}
 
-	lockDuration := config.GetInt("LOCK_DURATION")
-	retryInterval := config.GetInt("RETRY_INTERVAL")
+// readTTL reads the lock TTL in seconds and the acquire retry interval in
+// milliseconds.
+func readTTL(config port.Config) (time.Duration, time.Duration) {
+	lockDuration := config.GetInt("lock.LOCK_TTL")
+	retryInterval := config.GetInt("lock.LOCK_RETRY_INTERVAL")
 
-	return time.Duration(lockDuration) * time.Second, time.Duration(retryInterval) * time.Second
+	return time.Duration(lockDuration) * time.Second, time.Duration(retryInterval) * time.Millisecond
 }
 
-func getLockKey(from, to int64) string {
-	if from < to {
-		return fmt.Sprintf("transfer_lock:%d:%d", from, to)
+// getLockKeys returns one key per distinct account, ordered by account id.
+func getLockKeys(accountIDs []int64) []string {
+	ids := append([]int64(nil), accountIDs...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	keys := make([]string, 0, len(ids))
+	for i, id := range ids {
+		if i > 0 && id == ids[i-1] {
+			continue
+		}
+		keys = append(keys, fmt.Sprintf("account_lock:%d", id))
 	}
-	return fmt.Sprintf("transfer_lock:%d:%d", to, from)
+	return keys
 }
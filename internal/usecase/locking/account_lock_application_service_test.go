@@ -3,9 +3,13 @@ package locking
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
 	"points/internal/shared/apperror"
 	"points/internal/shared/errcode"
 	"points/test/mock"
@@ -20,19 +24,17 @@ func TestWithTradeLock_Success_GoMock(t *testing.T) {
 
 	mockLock := mock.NewMockLock(ctrl)
 	mockLock.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Renew(gomock.Any(), gomock.Any()).Return(nil).MinTimes(1)
 
 	mockLocker := mock.NewMockLocker(ctrl)
 	mockLocker.EXPECT().Acquire(gomock.Any(), "test-key", gomock.Any(), gomock.Any()).Return(mockLock, nil)
 
-	svc := &accountLockApplicationService{
-		locker:        mockLocker,
-		lockDuration:  100 * time.Millisecond,
-		retryInterval: 50 * time.Millisecond,
-	}
+	svc := &accountLockApplicationService{locker: mockLocker}
+	svc.setTTL(100*time.Millisecond, 50*time.Millisecond)
 
 	opCalled := false
-	operation := func() error {
+	operation := func(ctx context.Context) error {
 		opCalled = true
 		time.Sleep(150 * time.Millisecond)
 		return nil
@@ -51,13 +53,10 @@ func TestWithTradeLock_AcquireFailure_GoMock(t *testing.T) {
 	acquireErr := errors.New("acquire error")
 	mockLocker.EXPECT().Acquire(gomock.Any(), "test-key", gomock.Any(), gomock.Any()).Return(nil, acquireErr)
 
-	svc := &accountLockApplicationService{
-		locker:        mockLocker,
-		lockDuration:  100 * time.Millisecond,
-		retryInterval: 50 * time.Millisecond,
-	}
+	svc := &accountLockApplicationService{locker: mockLocker}
+	svc.setTTL(100*time.Millisecond, 50*time.Millisecond)
 
-	operation := func() error { return nil }
+	operation := func(ctx context.Context) error { return nil }
 	err := svc.WithTradeLock(context.Background(), "test-key", operation)
 	assert.Error(t, err)
 	appErr, ok := err.(*apperror.AppError)
@@ -72,19 +71,17 @@ func TestWithTradeLock_RenewFailure_GoMock(t *testing.T) {
 	mockLock := mock.NewMockLock(ctrl)
 	renewErr := errors.New("renew error")
 	mockLock.EXPECT().Release(gomock.Any()).Return(nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Renew(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 	mockLock.EXPECT().Renew(gomock.Any(), gomock.Any()).Return(renewErr)
 
 	mockLocker := mock.NewMockLocker(ctrl)
 	mockLocker.EXPECT().Acquire(gomock.Any(), "test-key", gomock.Any(), gomock.Any()).Return(mockLock, nil)
 
-	svc := &accountLockApplicationService{
-		locker:        mockLocker,
-		lockDuration:  100 * time.Millisecond,
-		retryInterval: 50 * time.Millisecond,
-	}
+	svc := &accountLockApplicationService{locker: mockLocker}
+	svc.setTTL(100*time.Millisecond, 50*time.Millisecond)
 
-	operation := func() error {
+	operation := func(ctx context.Context) error {
 		time.Sleep(300 * time.Millisecond)
 		return nil
 	}
@@ -95,3 +92,74 @@ func TestWithTradeLock_RenewFailure_GoMock(t *testing.T) {
 	assert.True(t, ok, "err should be AppError")
 	assert.Equal(t, appErr.Code, errcode.ErrDistrubutedLockRenew)
 }
+
+func TestWithTradeLock_FencingToken_GoMock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLock := mock.NewMockLock(ctrl)
+	mockLock.EXPECT().Release(gomock.Any()).Return(nil)
+	mockLock.EXPECT().Token().Return(int64(42))
+
+	mockLocker := mock.NewMockLocker(ctrl)
+	mockLocker.EXPECT().Acquire(gomock.Any(), "test-key", gomock.Any(), gomock.Any()).Return(mockLock, nil)
+
+	svc := &accountLockApplicationService{locker: mockLocker}
+	svc.setTTL(time.Second, 50*time.Millisecond)
+
+	var token int64
+	operation := func(ctx context.Context) error {
+		token, _ = valueobject.FencingTokenFromContext(ctx)
+		return fmt.Errorf("reserve balance: %w", repository.ErrStaleFencingToken)
+	}
+
+	err := svc.WithTradeLock(context.Background(), "test-key", operation)
+	assert.Equal(t, int64(42), token)
+	appErr, ok := err.(*apperror.AppError)
+	assert.True(t, ok, "err should be AppError")
+	assert.Equal(t, errcode.ErrStaleFencingToken, appErr.Code)
+}
+
+func TestNewAccountLockService_ReloadsTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfig := mock.NewMockConfig(ctrl)
+	mockConfig.EXPECT().SetDefaultInt(gomock.Any(), gomock.Any()).AnyTimes()
+	lockDuration := mockConfig.EXPECT().GetInt("lock.LOCK_TTL").Return(5)
+	retryInterval := mockConfig.EXPECT().GetInt("lock.LOCK_RETRY_INTERVAL").Return(1)
+	var reload func(port.ConfigChange)
+	mockConfig.EXPECT().Subscribe(gomock.Any()).Do(func(fn func(port.ConfigChange)) { reload = fn })
+
+	svc := NewAccountLockService(mock.NewMockLocker(ctrl), mockConfig).(*accountLockApplicationService)
+	assert.Equal(t, 5*time.Second, time.Duration(svc.lockDuration.Load()))
+
+	mockConfig.EXPECT().GetInt("lock.LOCK_TTL").Return(8).After(lockDuration)
+	mockConfig.EXPECT().GetInt("lock.LOCK_RETRY_INTERVAL").Return(2).After(retryInterval)
+	reload(port.ConfigChange{Keys: []string{"lock.lock_ttl", "lock.lock_retry_interval"}})
+
+	assert.Equal(t, 8*time.Second, time.Duration(svc.lockDuration.Load()))
+	assert.Equal(t, 2*time.Millisecond, time.Duration(svc.retryInterval.Load()))
+}
+
+func TestWithAccountTradeLock_LocksEachAccountInOrder_GoMock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLock := mock.NewMockLock(ctrl)
+	mockLock.EXPECT().Release(gomock.Any()).Return(nil)
+	mockLock.EXPECT().Token().Return(int64(0))
+
+	mockLocker := mock.NewMockLocker(ctrl)
+	mockLocker.EXPECT().AcquireAll(gomock.Any(), []string{"account_lock:2", "account_lock:10"}, gomock.Any(), gomock.Any()).Return(mockLock, nil)
+
+	svc := &accountLockApplicationService{locker: mockLocker}
+	svc.setTTL(time.Second, 50*time.Millisecond)
+
+	err := svc.WithAccountTradeLock(context.Background(), 10, 2, func(ctx context.Context) error { return nil })
+	assert.NoError(t, err)
+}
+
+func TestGetLockKeys(t *testing.T) {
+	assert.Equal(t, []string{"account_lock:0", "account_lock:3", "account_lock:7"}, getLockKeys([]int64{7, 0, 3, 7}))
+}
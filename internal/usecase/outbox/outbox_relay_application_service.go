@@ -0,0 +1,71 @@
+package outbox
+
+import (
+	"context"
+	"points/internal/domain"
+	"points/internal/domain/port"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/internal/usecase/locking"
+	"sync/atomic"
+	"time"
+)
+
+const outboxRelayLockKey = "outbox_relay_lock"
+
+type OutboxRelayApplicationService interface {
+	RelayPendingEvents(ctx context.Context) (int, error)
+}
+
+type outboxRelayApplicationService struct {
+	unitOfWork  repository.UnitOfWork
+	lockService locking.AccountLockApplicationService
+	publisher   domain.EventPublisher
+	batchSize   int
+	now         func() time.Time
+}
+
+func NewOutboxRelayApplicationService(unitOfWork repository.UnitOfWork, locker domain.Locker, publisher domain.EventPublisher, config port.Config) OutboxRelayApplicationService {
+	return &outboxRelayApplicationService{
+		unitOfWork:  unitOfWork,
+		lockService: locking.NewAccountLockService(locker, config),
+		publisher:   publisher,
+		batchSize:   initBatchSize(config),
+		now:         valueobject.Now,
+	}
+}
+
+func (s *outboxRelayApplicationService) RelayPendingEvents(ctx context.Context) (int, error) {
+	var published atomic.Int64
+
+	err := s.lockService.WithTradeLock(ctx, outboxRelayLockKey, func(ctx context.Context) error {
+		eventRepo := s.unitOfWork.TransactionEventRepository()
+
+		events, err := eventRepo.GetUnpublishedTransactionEvents(ctx, s.batchSize)
+		if err != nil {
+			return apperror.Wrap(errcode.ErrGetEvent, "outbox relay - get unpublished events", err)
+		}
+
+		for _, evt := range events {
+			if err := s.publisher.Publish(ctx, evt); err != nil {
+				return apperror.Wrap(errcode.ErrPublishEvent, "outbox relay - publish event", err)
+			}
+
+			if err := eventRepo.MarkTransactionEventPublished(ctx, evt.ID, s.now()); err != nil {
+				return apperror.Wrap(errcode.ErrUpdateEvent, "outbox relay - mark event published", err)
+			}
+			published.Add(1)
+		}
+		return nil
+	})
+
+	return int(published.Load()), err
+}
+
+func initBatchSize(config port.Config) int {
+	config.SetDefaultInt("outbox.OUTBOX_BATCH_SIZE", 100)
+
+	return config.GetInt("outbox.OUTBOX_BATCH_SIZE")
+}
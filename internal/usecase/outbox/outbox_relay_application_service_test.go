@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"points/internal/domain/entity"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestOutboxRelay(t *testing.T) (
+	ctrl *gomock.Controller,
+	ctx context.Context,
+	mockEventRepo *mock.MockTransactionEventRepository,
+	mockPublisher *mock.MockEventPublisher,
+	svc *outboxRelayApplicationService,
+) {
+	ctrl = gomock.NewController(t)
+	ctx = context.Background()
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockEventRepo = mock.NewMockTransactionEventRepository(ctrl)
+	mockPublisher = mock.NewMockEventPublisher(ctrl)
+	mockLocker := mock.NewMockLocker(ctrl)
+	mockLock := mock.NewMockLock(ctrl)
+	mockConfig := mock.NewMockConfig(ctrl)
+
+	mockUow.EXPECT().TransactionEventRepository().Return(mockEventRepo).AnyTimes()
+	mockLocker.EXPECT().Acquire(ctx, outboxRelayLockKey, gomock.Any(), gomock.Any()).Return(mockLock, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
+
+	mockConfig.EXPECT().SetDefaultInt(gomock.Any(), gomock.Any()).Return().AnyTimes()
+	mockConfig.EXPECT().GetInt("lock.LOCK_TTL").Return(5).Times(1)
+	mockConfig.EXPECT().GetInt("lock.LOCK_RETRY_INTERVAL").Return(100).Times(1)
+	mockConfig.EXPECT().Subscribe(gomock.Any()).Times(1)
+	mockConfig.EXPECT().GetInt("outbox.OUTBOX_BATCH_SIZE").Return(10).Times(1)
+
+	svc = NewOutboxRelayApplicationService(mockUow, mockLocker, mockPublisher, mockConfig).(*outboxRelayApplicationService)
+	return
+}
+
+func TestRelayPendingEvents(t *testing.T) {
+	now := time.Now()
+	first := &entity.TransactionEvent{ID: 1, TransactionID: "tx-1", EventType: "pending"}
+	second := &entity.TransactionEvent{ID: 2, TransactionID: "tx-1", EventType: "confirmed"}
+
+	tests := []struct {
+		name              string
+		setupMocks        func(ctx context.Context, eventRepo *mock.MockTransactionEventRepository, publisher *mock.MockEventPublisher)
+		expectedPublished int
+		expectedCode      *errcode.ErrorCode
+	}{
+		{
+			name: "success - publishes and marks events in order",
+			setupMocks: func(ctx context.Context, eventRepo *mock.MockTransactionEventRepository, publisher *mock.MockEventPublisher) {
+				eventRepo.EXPECT().GetUnpublishedTransactionEvents(ctx, 10).Return([]*entity.TransactionEvent{first, second}, nil).Times(1)
+				gomock.InOrder(
+					publisher.EXPECT().Publish(ctx, first).Return(nil),
+					eventRepo.EXPECT().MarkTransactionEventPublished(ctx, int32(1), now).Return(nil),
+					publisher.EXPECT().Publish(ctx, second).Return(nil),
+					eventRepo.EXPECT().MarkTransactionEventPublished(ctx, int32(2), now).Return(nil),
+				)
+			},
+			expectedPublished: 2,
+		},
+		{
+			name: "success - nothing to publish",
+			setupMocks: func(ctx context.Context, eventRepo *mock.MockTransactionEventRepository, publisher *mock.MockEventPublisher) {
+				eventRepo.EXPECT().GetUnpublishedTransactionEvents(ctx, 10).Return(nil, nil).Times(1)
+			},
+			expectedPublished: 0,
+		},
+		{
+			name: "fail - query error",
+			setupMocks: func(ctx context.Context, eventRepo *mock.MockTransactionEventRepository, publisher *mock.MockEventPublisher) {
+				eventRepo.EXPECT().GetUnpublishedTransactionEvents(ctx, 10).Return(nil, errors.New("db error")).Times(1)
+			},
+			expectedPublished: 0,
+			expectedCode:      codePtr(errcode.ErrGetEvent),
+		},
+		{
+			name: "fail - publish error stops the batch to keep ordering",
+			setupMocks: func(ctx context.Context, eventRepo *mock.MockTransactionEventRepository, publisher *mock.MockEventPublisher) {
+				eventRepo.EXPECT().GetUnpublishedTransactionEvents(ctx, 10).Return([]*entity.TransactionEvent{first, second}, nil).Times(1)
+				publisher.EXPECT().Publish(ctx, first).Return(nil).Times(1)
+				eventRepo.EXPECT().MarkTransactionEventPublished(ctx, int32(1), now).Return(nil).Times(1)
+				publisher.EXPECT().Publish(ctx, second).Return(errors.New("broker down")).Times(1)
+			},
+			expectedPublished: 1,
+			expectedCode:      codePtr(errcode.ErrPublishEvent),
+		},
+		{
+			name: "fail - mark published error",
+			setupMocks: func(ctx context.Context, eventRepo *mock.MockTransactionEventRepository, publisher *mock.MockEventPublisher) {
+				eventRepo.EXPECT().GetUnpublishedTransactionEvents(ctx, 10).Return([]*entity.TransactionEvent{first}, nil).Times(1)
+				publisher.EXPECT().Publish(ctx, first).Return(nil).Times(1)
+				eventRepo.EXPECT().MarkTransactionEventPublished(ctx, int32(1), now).Return(errors.New("update error")).Times(1)
+			},
+			expectedPublished: 0,
+			expectedCode:      codePtr(errcode.ErrUpdateEvent),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx, eventRepo, publisher, svc := setupTestOutboxRelay(t)
+			defer ctrl.Finish()
+			svc.now = func() time.Time { return now }
+
+			tt.setupMocks(ctx, eventRepo, publisher)
+			published, err := svc.RelayPendingEvents(ctx)
+			assert.Equal(t, tt.expectedPublished, published)
+			if tt.expectedCode == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var appErr *apperror.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, *tt.expectedCode, appErr.Code)
+		})
+	}
+}
+
+func codePtr(code errcode.ErrorCode) *errcode.ErrorCode {
+	return &code
+}
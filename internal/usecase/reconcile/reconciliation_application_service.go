@@ -0,0 +1,153 @@
+package reconcile
+
+import (
+	"context"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ReconciliationApplicationService interface {
+	Reconcile(ctx context.Context) (*Report, error)
+}
+
+type reconciliationApplicationService struct {
+	unitOfWork repository.UnitOfWork
+	now        func() time.Time
+}
+
+func NewReconciliationApplicationService(unitOfWork repository.UnitOfWork) ReconciliationApplicationService {
+	return &reconciliationApplicationService{
+		unitOfWork: unitOfWork,
+		now:        valueobject.Now,
+	}
+}
+
+// Reconcile reads everything it compares from one snapshot, so trades that
+// commit while it runs cannot show up as discrepancies.
+func (s *reconciliationApplicationService) Reconcile(ctx context.Context) (*Report, error) {
+	var report *Report
+	err := s.unitOfWork.SnapshotTransaction(ctx, func(u repository.UnitOfWork) error {
+		var err error
+		report, err = s.reconcile(ctx, u)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *reconciliationApplicationService) reconcile(ctx context.Context, unitOfWork repository.UnitOfWork) (*Report, error) {
+	report := &Report{
+		GeneratedAt:   s.now(),
+		Consistent:    true,
+		Discrepancies: []Discrepancy{},
+	}
+
+	accounts, err := unitOfWork.AccountRepository().ListAccounts(ctx)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetAccount, "reconcile - list accounts", err)
+	}
+	report.AccountsChecked = len(accounts)
+
+	pending, err := unitOfWork.TradeRecordsRepository().SumPendingAmountsBySender(ctx)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, "reconcile - sum pending amounts", err)
+	}
+
+	// pending amounts per sender must match the reserved balance
+	totals := make(map[valueobject.AssetCode]decimal.Decimal)
+	checked := make(map[repository.AccountAsset]struct{}, len(accounts))
+	for _, account := range accounts {
+		key := repository.AccountAsset{AccountID: account.UserID, AssetCode: account.Asset()}
+		checked[key] = struct{}{}
+		totals[key.AssetCode] = totals[key.AssetCode].Add(account.TotalBalance().Value())
+
+		expected, ok := pending[key]
+		if !ok {
+			expected = valueobject.ZeroOf(key.AssetCode)
+		}
+		if !expected.Equals(account.ReservedBalance) {
+			report.add(Discrepancy{
+				Check:     CheckPendingReserved,
+				AccountID: accountID(account.UserID),
+				AssetCode: key.AssetCode.String(),
+				Expected:  expected.String(),
+				Actual:    account.ReservedBalance.String(),
+				Message:   "reserved balance does not match the sum of pending transfers",
+			})
+		}
+	}
+
+	senders := make([]repository.AccountAsset, 0, len(pending))
+	for sender := range pending {
+		if _, ok := checked[sender]; !ok {
+			senders = append(senders, sender)
+		}
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		if senders[i].AccountID != senders[j].AccountID {
+			return senders[i].AccountID < senders[j].AccountID
+		}
+		return senders[i].AssetCode < senders[j].AssetCode
+	})
+	for _, sender := range senders {
+		report.add(Discrepancy{
+			Check:     CheckPendingReserved,
+			AccountID: accountID(sender.AccountID),
+			AssetCode: sender.AssetCode.String(),
+			Expected:  pending[sender].String(),
+			Actual:    valueobject.Zero.String(),
+			Message:   "pending transfers reference a sender without an account",
+		})
+	}
+
+	// every confirmed transfer must have emitted a confirmed event
+	missing, err := unitOfWork.TradeRecordsRepository().GetConfirmedTradeRecordsWithoutEvent(ctx)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, "reconcile - get confirmed transactions without event", err)
+	}
+	for _, record := range missing {
+		report.add(Discrepancy{
+			Check:         CheckConfirmedEvent,
+			AccountID:     accountID(record.FromAccountID),
+			TransactionID: record.TransactionID,
+			Message:       "confirmed transfer has no confirmed event",
+		})
+	}
+
+	// points held by accounts must equal the points ever issued, per asset
+	assets := make([]valueobject.AssetCode, 0, len(totals))
+	for asset := range totals {
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i] < assets[j] })
+	for _, asset := range assets {
+		issuance, err := unitOfWork.LedgerRepository().GetLedgerBalance(ctx, valueobject.SystemAccountID, asset, valueobject.LedgerBucketIssuance)
+		if err != nil {
+			return nil, apperror.Wrap(errcode.ErrGetLedgerEntry, "reconcile - get issuance balance", err)
+		}
+		issued := issuance.Value().Neg()
+		if !issued.Equal(totals[asset]) {
+			report.add(Discrepancy{
+				Check:     CheckPointsConserved,
+				AssetCode: asset.String(),
+				Expected:  issued.String(),
+				Actual:    totals[asset].String(),
+				Message:   "total account balances do not match issued points",
+			})
+		}
+	}
+
+	return report, nil
+}
+
+func accountID(id int64) *int64 {
+	return &id
+}
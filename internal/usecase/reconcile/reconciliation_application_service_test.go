@@ -0,0 +1,143 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"points/internal/domain/entity"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func money(v int64) valueobject.Money {
+	return valueobject.NewMoneyFromDecimal(decimal.NewFromInt(v))
+}
+
+func pendingKey(accountID int64) repository.AccountAsset {
+	return repository.AccountAsset{AccountID: accountID, AssetCode: valueobject.DefaultAssetCode}
+}
+
+func setupTestReconciliationService(t *testing.T) (
+	ctrl *gomock.Controller,
+	ctx context.Context,
+	mockAccRepo *mock.MockAccountRepository,
+	mockTxRepo *mock.MockTradeRecordsRepository,
+	mockLedgerRepo *mock.MockLedgerRepository,
+	svc ReconciliationApplicationService,
+) {
+	ctrl = gomock.NewController(t)
+	ctx = context.Background()
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockAccRepo = mock.NewMockAccountRepository(ctrl)
+	mockTxRepo = mock.NewMockTradeRecordsRepository(ctrl)
+	mockLedgerRepo = mock.NewMockLedgerRepository(ctrl)
+	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
+	mockUow.EXPECT().TradeRecordsRepository().Return(mockTxRepo).AnyTimes()
+	mockUow.EXPECT().LedgerRepository().Return(mockLedgerRepo).AnyTimes()
+	mockUow.EXPECT().SnapshotTransaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(repository.UnitOfWork) error) error {
+			return fn(mockUow)
+		}).Times(1)
+
+	svc = NewReconciliationApplicationService(mockUow)
+	return
+}
+
+func TestReconcile_Consistent(t *testing.T) {
+	ctrl, ctx, mockAccRepo, mockTxRepo, mockLedgerRepo, svc := setupTestReconciliationService(t)
+	defer ctrl.Finish()
+
+	mockAccRepo.EXPECT().ListAccounts(ctx).Return([]*entity.Account{
+		{UserID: 1, AvailableBalance: money(70), ReservedBalance: money(30)},
+		{UserID: 2, AvailableBalance: money(50), ReservedBalance: valueobject.Zero},
+	}, nil).Times(1)
+	mockTxRepo.EXPECT().SumPendingAmountsBySender(ctx).Return(map[repository.AccountAsset]valueobject.Money{pendingKey(1): money(30)}, nil).Times(1)
+	mockTxRepo.EXPECT().GetConfirmedTradeRecordsWithoutEvent(ctx).Return(nil, nil).Times(1)
+	mockLedgerRepo.EXPECT().GetLedgerBalance(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode, valueobject.LedgerBucketIssuance).Return(money(-150), nil).Times(1)
+
+	report, err := svc.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.True(t, report.Consistent)
+	assert.Equal(t, 2, report.AccountsChecked)
+	assert.Empty(t, report.Discrepancies)
+}
+
+func TestReconcile_ReportsEveryDiscrepancy(t *testing.T) {
+	ctrl, ctx, mockAccRepo, mockTxRepo, mockLedgerRepo, svc := setupTestReconciliationService(t)
+	defer ctrl.Finish()
+
+	mockAccRepo.EXPECT().ListAccounts(ctx).Return([]*entity.Account{
+		{UserID: 1, AvailableBalance: money(70), ReservedBalance: money(40)},
+		{UserID: 2, AvailableBalance: money(50), ReservedBalance: valueobject.Zero},
+	}, nil).Times(1)
+	mockTxRepo.EXPECT().SumPendingAmountsBySender(ctx).Return(map[repository.AccountAsset]valueobject.Money{
+		pendingKey(1): money(30),
+		pendingKey(9): money(5),
+	}, nil).Times(1)
+	mockTxRepo.EXPECT().GetConfirmedTradeRecordsWithoutEvent(ctx).Return([]*entity.TradeRecords{
+		{TransactionID: "tx-1", FromAccountID: 1, ToAccountID: 2},
+	}, nil).Times(1)
+	mockLedgerRepo.EXPECT().GetLedgerBalance(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode, valueobject.LedgerBucketIssuance).Return(money(-150), nil).Times(1)
+
+	report, err := svc.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.False(t, report.Consistent)
+	if assert.Len(t, report.Discrepancies, 4) {
+		assert.Equal(t, CheckPendingReserved, report.Discrepancies[0].Check)
+		assert.Equal(t, int64(1), *report.Discrepancies[0].AccountID)
+		assert.Equal(t, "30", report.Discrepancies[0].Expected)
+		assert.Equal(t, "40", report.Discrepancies[0].Actual)
+		assert.Equal(t, CheckPendingReserved, report.Discrepancies[1].Check)
+		assert.Equal(t, int64(9), *report.Discrepancies[1].AccountID)
+		assert.Equal(t, CheckConfirmedEvent, report.Discrepancies[2].Check)
+		assert.Equal(t, "tx-1", report.Discrepancies[2].TransactionID)
+		assert.Equal(t, CheckPointsConserved, report.Discrepancies[3].Check)
+		assert.Equal(t, "150", report.Discrepancies[3].Expected)
+		assert.Equal(t, "160", report.Discrepancies[3].Actual)
+	}
+}
+
+func TestReconcile_ChecksEachAssetSeparately(t *testing.T) {
+	ctrl, ctx, mockAccRepo, mockTxRepo, mockLedgerRepo, svc := setupTestReconciliationService(t)
+	defer ctrl.Finish()
+
+	cashback := valueobject.AssetCode("CASHBACK")
+	mockAccRepo.EXPECT().ListAccounts(ctx).Return([]*entity.Account{
+		{UserID: 1, AssetCode: valueobject.DefaultAssetCode, AvailableBalance: money(100), ReservedBalance: valueobject.Zero},
+		{UserID: 1, AssetCode: cashback, AvailableBalance: money(20).WithAsset(cashback), ReservedBalance: money(5).WithAsset(cashback)},
+	}, nil).Times(1)
+	mockTxRepo.EXPECT().SumPendingAmountsBySender(ctx).Return(map[repository.AccountAsset]valueobject.Money{
+		{AccountID: 1, AssetCode: cashback}: money(5).WithAsset(cashback),
+	}, nil).Times(1)
+	mockTxRepo.EXPECT().GetConfirmedTradeRecordsWithoutEvent(ctx).Return(nil, nil).Times(1)
+	mockLedgerRepo.EXPECT().GetLedgerBalance(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode, valueobject.LedgerBucketIssuance).Return(money(-100), nil).Times(1)
+	mockLedgerRepo.EXPECT().GetLedgerBalance(ctx, valueobject.SystemAccountID, cashback, valueobject.LedgerBucketIssuance).Return(money(-20).WithAsset(cashback), nil).Times(1)
+
+	report, err := svc.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.False(t, report.Consistent)
+	if assert.Len(t, report.Discrepancies, 1) {
+		assert.Equal(t, CheckPointsConserved, report.Discrepancies[0].Check)
+		assert.Equal(t, "CASHBACK", report.Discrepancies[0].AssetCode)
+		assert.Equal(t, "20", report.Discrepancies[0].Expected)
+		assert.Equal(t, "25", report.Discrepancies[0].Actual)
+	}
+}
+
+func TestReconcile_ListAccountsError(t *testing.T) {
+	ctrl, ctx, mockAccRepo, _, _, svc := setupTestReconciliationService(t)
+	defer ctrl.Finish()
+
+	mockAccRepo.EXPECT().ListAccounts(ctx).Return(nil, errors.New("db error")).Times(1)
+
+	report, err := svc.Reconcile(ctx)
+	assert.Nil(t, report)
+	assert.ErrorContains(t, err, "db error")
+}
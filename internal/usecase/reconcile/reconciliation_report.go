@@ -0,0 +1,31 @@
+package reconcile
+
+import "time"
+
+const (
+	CheckPendingReserved = "pending_reserved_mismatch"
+	CheckConfirmedEvent  = "missing_confirmed_event"
+	CheckPointsConserved = "points_not_conserved"
+)
+
+type Discrepancy struct {
+	Check         string `json:"check"`
+	AccountID     *int64 `json:"account_id,omitempty"`
+	AssetCode     string `json:"asset_code,omitempty"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	Expected      string `json:"expected,omitempty"`
+	Actual        string `json:"actual,omitempty"`
+	Message       string `json:"message"`
+}
+
+type Report struct {
+	GeneratedAt     time.Time     `json:"generated_at"`
+	AccountsChecked int           `json:"accounts_checked"`
+	Consistent      bool          `json:"consistent"`
+	Discrepancies   []Discrepancy `json:"discrepancies"`
+}
+
+func (r *Report) add(d Discrepancy) {
+	r.Discrepancies = append(r.Discrepancies, d)
+	r.Consistent = false
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"points/internal/domain"
+	"points/internal/domain/entity"
+	"points/internal/domain/query"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	defaultTradePageSize = 20
+	maxTradePageSize     = 100
+)
+
+type tradeQueryUsecase struct {
+	unitOfWork repository.UnitOfWork
+}
+
+func NewTradeQueryUsecase(unitOfWork repository.UnitOfWork) domain.TradeQueryUsecase {
+	return &tradeQueryUsecase{
+		unitOfWork: unitOfWork,
+	}
+}
+
+func (s *tradeQueryUsecase) GetTrade(ctx context.Context, req *query.GetTradeQuery) (*query.TradeDetail, error) {
+	if err := authorizeCaller(ctx, req.From, valueobject.PermissionAccountReadAny); err != nil {
+		return nil, err
+	}
+
+	trade, err := s.unitOfWork.TradeRecordsRepository().GetTradeRecord(ctx, req.Nonce, req.From, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && trade == nil) {
+		return nil, apperror.Wrap(errcode.ErrTransactionNotFound, "get trade", err)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, "get trade", err)
+	}
+
+	events, err := s.unitOfWork.TransactionEventRepository().GetTransactionEvents(ctx, trade.TransactionID)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetEvent, "get trade events", err)
+	}
+
+	return &query.TradeDetail{
+		Trade:  trade,
+		Events: events,
+	}, nil
+}
+
+func (s *tradeQueryUsecase) ListAccountTrades(ctx context.Context, req *query.ListAccountTradesQuery) (*query.TradePage, error) {
+	if err := authorizeCaller(ctx, req.AccountID, valueobject.PermissionAccountReadAny); err != nil {
+		return nil, err
+	}
+
+	after, err := decodeTradeCursor(req.Cursor)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrInvalidRequest, "list trades - invalid cursor", err)
+	}
+
+	accounts, err := s.unitOfWork.AccountRepository().ListUserAccounts(ctx, req.AccountID)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetAccount, "list trades - get account", err)
+	}
+	if !holdsAsset(accounts, req.AssetCode) {
+		return nil, apperror.Wrap(errcode.ErrAccountNotFound, "list trades - get account", nil)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTradePageSize
+	}
+	if limit > maxTradePageSize {
+		limit = maxTradePageSize
+	}
+
+	trades, err := s.unitOfWork.TradeRecordsRepository().ListTradeRecords(ctx, repository.TradeRecordFilter{
+		AccountID: req.AccountID,
+		AssetCode: req.AssetCode,
+		Status:    req.Status,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		After:     after,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, "list trades", err)
+	}
+
+	page := &query.TradePage{Trades: trades}
+	if len(trades) > limit {
+		page.Trades = trades[:limit]
+		last := page.Trades[limit-1]
+		page.NextCursor = encodeTradeCursor(&repository.TradeRecordCursor{
+			CreatedAt:     last.CreatedAt,
+			FromAccountID: last.FromAccountID,
+			Nonce:         last.Nonce,
+		})
+	}
+
+	return page, nil
+}
+
+func encodeTradeCursor(cursor *repository.TradeRecordCursor) string {
+	raw := fmt.Sprintf("%d:%d:%d", cursor.CreatedAt.UnixNano(), cursor.FromAccountID, cursor.Nonce)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeTradeCursor(encoded string) (*repository.TradeRecordCursor, error) {
+	if encoded == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdAt, from, nonce int64
+	if _, err := fmt.Sscanf(string(raw), "%d:%d:%d", &createdAt, &from, &nonce); err != nil {
+		return nil, err
+	}
+
+	return &repository.TradeRecordCursor{
+		CreatedAt:     time.Unix(0, createdAt).UTC(),
+		FromAccountID: from,
+		Nonce:         nonce,
+	}, nil
+}
+
+// holdsAsset reports whether the user has a balance in asset, or in any asset when asset is nil.
+func holdsAsset(accounts []*entity.Account, asset *valueobject.AssetCode) bool {
+	for _, account := range accounts {
+		if asset == nil || account.Asset() == *asset {
+			return true
+		}
+	}
+	return false
+}
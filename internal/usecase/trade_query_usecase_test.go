@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"points/internal/domain/entity"
+	"points/internal/domain/query"
+	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
+	"points/test/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func setupTestTradeQueryUsecase(t *testing.T) (
+	ctrl *gomock.Controller,
+	ctx context.Context,
+	mockAccRepo *mock.MockAccountRepository,
+	mockTxRepo *mock.MockTradeRecordsRepository,
+	mockEventRepo *mock.MockTransactionEventRepository,
+	svc *tradeQueryUsecase,
+) {
+	ctrl = gomock.NewController(t)
+	ctx = valueobject.WithPrincipal(context.Background(), &valueobject.Principal{Subject: "1", Kind: valueobject.PrincipalKindUser, AccountID: 1})
+
+	mockUow := mock.NewMockUnitOfWork(ctrl)
+	mockAccRepo = mock.NewMockAccountRepository(ctrl)
+	mockTxRepo = mock.NewMockTradeRecordsRepository(ctrl)
+	mockEventRepo = mock.NewMockTransactionEventRepository(ctrl)
+	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
+	mockUow.EXPECT().TradeRecordsRepository().Return(mockTxRepo).AnyTimes()
+	mockUow.EXPECT().TransactionEventRepository().Return(mockEventRepo).AnyTimes()
+
+	svc = NewTradeQueryUsecase(mockUow).(*tradeQueryUsecase)
+	return
+}
+
+func dummyTrade(nonce int64, createdAt time.Time) *entity.TradeRecords {
+	return &entity.TradeRecords{
+		TransactionID: fmt.Sprintf("tx-%d", nonce),
+		Nonce:         nonce,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(10)),
+		Status:        int32(valueobject.TccConfirmed),
+		CreatedAt:     createdAt,
+	}
+}
+
+func TestGetTrade_Success(t *testing.T) {
+	ctrl, ctx, _, mockTxRepo, mockEventRepo, svc := setupTestTradeQueryUsecase(t)
+	defer ctrl.Finish()
+
+	trade := dummyTrade(1, time.Now())
+	events := []*entity.TransactionEvent{
+		{ID: 1, TransactionID: trade.TransactionID, EventType: valueobject.TccPending.String()},
+		{ID: 2, TransactionID: trade.TransactionID, EventType: valueobject.TccConfirmed.String()},
+	}
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, int64(1), int64(1), nil).Return(trade, nil).Times(1)
+	mockEventRepo.EXPECT().GetTransactionEvents(ctx, trade.TransactionID).Return(events, nil).Times(1)
+
+	detail, err := svc.GetTrade(ctx, &query.GetTradeQuery{From: 1, Nonce: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, trade, detail.Trade)
+	assert.Equal(t, events, detail.Events)
+}
+
+func TestGetTrade_NotFound(t *testing.T) {
+	ctrl, ctx, _, mockTxRepo, _, svc := setupTestTradeQueryUsecase(t)
+	defer ctrl.Finish()
+
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, int64(1), int64(1), nil).Return(nil, gorm.ErrRecordNotFound).Times(1)
+
+	_, err := svc.GetTrade(ctx, &query.GetTradeQuery{From: 1, Nonce: 1})
+	var appErr *apperror.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, errcode.ErrTransactionNotFound, appErr.Code)
+}
+
+func TestGetTrade_SentByAnotherAccount(t *testing.T) {
+	ctrl, ctx, _, _, _, svc := setupTestTradeQueryUsecase(t)
+	defer ctrl.Finish()
+
+	_, err := svc.GetTrade(ctx, &query.GetTradeQuery{From: 2, Nonce: 1})
+	var appErr *apperror.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, errcode.ErrForbidden, appErr.Code)
+}
+
+func TestListAccountTrades_Pagination(t *testing.T) {
+	ctrl, ctx, mockAccRepo, mockTxRepo, _, svc := setupTestTradeQueryUsecase(t)
+	defer ctrl.Finish()
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	trades := []*entity.TradeRecords{
+		dummyTrade(3, base.Add(3*time.Minute)),
+		dummyTrade(2, base.Add(2*time.Minute)),
+		dummyTrade(1, base.Add(1*time.Minute)),
+	}
+	status := valueobject.TccConfirmed
+
+	mockAccRepo.EXPECT().ListUserAccounts(ctx, int64(1)).Return([]*entity.Account{dummyAccount(1, decimal.Zero, decimal.Zero)}, nil).Times(2)
+	mockTxRepo.EXPECT().ListTradeRecords(ctx, repository.TradeRecordFilter{
+		AccountID: 1,
+		Status:    &status,
+		Limit:     3,
+	}).Return(trades, nil).Times(1)
+
+	page, err := svc.ListAccountTrades(ctx, &query.ListAccountTradesQuery{AccountID: 1, Status: &status, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Trades, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	mockTxRepo.EXPECT().ListTradeRecords(ctx, repository.TradeRecordFilter{
+		AccountID: 1,
+		Status:    &status,
+		After: &repository.TradeRecordCursor{
+			CreatedAt:     trades[1].CreatedAt,
+			FromAccountID: trades[1].FromAccountID,
+			Nonce:         trades[1].Nonce,
+		},
+		Limit: 3,
+	}).Return(trades[2:], nil).Times(1)
+
+	next, err := svc.ListAccountTrades(ctx, &query.ListAccountTradesQuery{AccountID: 1, Status: &status, Cursor: page.NextCursor, Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, next.Trades, 1)
+	assert.Empty(t, next.NextCursor)
+}
+
+func TestListAccountTrades_Failures(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *query.ListAccountTradesQuery
+		setupMocks   func(ctx context.Context, accRepo *mock.MockAccountRepository, txRepo *mock.MockTradeRecordsRepository)
+		expectedCode errcode.ErrorCode
+	}{
+		{
+			name: "invalid cursor",
+			req:  &query.ListAccountTradesQuery{AccountID: 1, Cursor: "not-a-cursor"},
+			setupMocks: func(ctx context.Context, accRepo *mock.MockAccountRepository, txRepo *mock.MockTradeRecordsRepository) {
+			},
+			expectedCode: errcode.ErrInvalidRequest,
+		},
+		{
+			name: "account of another user",
+			req:  &query.ListAccountTradesQuery{AccountID: 2},
+			setupMocks: func(ctx context.Context, accRepo *mock.MockAccountRepository, txRepo *mock.MockTradeRecordsRepository) {
+			},
+			expectedCode: errcode.ErrForbidden,
+		},
+		{
+			name: "account not found",
+			req:  &query.ListAccountTradesQuery{AccountID: 1},
+			setupMocks: func(ctx context.Context, accRepo *mock.MockAccountRepository, txRepo *mock.MockTradeRecordsRepository) {
+				accRepo.EXPECT().ListUserAccounts(ctx, int64(1)).Return([]*entity.Account{}, nil).Times(1)
+			},
+			expectedCode: errcode.ErrAccountNotFound,
+		},
+		{
+			name: "no balance in requested asset",
+			req:  &query.ListAccountTradesQuery{AccountID: 1, AssetCode: assetPtr("CASHBACK")},
+			setupMocks: func(ctx context.Context, accRepo *mock.MockAccountRepository, txRepo *mock.MockTradeRecordsRepository) {
+				accRepo.EXPECT().ListUserAccounts(ctx, int64(1)).Return([]*entity.Account{dummyAccount(1, decimal.Zero, decimal.Zero)}, nil).Times(1)
+			},
+			expectedCode: errcode.ErrAccountNotFound,
+		},
+		{
+			name: "list error",
+			req:  &query.ListAccountTradesQuery{AccountID: 1},
+			setupMocks: func(ctx context.Context, accRepo *mock.MockAccountRepository, txRepo *mock.MockTradeRecordsRepository) {
+				accRepo.EXPECT().ListUserAccounts(ctx, int64(1)).Return([]*entity.Account{dummyAccount(1, decimal.Zero, decimal.Zero)}, nil).Times(1)
+				txRepo.EXPECT().ListTradeRecords(ctx, gomock.Any()).Return(nil, errors.New("db error")).Times(1)
+			},
+			expectedCode: errcode.ErrGetTransaction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl, ctx, mockAccRepo, mockTxRepo, _, svc := setupTestTradeQueryUsecase(t)
+			defer ctrl.Finish()
+
+			tt.setupMocks(ctx, mockAccRepo, mockTxRepo)
+			_, err := svc.ListAccountTrades(ctx, tt.req)
+			var appErr *apperror.AppError
+			assert.ErrorAs(t, err, &appErr)
+			assert.Equal(t, tt.expectedCode, appErr.Code)
+		})
+	}
+}
+
+func assetPtr(code string) *valueobject.AssetCode {
+	asset := valueobject.AssetCode(code)
+	return &asset
+}
@@ -2,18 +2,27 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"points/internal/domain"
 	"points/internal/domain/command"
+	"points/internal/domain/entity"
 	"points/internal/domain/port"
 	"points/internal/domain/repository"
+	"points/internal/domain/valueobject"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
 	"points/internal/usecase/locking"
 	"points/internal/usecase/transaction"
+	"time"
 )
 
 type tradeUsecase struct {
 	unitOfWork         repository.UnitOfWork
 	lockService        locking.AccountLockApplicationService
 	transactionService transaction.TransactionApplicationService
+	pendingTimeout     time.Duration
+	maxPendingTimeout  time.Duration
 }
 
 func NewTradeUsecase(unitOfWork repository.UnitOfWork, locker domain.Locker, config port.Config) domain.TradeUsecase {
@@ -21,57 +30,138 @@ func NewTradeUsecase(unitOfWork repository.UnitOfWork, locker domain.Locker, con
 		unitOfWork:         unitOfWork,
 		lockService:        locking.NewAccountLockService(locker, config),
 		transactionService: transaction.NewTransactionApplicationService(),
+		pendingTimeout:     initPendingTimeout(config),
+		maxPendingTimeout:  initMaxPendingTimeout(config),
 	}
 }
 
-func (s *tradeUsecase) Transfer(ctx context.Context, req *command.TransferCommand) error {
-	return s.lockService.WithAccountTradeLock(ctx, req.From, req.To, func() error {
+func (s *tradeUsecase) Transfer(ctx context.Context, req *command.TransferCommand) (*entity.TradeRecords, error) {
+	if err := authorizeCaller(ctx, req.From); err != nil {
+		return nil, err
+	}
+	asset, err := valueobject.NewAssetCode(req.AssetCode.String())
+	if err != nil {
+		return nil, err
+	}
+	amount := req.Amount.WithAsset(asset)
+	pendingTimeout, err := s.getPendingTimeout(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var trans *entity.TradeRecords
+	expiredAt := valueobject.Now().Add(pendingTimeout)
+	err = s.lockService.WithAccountTradeLock(ctx, req.From, req.To, func(ctx context.Context) error {
 		return s.unitOfWork.Transaction(ctx, func(u repository.UnitOfWork) error {
-			if err := s.transactionService.TransferTransaction(ctx, u, req.Nonce, req.From, req.To, req.Amount); err != nil {
+			replayed, err := s.transactionService.ReplayTransfer(ctx, u, req.Nonce, req.From, req.To, amount)
+			if err != nil {
 				return err
 			}
-
-			if !req.AutoConfirm {
+			if replayed != nil {
+				trans = replayed
 				return nil
 			}
 
-			if err := s.confirm(ctx, &req.BaseCommand, u); err != nil {
+			trans, err = s.transactionService.TransferTransaction(ctx, u, req.Nonce, req.From, req.To, amount, expiredAt)
+			if err != nil {
 				return err
 			}
 
-			return nil
+			if !req.AutoConfirm {
+				return nil
+			}
+
+			trans, err = s.confirm(ctx, &req.BaseCommand, u)
+			return err
 		})
 	})
+	if err != nil {
+		return nil, err
+	}
+	return trans, nil
 }
 
-func (s *tradeUsecase) ManualConfirm(ctx context.Context, req *command.ConfirmCommand) error {
-	return s.lockService.WithAccountTradeLock(ctx, req.From, req.To, func() error {
+func (s *tradeUsecase) ManualConfirm(ctx context.Context, req *command.ConfirmCommand) (*entity.TradeRecords, error) {
+	if err := authorizeCaller(ctx, req.From); err != nil {
+		return nil, err
+	}
+	var trans *entity.TradeRecords
+	err := s.lockService.WithAccountTradeLock(ctx, req.From, req.To, func(ctx context.Context) error {
 		return s.unitOfWork.Transaction(ctx, func(u repository.UnitOfWork) error {
-			if err := s.confirm(ctx, &req.BaseCommand, u); err != nil {
-				return err
-			}
-
-			return nil
+			var err error
+			trans, err = s.confirm(ctx, &req.BaseCommand, u)
+			return err
 		})
 	})
+	if err != nil {
+		return nil, err
+	}
+	return trans, nil
 }
 
-func (s *tradeUsecase) Cancel(ctx context.Context, req *command.CancelCommand) error {
-	return s.lockService.WithAccountTradeLock(ctx, req.From, req.To, func() error {
+func (s *tradeUsecase) Cancel(ctx context.Context, req *command.CancelCommand) (*entity.TradeRecords, error) {
+	if err := authorizeCaller(ctx, req.From, valueobject.PermissionTradeCancelAny); err != nil {
+		return nil, err
+	}
+	var trans *entity.TradeRecords
+	err := s.lockService.WithAccountTradeLock(ctx, req.From, req.To, func(ctx context.Context) error {
 		return s.unitOfWork.Transaction(ctx, func(u repository.UnitOfWork) error {
-			if err := s.transactionService.CancelTransaction(ctx, u, req.Nonce, req.From, req.To); err != nil {
-				return err
-			}
-
-			return nil
+			var err error
+			trans, err = s.transactionService.CancelTransaction(ctx, u, req.Nonce, req.From, req.To, valueobject.CancelReasonClient)
+			return err
 		})
 	})
+	if err != nil {
+		return nil, err
+	}
+	return trans, nil
 }
 
-func (s *tradeUsecase) confirm(ctx context.Context, rq *command.BaseCommand, unitOfWork repository.UnitOfWork) error {
-	err := s.transactionService.ConfirmTransaction(ctx, unitOfWork, rq.Nonce, rq.From, rq.To)
-	if err != nil {
-		return err
+// authorizeCaller rejects callers that may not act for the account from,
+// unless they hold one of the overriding permissions.
+func authorizeCaller(ctx context.Context, from int64, overrides ...valueobject.Permission) error {
+	principal, ok := valueobject.PrincipalFromContext(ctx)
+	if !ok {
+		return apperror.Wrap(errcode.ErrUnauthorized, "authorize caller", errors.New("missing principal"))
+	}
+	if principal.CanActFor(from) {
+		return nil
+	}
+	for _, permission := range overrides {
+		if principal.HasPermission(permission) {
+			return nil
+		}
 	}
-	return nil
+	return apperror.Wrap(errcode.ErrForbidden, "authorize caller", fmt.Errorf("%s may not act for account %d", principal.Subject, from))
+}
+
+func (s *tradeUsecase) confirm(ctx context.Context, rq *command.BaseCommand, unitOfWork repository.UnitOfWork) (*entity.TradeRecords, error) {
+	return s.transactionService.ConfirmTransaction(ctx, unitOfWork, rq.Nonce, rq.From, rq.To)
+}
+
+// getPendingTimeout caps a requested timeout at maxPendingTimeout, so a
+// client cannot keep the sender's funds reserved indefinitely.
+func (s *tradeUsecase) getPendingTimeout(req *command.TransferCommand) (time.Duration, error) {
+	if req.TimeoutSeconds <= 0 {
+		return s.pendingTimeout, nil
+	}
+	maxSeconds := int64(s.maxPendingTimeout / time.Second)
+	if req.TimeoutSeconds > maxSeconds {
+		return 0, apperror.Wrap(errcode.ErrInvalidRequest, "transfer phase - timeout validation", fmt.Errorf("timeout_seconds must not exceed %d", maxSeconds))
+	}
+	return time.Duration(req.TimeoutSeconds) * time.Second, nil
+}
+
+func initPendingTimeout(config port.Config) time.Duration {
+	config.SetDefaultInt("tcc.TCC_PENDING_TIMEOUT", 300)
+
+	pendingTimeout := config.GetInt("tcc.TCC_PENDING_TIMEOUT")
+
+	return time.Duration(pendingTimeout) * time.Second
+}
+
+func initMaxPendingTimeout(config port.Config) time.Duration {
+	config.SetDefaultInt("tcc.TCC_MAX_PENDING_TIMEOUT", 86400)
+
+	return time.Duration(config.GetInt("tcc.TCC_MAX_PENDING_TIMEOUT")) * time.Second
 }
@@ -20,6 +20,8 @@ import (
 	"points/internal/domain/repository"
 	"points/internal/domain/valueobject"
 	"points/internal/infrastructure/distributedlock"
+	"points/internal/shared/apperror"
+	"points/internal/shared/errcode"
 	"points/test/mock"
 )
 
@@ -43,7 +45,7 @@ func setupTestTradeUsecase(t *testing.T) (
 	tradeSvc domain.TradeUsecase,
 ) {
 	ctrl = gomock.NewController(t)
-	ctx = context.Background()
+	ctx = valueobject.WithPrincipal(context.Background(), &valueobject.Principal{Subject: "test-service", Kind: valueobject.PrincipalKindService})
 
 	mockUow = mock.NewMockUnitOfWork(ctrl)
 	mockAccRepo = mock.NewMockAccountRepository(ctrl)
@@ -61,25 +63,50 @@ func setupTestTradeUsecase(t *testing.T) (
 	mockUow.EXPECT().AccountRepository().Return(mockAccRepo).AnyTimes()
 	mockUow.EXPECT().TradeRecordsRepository().Return(mockTxRepo).AnyTimes()
 	mockUow.EXPECT().TransactionEventRepository().Return(mockEventRepo).AnyTimes()
-
-	mockConfig.EXPECT().SetDefaultInt("LOCK_DURATION", 5).Return().Times(1)
-	mockConfig.EXPECT().SetDefaultInt("RETRY_INTERVAL", 100).Return().Times(1)
-	mockConfig.EXPECT().GetInt("LOCK_DURATION").Return(5).Times(1)
-	mockConfig.EXPECT().GetInt("RETRY_INTERVAL").Return(100).Times(1)
+	mockLedgerRepo := mock.NewMockLedgerRepository(ctrl)
+	mockLedgerRepo.EXPECT().CreateLedgerEntries(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockUow.EXPECT().LedgerRepository().Return(mockLedgerRepo).AnyTimes()
+	stubPointLots(ctrl, mockUow)
+
+	mockConfig.EXPECT().SetDefaultInt("lock.LOCK_TTL", 5).Return().Times(1)
+	mockConfig.EXPECT().SetDefaultInt("lock.LOCK_RETRY_INTERVAL", 100).Return().Times(1)
+	mockConfig.EXPECT().GetInt("lock.LOCK_TTL").Return(5).Times(1)
+	mockConfig.EXPECT().GetInt("lock.LOCK_RETRY_INTERVAL").Return(100).Times(1)
+	mockConfig.EXPECT().Subscribe(gomock.Any()).Times(1)
+	mockConfig.EXPECT().SetDefaultInt("tcc.TCC_PENDING_TIMEOUT", 300).Return().Times(1)
+	mockConfig.EXPECT().GetInt("tcc.TCC_PENDING_TIMEOUT").Return(300).Times(1)
+	mockConfig.EXPECT().SetDefaultInt("tcc.TCC_MAX_PENDING_TIMEOUT", 86400).Return().Times(1)
+	mockConfig.EXPECT().GetInt("tcc.TCC_MAX_PENDING_TIMEOUT").Return(3600).Times(1)
 
 	tradeSvc = NewTradeUsecase(mockUow, mockLocker, mockConfig)
 	return
 }
 
+// stubPointLots serves accounts without lots and accepts every lot update.
+func stubPointLots(ctrl *gomock.Controller, uow *mock.MockUnitOfWork) *mock.MockPointLotRepository {
+	lotRepo := mock.NewMockPointLotRepository(ctrl)
+	uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+	lotRepo.EXPECT().ListPointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	lotRepo.EXPECT().GetLotAllocations(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	lotRepo.EXPECT().CreatePointLots(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ReservePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ReleasePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().SettlePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ConsumePointLots(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return lotRepo
+}
+
 func TestTransfer_AutoConfirmSuccess(t *testing.T) {
 	ctrl, ctx, _, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(1)
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(1)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(2)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 
 	req := &command.TransferCommand{
 		BaseCommand: command.BaseCommand{
@@ -94,20 +121,20 @@ func TestTransfer_AutoConfirmSuccess(t *testing.T) {
 	mockAccRepo.EXPECT().ReserveBalance(ctx, req.From, req.Amount).Return(nil).Times(1)
 	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
 	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
-	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(1)
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(2)
 	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, valueobject.TccPending.Ptr()).Return(&entity.TradeRecords{
 		TransactionID: "tx-123",
 		FromAccountID: req.From,
 		ToAccountID:   req.To,
 		Amount:        req.Amount,
 		Status:        int32(valueobject.TccPending),
-	}, nil).Times(1)
+	}, nil).Times(2)
 
 	mockAccRepo.EXPECT().UnreserveBalance(ctx, req.From, req.To, req.Amount).Return(nil).Times(1)
 	mockTxRepo.EXPECT().UpdateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
 	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
 
-	err := svc.Transfer(ctx, req)
+	_, err := svc.Transfer(ctx, req)
 	if err != nil {
 		t.Fatalf("Transfer (auto-confirm) returned error: %v", err)
 	}
@@ -117,11 +144,12 @@ func TestTransfer_NoAutoConfirmSuccess(t *testing.T) {
 	ctrl, ctx, _, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
 
 	req := &command.TransferCommand{
 		BaseCommand: command.BaseCommand{
@@ -133,26 +161,186 @@ func TestTransfer_NoAutoConfirmSuccess(t *testing.T) {
 		AutoConfirm: false,
 	}
 
-	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(1)
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(2)
 	mockAccRepo.EXPECT().ReserveBalance(ctx, req.From, req.Amount).Return(nil).Times(1)
-	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Do(func(ctx context.Context, trade *entity.TradeRecords) {
+		// expired_at has no time zone and is read back as UTC.
+		if trade.ExpiredAt == nil || trade.ExpiredAt.Location() != time.UTC {
+			t.Errorf("expected the pending deadline in UTC, got %v", trade.ExpiredAt)
+		}
+	}).Return(nil).Times(1)
 	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
 
-	err := svc.Transfer(ctx, req)
+	_, err := svc.Transfer(ctx, req)
 	if err != nil {
 		t.Fatalf("Transfer (no auto-confirm) returned error: %v", err)
 	}
 }
 
+func TestTransfer_UsesRequestedAsset(t *testing.T) {
+	ctrl, ctx, _, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
+	defer ctrl.Finish()
+
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(1)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
+
+	cashback := valueobject.AssetCode("CASHBACK")
+	req := &command.TransferCommand{
+		BaseCommand: command.BaseCommand{
+			From:  1,
+			To:    2,
+			Nonce: 777,
+		},
+		Amount:    valueobject.NewMoneyFromDecimal(decimal.NewFromInt(40)),
+		AssetCode: "cashback",
+	}
+	amount := req.Amount.WithAsset(cashback)
+
+	sender := dummyAccount(1, decimal.NewFromInt(100), decimal.Zero)
+	sender.AssetCode = cashback
+	sender.AvailableBalance = sender.AvailableBalance.WithAsset(cashback)
+	sender.ReservedBalance = sender.ReservedBalance.WithAsset(cashback)
+
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(2)
+	mockAccRepo.EXPECT().GetAccount(ctx, int64(2), cashback).Return(nil, nil).Times(1)
+	mockAccRepo.EXPECT().CreateAccount(ctx, int64(2), cashback).Return(nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), cashback).Return(sender, nil).Times(1)
+	mockAccRepo.EXPECT().ReserveBalance(ctx, req.From, amount).Return(nil).Times(1)
+	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
+
+	trans, err := svc.Transfer(ctx, req)
+	if err != nil {
+		t.Fatalf("Transfer returned error: %v", err)
+	}
+	if trans.Amount.Asset() != cashback {
+		t.Errorf("expected trade in %s, got %s", cashback, trans.Amount.Asset())
+	}
+}
+
+func TestTransfer_InvalidAssetCode(t *testing.T) {
+	ctrl, ctx, _, _, _, _, _, _, svc := setupTestTradeUsecase(t)
+	defer ctrl.Finish()
+
+	_, err := svc.Transfer(ctx, &command.TransferCommand{
+		BaseCommand: command.BaseCommand{From: 1, To: 2, Nonce: 1},
+		Amount:      valueobject.NewMoneyFromDecimal(decimal.NewFromInt(10)),
+		AssetCode:   "cash-back",
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid asset code") {
+		t.Fatalf("expected invalid asset code error, got %v", err)
+	}
+}
+
+func TestTransfer_TimeoutAboveMaximum(t *testing.T) {
+	ctrl, ctx, _, _, _, _, _, _, svc := setupTestTradeUsecase(t)
+	defer ctrl.Finish()
+
+	_, err := svc.Transfer(ctx, &command.TransferCommand{
+		BaseCommand:    command.BaseCommand{From: 1, To: 2, Nonce: 1},
+		Amount:         valueobject.NewMoneyFromDecimal(decimal.NewFromInt(10)),
+		TimeoutSeconds: 3601,
+	})
+	assertErrorCode(t, err, errcode.ErrInvalidRequest)
+}
+
+func TestTradeUsecase_RejectsCallerOfOtherAccount(t *testing.T) {
+	ctrl, _, _, _, _, _, _, _, svc := setupTestTradeUsecase(t)
+	defer ctrl.Finish()
+
+	base := command.BaseCommand{From: 1, To: 2, Nonce: 1}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code errcode.ErrorCode
+	}{
+		{name: "no principal", ctx: context.Background(), code: errcode.ErrUnauthorized},
+		{name: "other user", ctx: valueobject.WithPrincipal(context.Background(), &valueobject.Principal{Subject: "2", Kind: valueobject.PrincipalKindUser, AccountID: 2}), code: errcode.ErrForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := svc.Transfer(tc.ctx, &command.TransferCommand{BaseCommand: base, Amount: valueobject.NewMoneyFromDecimal(decimal.NewFromInt(10))})
+			assertErrorCode(t, err, tc.code)
+			_, err = svc.ManualConfirm(tc.ctx, &command.ConfirmCommand{BaseCommand: base})
+			assertErrorCode(t, err, tc.code)
+			_, err = svc.Cancel(tc.ctx, &command.CancelCommand{BaseCommand: base})
+			assertErrorCode(t, err, tc.code)
+		})
+	}
+}
+
+func TestCancel_AllowsCancelAnyPermission(t *testing.T) {
+	ctrl, _, _, _, _, _, _, _, svc := setupTestTradeUsecase(t)
+	defer ctrl.Finish()
+
+	support := (&valueobject.Principal{Subject: "support", Kind: valueobject.PrincipalKindUser, AccountID: 9}).
+		WithPermissions([]valueobject.Permission{valueobject.PermissionTradeCancelAny})
+	ctx := valueobject.WithPrincipal(context.Background(), support)
+	base := command.BaseCommand{From: 1, To: 2, Nonce: 1}
+
+	_, err := svc.Transfer(ctx, &command.TransferCommand{BaseCommand: base, Amount: valueobject.NewMoneyFromDecimal(decimal.NewFromInt(10))})
+	assertErrorCode(t, err, errcode.ErrForbidden)
+	if err := authorizeCaller(ctx, base.From, valueobject.PermissionTradeCancelAny); err != nil {
+		t.Fatalf("cancel_any should allow canceling for another account, got %v", err)
+	}
+}
+
+func assertErrorCode(t *testing.T, err error, code errcode.ErrorCode) {
+	t.Helper()
+	var appErr *apperror.AppError
+	if !errors.As(err, &appErr) || appErr.Code != code {
+		t.Fatalf("expected %s error, got %v", code, err)
+	}
+}
+
+func TestTransfer_ReplayReturnsOriginalTrade(t *testing.T) {
+	ctrl, ctx, _, _, mockTxRepo, _, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
+	defer ctrl.Finish()
+
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(mockLock, nil).Times(1)
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
+	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
+
+	req := &command.TransferCommand{
+		BaseCommand: command.BaseCommand{
+			From:  1,
+			To:    2,
+			Nonce: 12345,
+		},
+		Amount:      valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
+		AutoConfirm: true,
+	}
+	original := &entity.TradeRecords{
+		TransactionID: "tx-123",
+		Nonce:         req.Nonce,
+		FromAccountID: req.From,
+		ToAccountID:   req.To,
+		Amount:        req.Amount,
+		Status:        int32(valueobject.TccConfirmed),
+	}
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(original, nil).Times(1)
+
+	trans, err := svc.Transfer(ctx, req)
+	if err != nil {
+		t.Fatalf("Transfer replay returned error: %v", err)
+	}
+	if trans != original {
+		t.Errorf("expected the original trade to be returned, got %+v", trans)
+	}
+}
+
 func TestTransfer_FailureInTransferTransaction(t *testing.T) {
 	ctrl, ctx, _, mockAccRepo, mockTxRepo, _, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
 
 	req := &command.TransferCommand{
 		BaseCommand: command.BaseCommand{
@@ -163,10 +351,10 @@ func TestTransfer_FailureInTransferTransaction(t *testing.T) {
 		Amount:      valueobject.NewMoneyFromDecimal(decimal.NewFromInt(75)),
 		AutoConfirm: false,
 	}
-	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(1)
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(2)
 	mockAccRepo.EXPECT().ReserveBalance(ctx, req.From, req.Amount).Return(errors.New("reserve error")).Times(1)
 
-	err := svc.Transfer(ctx, req)
+	_, err := svc.Transfer(ctx, req)
 	if err == nil || !strings.Contains(err.Error(), "reserve error") {
 		t.Fatalf("Expected reserve error, got: %v", err)
 	}
@@ -176,11 +364,12 @@ func TestTransfer_FailureInConfirm(t *testing.T) {
 	ctrl, ctx, _, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(120), decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(120), decimal.Zero), nil).Times(1)
 
 	req := &command.TransferCommand{
 		BaseCommand: command.BaseCommand{
@@ -193,13 +382,13 @@ func TestTransfer_FailureInConfirm(t *testing.T) {
 	}
 
 	mockAccRepo.EXPECT().ReserveBalance(ctx, req.From, req.Amount).Return(nil).Times(1)
-	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(1)
+	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, nil).Return(nil, nil).Times(2)
 	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
 	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
 
 	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, gomock.Any()).Return(nil, errors.New("get transaction error")).Times(1)
 
-	err := svc.Transfer(ctx, req)
+	_, err := svc.Transfer(ctx, req)
 	if err == nil || !strings.Contains(err.Error(), "get transaction error") {
 		t.Fatalf("Expected confirm error, got: %v", err)
 	}
@@ -209,7 +398,7 @@ func TestTransfer_LockAcquisitionFailure(t *testing.T) {
 	ctrl, ctx, _, _, _, _, mockLocker, _, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("lock acquire failed")).Times(1)
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("lock acquire failed")).Times(1)
 
 	req := &command.TransferCommand{
 		BaseCommand: command.BaseCommand{
@@ -221,7 +410,7 @@ func TestTransfer_LockAcquisitionFailure(t *testing.T) {
 		AutoConfirm: true,
 	}
 
-	err := svc.Transfer(ctx, req)
+	_, err := svc.Transfer(ctx, req)
 	if err == nil || !strings.Contains(err.Error(), "lock acquire failed") {
 		t.Fatalf("Expected lock acquire error, got: %v", err)
 	}
@@ -231,7 +420,8 @@ func TestManualConfirm_Success(t *testing.T) {
 	ctrl, ctx, _, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
 	req := &command.ConfirmCommand{
@@ -248,12 +438,14 @@ func TestManualConfirm_Success(t *testing.T) {
 		ToAccountID:   req.To,
 		Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(80)),
 		Status:        int32(valueobject.TccPending),
-	}, nil).Times(1)
+	}, nil).Times(2)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 	mockAccRepo.EXPECT().UnreserveBalance(ctx, req.From, req.To, valueobject.NewMoneyFromDecimal(decimal.NewFromInt(80))).Return(nil).Times(1)
 	mockTxRepo.EXPECT().UpdateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
 	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
 
-	err := svc.ManualConfirm(ctx, req)
+	_, err := svc.ManualConfirm(ctx, req)
 	if err != nil {
 		t.Fatalf("ManualConfirm returned error: %v", err)
 	}
@@ -263,7 +455,8 @@ func TestManualConfirm_Failure(t *testing.T) {
 	ctrl, ctx, _, _, mockTxRepo, _, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
 	req := &command.ConfirmCommand{
@@ -275,7 +468,7 @@ func TestManualConfirm_Failure(t *testing.T) {
 	}
 	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, gomock.Any()).Return(nil, errors.New("confirm error")).Times(1)
 
-	err := svc.ManualConfirm(ctx, req)
+	_, err := svc.ManualConfirm(ctx, req)
 	if err == nil || !strings.Contains(err.Error(), "confirm error") {
 		t.Fatalf("Expected confirm error, got: %v", err)
 	}
@@ -285,7 +478,8 @@ func TestManualConfirm_Failure_To_Account_Mismatch(t *testing.T) {
 	ctrl, ctx, _, _, mockTxRepo, _, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
 	req := &command.ConfirmCommand{
@@ -304,7 +498,7 @@ func TestManualConfirm_Failure_To_Account_Mismatch(t *testing.T) {
 			Status:        int32(valueobject.TccPending),
 		}, nil).Times(1)
 
-	err := svc.ManualConfirm(ctx, req)
+	_, err := svc.ManualConfirm(ctx, req)
 	if err == nil || !strings.Contains(err.Error(), "to account id mismatch") {
 		t.Fatalf("Expected cancel error, got: %v", err)
 	}
@@ -314,7 +508,8 @@ func TestCancel_Success(t *testing.T) {
 	ctrl, ctx, _, mockAccRepo, mockTxRepo, mockEventRepo, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
 	req := &command.CancelCommand{
@@ -331,12 +526,13 @@ func TestCancel_Success(t *testing.T) {
 		ToAccountID:   req.To,
 		Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(60)),
 		Status:        int32(valueobject.TccPending),
-	}, nil).Times(1)
+	}, nil).Times(2)
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
 	mockAccRepo.EXPECT().UnreserveBalance(ctx, req.From, req.From, valueobject.NewMoneyFromDecimal(decimal.NewFromInt(60))).Return(nil).Times(1)
 	mockTxRepo.EXPECT().UpdateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
 	mockEventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
 
-	err := svc.Cancel(ctx, req)
+	_, err := svc.Cancel(ctx, req)
 	if err != nil {
 		t.Fatalf("Cancel returned error: %v", err)
 	}
@@ -346,7 +542,8 @@ func TestCancel_Failure(t *testing.T) {
 	ctrl, ctx, _, _, mockTxRepo, _, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
 	req := &command.CancelCommand{
@@ -359,7 +556,7 @@ func TestCancel_Failure(t *testing.T) {
 
 	mockTxRepo.EXPECT().GetTradeRecord(ctx, req.Nonce, req.From, gomock.Any()).Return(nil, errors.New("cancel error")).Times(1)
 
-	err := svc.Cancel(ctx, req)
+	_, err := svc.Cancel(ctx, req)
 	if err == nil || !strings.Contains(err.Error(), "cancel error") {
 		t.Fatalf("Expected cancel error, got: %v", err)
 	}
@@ -369,7 +566,8 @@ func TestCancel_Failure_To_Account_Mismatch(t *testing.T) {
 	ctrl, ctx, _, _, mockTxRepo, _, mockLocker, mockLock, svc := setupTestTradeUsecase(t)
 	defer ctrl.Finish()
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(&distributedlock.RedisLock{}, nil).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).Return(nil).AnyTimes()
 
 	req := &command.CancelCommand{
@@ -389,7 +587,7 @@ func TestCancel_Failure_To_Account_Mismatch(t *testing.T) {
 			Status:        int32(valueobject.TccPending),
 		}, nil).Times(1)
 
-	err := svc.Cancel(ctx, req)
+	_, err := svc.Cancel(ctx, req)
 	if err == nil || !strings.Contains(err.Error(), "to account id mismatch") {
 		t.Fatalf("Expected cancel error, got: %v", err)
 	}
@@ -402,8 +600,8 @@ func TestTransfer_HighConcurrency_ContendLock(t *testing.T) {
 	var lock sync.Mutex
 	var locked bool
 
-	mockLocker.EXPECT().Acquire(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
-		func(ctx context.Context, key string, lockDuration, retryInterval time.Duration) (*distributedlock.RedisLock, error) {
+	mockLocker.EXPECT().AcquireAll(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, keys []string, lockDuration, retryInterval time.Duration) (*distributedlock.RedisLock, error) {
 			lock.Lock()
 			defer lock.Unlock()
 			if !locked {
@@ -412,6 +610,7 @@ func TestTransfer_HighConcurrency_ContendLock(t *testing.T) {
 			}
 			return nil, fmt.Errorf("lock contention")
 		}).AnyTimes()
+	mockLock.EXPECT().Token().Return(int64(0)).AnyTimes()
 	mockLock.EXPECT().Release(ctx).DoAndReturn(
 		func(ctx context.Context) error {
 			lock.Lock()
@@ -422,8 +621,9 @@ func TestTransfer_HighConcurrency_ContendLock(t *testing.T) {
 		}).AnyTimes()
 
 	transferAmount := decimal.NewFromInt(10)
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, transferAmount, decimal.Zero), nil).AnyTimes()
-	mockAccRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).AnyTimes()
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, transferAmount, decimal.Zero), nil).AnyTimes()
+	mockAccRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).AnyTimes()
+	mockAccRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).AnyTimes()
 	mockAccRepo.EXPECT().ReserveBalance(ctx, int64(1), valueobject.NewMoneyFromDecimal(transferAmount)).AnyTimes().Return(nil)
 	mockTxRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).AnyTimes().DoAndReturn(func(ctx context.Context, trans *entity.TradeRecords) error {
 		time.Sleep(5 * time.Millisecond)
@@ -461,7 +661,7 @@ func TestTransfer_HighConcurrency_ContendLock(t *testing.T) {
 				AutoConfirm: true,
 			}
 
-			err := svc.Transfer(ctx, req)
+			_, err := svc.Transfer(ctx, req)
 			if err == nil {
 				atomic.AddInt32(&successCount, 1)
 			} else if !strings.Contains(err.Error(), "lock") {
@@ -4,21 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"points/internal/domain/entity"
 	"points/internal/domain/event"
 	"points/internal/domain/repository"
 	"points/internal/domain/valueobject"
 	"points/internal/shared/apperror"
 	"points/internal/shared/errcode"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type TransactionApplicationService interface {
-	TransferTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, amount valueobject.Money) error
-	ConfirmTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64) error
-	CancelTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64) error
+	ReplayTransfer(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, amount valueobject.Money) (*entity.TradeRecords, error)
+	TransferTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, amount valueobject.Money, expiredAt time.Time) (*entity.TradeRecords, error)
+	ConfirmTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64) (*entity.TradeRecords, error)
+	CancelTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, reason valueobject.CancelReason) (*entity.TradeRecords, error)
+	MintTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, to int64, amount valueobject.Money, reasonCode, externalReference string, expiresAt *time.Time) (*entity.TradeRecords, error)
+	BurnTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from int64, amount valueobject.Money, reasonCode, externalReference string) (*entity.TradeRecords, error)
+	ExpirePointLots(ctx context.Context, unitOfWork repository.UnitOfWork, accountID int64, asset valueobject.AssetCode, now time.Time) (*entity.TradeRecords, error)
 }
 
 type transactionApplicationService struct{}
@@ -27,118 +34,490 @@ func NewTransactionApplicationService() TransactionApplicationService {
 	return &transactionApplicationService{}
 }
 
-func (ts *transactionApplicationService) TransferTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, amount valueobject.Money) error {
-	toAccount, err := unitOfWork.AccountRepository().GetAccount(ctx, to)
+// ReplayTransfer returns the trade previously recorded under (from, nonce) when
+// the request parameters match it, or nil when the nonce has not been used yet.
+func (ts *transactionApplicationService) ReplayTransfer(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, amount valueobject.Money) (*entity.TradeRecords, error) {
+	return ts.replayTrade(ctx, unitOfWork, valueobject.TradeTypeTransfer, nonce, from, to, amount, "transfer phase")
+}
+
+func (ts *transactionApplicationService) TransferTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, amount valueobject.Money, expiredAt time.Time) (*entity.TradeRecords, error) {
+	if from == valueobject.SystemAccountID || to == valueobject.SystemAccountID {
+		return nil, apperror.Wrap(errcode.ErrInvalidRequest, "transfer phase - account validation", errors.New("system account cannot take part in transfers"))
+	}
+	asset := amount.Asset()
+	if err := asset.Validate(); err != nil {
+		return nil, err
+	}
+
+	toAccount, err := unitOfWork.AccountRepository().GetAccount(ctx, to, asset)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return apperror.Wrap(errcode.ErrGetAccount, "transfer phase - get to account", err)
+		return nil, apperror.Wrap(errcode.ErrGetAccount, "transfer phase - get to account", err)
 	}
 
 	if toAccount == nil {
-		err := unitOfWork.AccountRepository().CreateAccount(ctx, to)
+		err := unitOfWork.AccountRepository().CreateAccount(ctx, to, asset)
 		if err != nil {
-			return apperror.Wrap(errcode.ErrCreateAccount, "transfer phase - create account", err)
+			return nil, apperror.Wrap(errcode.ErrCreateAccount, "transfer phase - create account", err)
 		}
 	}
 	tx, err := unitOfWork.TradeRecordsRepository().GetTradeRecord(ctx, nonce, from, nil)
 	if tx != nil || (err != nil && !errors.Is(err, gorm.ErrRecordNotFound)) {
-		return apperror.Wrap(errcode.ErrConflict, "transfer phase - conflict nonce", err)
+		return nil, apperror.Wrap(errcode.ErrConflict, "transfer phase - conflict nonce", err)
 	}
 
-	fromAccount, err := unitOfWork.AccountRepository().GetAccount(ctx, from)
+	fromAccount, err := unitOfWork.AccountRepository().GetAccountForUpdate(ctx, from, asset)
 	if err != nil {
-		return apperror.Wrap(errcode.ErrGetAccount, "transfer phase - get from account", err)
+		return nil, apperror.Wrap(errcode.ErrGetAccount, "transfer phase - get from account", err)
 	}
 
-	if err := fromAccount.Reserve(amount); err != nil {
-		return err
+	if err := loadPointLots(ctx, unitOfWork, fromAccount, "transfer phase"); err != nil {
+		return nil, err
+	}
+
+	allocations, err := fromAccount.Reserve(amount, valueobject.Now())
+	if err != nil {
+		return nil, err
 	}
 
 	if err := unitOfWork.AccountRepository().ReserveBalance(ctx, from, amount); err != nil {
-		return apperror.Wrap(errcode.ErrReserveBalance, "transfer phase - reserve balance", err)
+		return nil, apperror.Wrap(errcode.ErrReserveBalance, "transfer phase - reserve balance", err)
+	}
+
+	transactionID := uuid.New().String()
+	if err := unitOfWork.PointLotRepository().ReservePointLots(ctx, transactionID, allocations); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "transfer phase - reserve point lots", err)
+	}
+
+	ledgerEntries := entity.NewReserveLedgerEntries(transactionID, from, amount)
+	if err := unitOfWork.LedgerRepository().CreateLedgerEntries(ctx, ledgerEntries); err != nil {
+		return nil, apperror.Wrap(errcode.ErrCreateLedgerEntry, "transfer phase - create ledger entries", err)
 	}
 
 	trans := &entity.TradeRecords{
-		TransactionID: uuid.New().String(),
+		TransactionID: transactionID,
 		Nonce:         nonce,
 		FromAccountID: from,
 		ToAccountID:   to,
 		Amount:        amount,
 		Status:        int32(valueobject.TccPending),
+		ExpiredAt:     &expiredAt,
 	}
 
 	trans.Transfer()
 
 	if err := unitOfWork.TradeRecordsRepository().CreateTradeRecord(ctx, trans); err != nil {
-		return apperror.Wrap(errcode.ErrCreateTransaction, "transfer phase - create transaction", err)
+		return nil, apperror.Wrap(errcode.ErrCreateTransaction, "transfer phase - create transaction", err)
 	}
 
 	domainEvents := trans.PullEvents()
 	if err := ts.saveDomainEvents(ctx, unitOfWork, trans.TransactionID, domainEvents, "transfer phase"); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return trans, nil
 }
 
-func (ts *transactionApplicationService) ConfirmTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64) error {
+func (ts *transactionApplicationService) ConfirmTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64) (*entity.TradeRecords, error) {
 	trans, err := unitOfWork.TradeRecordsRepository().GetTradeRecord(ctx, nonce, from, valueobject.TccPending.Ptr())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ts.replaySettled(ctx, unitOfWork, nonce, from, to, valueobject.TccConfirmed, "confirm phase")
+	}
 	if err != nil {
-		return apperror.Wrap(errcode.ErrGetTransaction, "confirm phase - get transaction", err)
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, "confirm phase - get transaction", err)
 	}
 	if trans == nil {
-		return apperror.Wrap(errcode.ErrGetTransaction, "confirm phase - get transaction", errors.New("transaction not found"))
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, "confirm phase - get transaction", errors.New("transaction not found"))
 	}
 
 	if trans.ToAccountID != to {
-		return apperror.Wrap(errcode.ErrInvalidRequest, "confirm phase - to account validation", errors.New("to account id mismatch"))
+		return nil, apperror.Wrap(errcode.ErrInvalidRequest, "confirm phase - to account validation", errors.New("to account id mismatch"))
+	}
+
+	if trans.IsExpired(valueobject.Now()) {
+		return nil, apperror.Wrap(errcode.ErrTransactionExpired, "confirm phase - expiry validation", errors.New("transaction has passed its deadline"))
+	}
+
+	trans, err = lockPendingTrade(ctx, unitOfWork, trans, nonce, "confirm phase", from, to)
+	if err != nil {
+		return nil, err
+	}
+	if trans == nil {
+		return ts.replaySettled(ctx, unitOfWork, nonce, from, to, valueobject.TccConfirmed, "confirm phase")
 	}
 
 	if err := unitOfWork.AccountRepository().UnreserveBalance(ctx, from, to, trans.Amount); err != nil {
-		return apperror.Wrap(errcode.ErrReserveBalance, "confirm phase - unreserve balance", err)
+		return nil, apperror.Wrap(errcode.ErrReserveBalance, "confirm phase - unreserve balance", err)
+	}
+
+	allocations, err := unitOfWork.PointLotRepository().GetLotAllocations(ctx, trans.TransactionID)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetPointLot, "confirm phase - get lot allocations", err)
+	}
+	if err := unitOfWork.PointLotRepository().SettlePointLots(ctx, trans.TransactionID, allocations); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "confirm phase - settle point lots", err)
+	}
+	receivedLots := entity.NewSettledPointLots(to, trans.TransactionID, trans.Amount, allocations)
+	if err := unitOfWork.PointLotRepository().CreatePointLots(ctx, receivedLots); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "confirm phase - create point lots", err)
+	}
+
+	ledgerEntries := entity.NewSettleLedgerEntries(trans.TransactionID, from, to, trans.Amount)
+	if err := unitOfWork.LedgerRepository().CreateLedgerEntries(ctx, ledgerEntries); err != nil {
+		return nil, apperror.Wrap(errcode.ErrCreateLedgerEntry, "confirm phase - create ledger entries", err)
 	}
 
 	trans.Confirm()
 
 	if err := unitOfWork.TradeRecordsRepository().UpdateTradeRecord(ctx, trans); err != nil {
-		return apperror.Wrap(errcode.ErrUpdateTransaction, "confirm phase - update transaction", err)
+		return nil, apperror.Wrap(errcode.ErrUpdateTransaction, "confirm phase - update transaction", err)
 	}
 
 	domainEvents := trans.PullEvents()
 	if err := ts.saveDomainEvents(ctx, unitOfWork, trans.TransactionID, domainEvents, "transfer phase"); err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return trans, nil
 }
 
-func (ts *transactionApplicationService) CancelTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64) error {
+func (ts *transactionApplicationService) CancelTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, reason valueobject.CancelReason) (*entity.TradeRecords, error) {
 	trans, err := unitOfWork.TradeRecordsRepository().GetTradeRecord(ctx, nonce, from, valueobject.TccPending.Ptr())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ts.replaySettled(ctx, unitOfWork, nonce, from, to, valueobject.TccCanceled, "cancel phase")
+	}
 	if err != nil {
-		return apperror.Wrap(errcode.ErrGetTransaction, "cancel phase - get transaction", err)
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, "cancel phase - get transaction", err)
 	}
 
 	if trans.ToAccountID != to {
-		return apperror.Wrap(errcode.ErrInvalidRequest, "cancel phase - to account validation", errors.New("to account id mismatch"))
+		return nil, apperror.Wrap(errcode.ErrInvalidRequest, "cancel phase - to account validation", errors.New("to account id mismatch"))
+	}
+
+	trans, err = lockPendingTrade(ctx, unitOfWork, trans, nonce, "cancel phase", from)
+	if err != nil {
+		return nil, err
+	}
+	if trans == nil {
+		return ts.replaySettled(ctx, unitOfWork, nonce, from, to, valueobject.TccCanceled, "cancel phase")
 	}
 
 	if err := unitOfWork.AccountRepository().UnreserveBalance(ctx, from, from, trans.Amount); err != nil {
-		return apperror.Wrap(errcode.ErrReserveBalance, "cancel phase - unreserve balance", err)
+		return nil, apperror.Wrap(errcode.ErrReserveBalance, "cancel phase - unreserve balance", err)
+	}
+
+	allocations, err := unitOfWork.PointLotRepository().GetLotAllocations(ctx, trans.TransactionID)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetPointLot, "cancel phase - get lot allocations", err)
+	}
+	if err := unitOfWork.PointLotRepository().ReleasePointLots(ctx, trans.TransactionID, allocations); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "cancel phase - release point lots", err)
+	}
+	if lot := entity.NewUncoveredPointLot(from, trans.TransactionID, trans.Amount, allocations); lot != nil {
+		if err := unitOfWork.PointLotRepository().CreatePointLots(ctx, []*entity.PointLot{lot}); err != nil {
+			return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "cancel phase - create point lots", err)
+		}
+	}
+
+	ledgerEntries := entity.NewReleaseLedgerEntries(trans.TransactionID, from, trans.Amount)
+	if err := unitOfWork.LedgerRepository().CreateLedgerEntries(ctx, ledgerEntries); err != nil {
+		return nil, apperror.Wrap(errcode.ErrCreateLedgerEntry, "cancel phase - create ledger entries", err)
 	}
 
-	trans.Cancel()
+	trans.Cancel(reason)
 
 	if err := unitOfWork.TradeRecordsRepository().UpdateTradeRecord(ctx, trans); err != nil {
-		return err
+		return nil, err
 	}
 
 	domainEvents := trans.PullEvents()
 	if err := ts.saveDomainEvents(ctx, unitOfWork, trans.TransactionID, domainEvents, "cancel phase"); err != nil {
-		return err
+		return nil, err
+	}
+
+	return trans, nil
+}
+
+// MintTransaction credits freshly issued points as a lot that expires at
+// expiresAt, or never when it is nil.
+func (ts *transactionApplicationService) MintTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, to int64, amount valueobject.Money, reasonCode, externalReference string, expiresAt *time.Time) (*entity.TradeRecords, error) {
+	if err := validateAdjustment(to, amount, reasonCode, "mint phase"); err != nil {
+		return nil, err
+	}
+
+	from := valueobject.SystemAccountID
+	replayed, err := ts.replayTrade(ctx, unitOfWork, valueobject.TradeTypeMint, nonce, from, to, amount, "mint phase")
+	if err != nil || replayed != nil {
+		return replayed, err
+	}
+
+	if err := ensureAccount(ctx, unitOfWork, from, amount.Asset(), "mint phase"); err != nil {
+		return nil, err
+	}
+	if err := ensureAccount(ctx, unitOfWork, to, amount.Asset(), "mint phase"); err != nil {
+		return nil, err
+	}
+
+	if err := unitOfWork.AccountRepository().CreditBalance(ctx, to, amount); err != nil {
+		return nil, apperror.Wrap(errcode.ErrCreditBalance, "mint phase - credit balance", err)
+	}
+
+	trans := newAdjustment(nonce, from, to, amount, reasonCode, externalReference)
+	lot := entity.NewPointLot(to, trans.TransactionID, amount, expiresAt)
+	if err := unitOfWork.PointLotRepository().CreatePointLots(ctx, []*entity.PointLot{lot}); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "mint phase - create point lot", err)
+	}
+
+	if err := unitOfWork.LedgerRepository().CreateLedgerEntries(ctx, entity.NewMintLedgerEntries(trans.TransactionID, to, amount)); err != nil {
+		return nil, apperror.Wrap(errcode.ErrCreateLedgerEntry, "mint phase - create ledger entries", err)
+	}
+
+	trans.Mint()
+	if err := ts.saveAdjustment(ctx, unitOfWork, trans, "mint phase"); err != nil {
+		return nil, err
+	}
+
+	return trans, nil
+}
+
+func (ts *transactionApplicationService) BurnTransaction(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from int64, amount valueobject.Money, reasonCode, externalReference string) (*entity.TradeRecords, error) {
+	if err := validateAdjustment(from, amount, reasonCode, "burn phase"); err != nil {
+		return nil, err
+	}
+
+	to := valueobject.SystemAccountID
+	replayed, err := ts.replayTrade(ctx, unitOfWork, valueobject.TradeTypeBurn, nonce, from, to, amount, "burn phase")
+	if err != nil || replayed != nil {
+		return replayed, err
+	}
+
+	fromAccount, err := unitOfWork.AccountRepository().GetAccountForUpdate(ctx, from, amount.Asset())
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && fromAccount == nil) {
+		return nil, apperror.Wrap(errcode.ErrAccountNotFound, "burn phase - get account", err)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetAccount, "burn phase - get account", err)
+	}
+
+	if err := loadPointLots(ctx, unitOfWork, fromAccount, "burn phase"); err != nil {
+		return nil, err
+	}
+
+	allocations, err := fromAccount.Debit(amount, valueobject.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensureAccount(ctx, unitOfWork, to, amount.Asset(), "burn phase"); err != nil {
+		return nil, err
+	}
+
+	if err := unitOfWork.AccountRepository().DebitBalance(ctx, from, amount); err != nil {
+		return nil, apperror.Wrap(errcode.ErrDebitBalance, "burn phase - debit balance", err)
+	}
+	if err := unitOfWork.PointLotRepository().ConsumePointLots(ctx, allocations); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "burn phase - consume point lots", err)
 	}
 
+	trans := newAdjustment(nonce, from, to, amount, reasonCode, externalReference)
+	if err := unitOfWork.LedgerRepository().CreateLedgerEntries(ctx, entity.NewBurnLedgerEntries(trans.TransactionID, from, amount)); err != nil {
+		return nil, apperror.Wrap(errcode.ErrCreateLedgerEntry, "burn phase - create ledger entries", err)
+	}
+
+	trans.Burn()
+	if err := ts.saveAdjustment(ctx, unitOfWork, trans, "burn phase"); err != nil {
+		return nil, err
+	}
+
+	return trans, nil
+}
+
+// ExpirePointLots burns whatever is left in the expired lots of an account and
+// records it as an expire trade. It returns nil when nothing has expired.
+func (ts *transactionApplicationService) ExpirePointLots(ctx context.Context, unitOfWork repository.UnitOfWork, accountID int64, asset valueobject.AssetCode, now time.Time) (*entity.TradeRecords, error) {
+	account, err := unitOfWork.AccountRepository().GetAccountForUpdate(ctx, accountID, asset)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && account == nil) {
+		return nil, apperror.Wrap(errcode.ErrAccountNotFound, "expire phase - get account", err)
+	}
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetAccount, "expire phase - get account", err)
+	}
+
+	if err := loadPointLots(ctx, unitOfWork, account, "expire phase"); err != nil {
+		return nil, err
+	}
+
+	allocations := account.ExpireLots(now)
+	if len(allocations) == 0 {
+		return nil, nil
+	}
+	amount := entity.SumLotAllocations(allocations).WithAsset(account.Asset())
+
+	to := valueobject.SystemAccountID
+	if err := ensureAccount(ctx, unitOfWork, to, amount.Asset(), "expire phase"); err != nil {
+		return nil, err
+	}
+
+	if err := unitOfWork.AccountRepository().DebitBalance(ctx, accountID, amount); err != nil {
+		return nil, apperror.Wrap(errcode.ErrDebitBalance, "expire phase - debit balance", err)
+	}
+	if err := unitOfWork.PointLotRepository().ConsumePointLots(ctx, allocations); err != nil {
+		return nil, apperror.Wrap(errcode.ErrUpdatePointLot, "expire phase - consume point lots", err)
+	}
+
+	trans := newAdjustment(expireNonce(allocations), accountID, to, amount, valueobject.TradeTypeExpire.String(), "")
+	if err := unitOfWork.LedgerRepository().CreateLedgerEntries(ctx, entity.NewExpireLedgerEntries(trans.TransactionID, accountID, amount)); err != nil {
+		return nil, apperror.Wrap(errcode.ErrCreateLedgerEntry, "expire phase - create ledger entries", err)
+	}
+
+	trans.Expire()
+	if err := ts.saveAdjustment(ctx, unitOfWork, trans, "expire phase"); err != nil {
+		return nil, err
+	}
+
+	return trans, nil
+}
+
+// lockPendingTrade locks the rows of the accounts a pending trade moves, in
+// account id order, and reads the trade again. It returns nil if a confirm or
+// cancel settled the trade while this one waited for the rows.
+func lockPendingTrade(ctx context.Context, unitOfWork repository.UnitOfWork, trans *entity.TradeRecords, nonce int64, phase string, accountIDs ...int64) (*entity.TradeRecords, error) {
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+	for i, id := range accountIDs {
+		if i > 0 && id == accountIDs[i-1] {
+			continue
+		}
+		if _, err := unitOfWork.AccountRepository().GetAccountForUpdate(ctx, id, trans.Amount.Asset()); err != nil {
+			return nil, apperror.Wrap(errcode.ErrGetAccount, phase+" - lock account", err)
+		}
+	}
+
+	locked, err := unitOfWork.TradeRecordsRepository().GetTradeRecord(ctx, nonce, trans.FromAccountID, valueobject.TccPending.Ptr())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil || locked == nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, phase+" - get transaction", err)
+	}
+	return locked, nil
+}
+
+// expireNonce derives the nonce of an expiry trade from the lowest lot id it
+// burns. Lot ids are unique across accounts and assets and an expired lot is
+// burned in full, so no two expiry trades share a nonce. The value is negative
+// so it never collides with client nonces.
+func expireNonce(allocations []*entity.LotAllocation) int64 {
+	lowest := allocations[0].LotID
+	for _, allocation := range allocations[1:] {
+		if allocation.LotID < lowest {
+			lowest = allocation.LotID
+		}
+	}
+	return -lowest
+}
+
+// loadPointLots attaches the spendable lots of account so balance checks can
+// leave expired lots out.
+func loadPointLots(ctx context.Context, unitOfWork repository.UnitOfWork, account *entity.Account, phase string) error {
+	lots, err := unitOfWork.PointLotRepository().ListPointLots(ctx, account.UserID, account.Asset())
+	if err != nil {
+		return apperror.Wrap(errcode.ErrGetPointLot, phase+" - list point lots", err)
+	}
+	account.Lots = lots
+	return nil
+}
+
+func validateAdjustment(accountID int64, amount valueobject.Money, reasonCode, phase string) error {
+	if accountID == valueobject.SystemAccountID {
+		return apperror.Wrap(errcode.ErrInvalidRequest, phase+" - account validation", errors.New("system account cannot be adjusted"))
+	}
+	if !amount.GreaterThan(valueobject.ZeroOf(amount.Asset())) {
+		return apperror.Wrap(errcode.ErrInvalidRequest, phase+" - amount validation", errors.New("amount must be positive"))
+	}
+	if reasonCode == "" {
+		return apperror.Wrap(errcode.ErrInvalidRequest, phase+" - reason validation", errors.New("reason code is required"))
+	}
+	return amount.Asset().Validate()
+}
+
+// ensureAccount opens an empty balance for userID in asset unless one exists.
+// The system account needs one per asset because trades reference it.
+func ensureAccount(ctx context.Context, unitOfWork repository.UnitOfWork, userID int64, asset valueobject.AssetCode, phase string) error {
+	account, err := unitOfWork.AccountRepository().GetAccount(ctx, userID, asset)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.Wrap(errcode.ErrGetAccount, phase+" - get account", err)
+	}
+	if account != nil {
+		return nil
+	}
+
+	if err := unitOfWork.AccountRepository().CreateAccount(ctx, userID, asset); err != nil {
+		return apperror.Wrap(errcode.ErrCreateAccount, phase+" - create account", err)
+	}
 	return nil
 }
 
+func newAdjustment(nonce, from, to int64, amount valueobject.Money, reasonCode, externalReference string) *entity.TradeRecords {
+	return &entity.TradeRecords{
+		TransactionID:     uuid.New().String(),
+		Nonce:             nonce,
+		FromAccountID:     from,
+		ToAccountID:       to,
+		Amount:            amount,
+		ReasonCode:        reasonCode,
+		ExternalReference: externalReference,
+	}
+}
+
+func (ts *transactionApplicationService) saveAdjustment(ctx context.Context, unitOfWork repository.UnitOfWork, trans *entity.TradeRecords, phase string) error {
+	if err := unitOfWork.TradeRecordsRepository().CreateTradeRecord(ctx, trans); err != nil {
+		return apperror.Wrap(errcode.ErrCreateTransaction, phase+" - create transaction", err)
+	}
+
+	return ts.saveDomainEvents(ctx, unitOfWork, trans.TransactionID, trans.PullEvents(), phase)
+}
+
+// replayTrade returns the trade previously recorded under (from, nonce) when it
+// matches the request, nil when the nonce is unused, and a conflict otherwise.
+func (ts *transactionApplicationService) replayTrade(ctx context.Context, unitOfWork repository.UnitOfWork, tradeType valueobject.TradeType, nonce, from, to int64, amount valueobject.Money, phase string) (*entity.TradeRecords, error) {
+	trans, err := unitOfWork.TradeRecordsRepository().GetTradeRecord(ctx, nonce, from, nil)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && trans == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, phase+" - get transaction", err)
+	}
+
+	if trans.Type() != tradeType || trans.ToAccountID != to || !trans.Amount.Equals(amount) {
+		return nil, apperror.Wrap(errcode.ErrConflict, phase+" - conflict nonce", errors.New("nonce already used with different parameters"))
+	}
+
+	return trans, nil
+}
+
+// replaySettled answers a retried confirm or cancel whose transaction is no
+// longer pending: the original outcome is returned when it matches, anything
+// else is a conflict.
+func (ts *transactionApplicationService) replaySettled(ctx context.Context, unitOfWork repository.UnitOfWork, nonce, from, to int64, want valueobject.TccStatus, phase string) (*entity.TradeRecords, error) {
+	trans, err := unitOfWork.TradeRecordsRepository().GetTradeRecord(ctx, nonce, from, nil)
+	if err != nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, phase+" - get transaction", err)
+	}
+	if trans == nil {
+		return nil, apperror.Wrap(errcode.ErrGetTransaction, phase+" - get transaction", errors.New("transaction not found"))
+	}
+
+	if trans.ToAccountID != to {
+		return nil, apperror.Wrap(errcode.ErrInvalidRequest, phase+" - to account validation", errors.New("to account id mismatch"))
+	}
+
+	if valueobject.TccStatus(trans.Status) != want {
+		return nil, apperror.Wrap(errcode.ErrConflict, phase+" - status validation",
+			fmt.Errorf("transaction already %s", valueobject.TccStatus(trans.Status)))
+	}
+
+	return trans, nil
+}
+
 func (ts *transactionApplicationService) saveDomainEvents(
 	ctx context.Context,
 	uow repository.UnitOfWork,
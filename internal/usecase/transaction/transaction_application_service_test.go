@@ -12,6 +12,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func dummyAccount(userID int64, availableBalance, reservedBalance decimal.Decimal) *entity.Account {
@@ -22,6 +23,20 @@ func dummyAccount(userID int64, availableBalance, reservedBalance decimal.Decima
 	}
 }
 
+// stubPointLots serves an account without lots and accepts every lot update.
+func stubPointLots(ctrl *gomock.Controller, uow *mock.MockUnitOfWork) *mock.MockPointLotRepository {
+	lotRepo := mock.NewMockPointLotRepository(ctrl)
+	uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+	lotRepo.EXPECT().ListPointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	lotRepo.EXPECT().GetLotAllocations(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	lotRepo.EXPECT().CreatePointLots(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ReservePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ReleasePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().SettlePointLots(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	lotRepo.EXPECT().ConsumePointLots(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	return lotRepo
+}
+
 func TestTransferTransaction(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
@@ -43,9 +58,9 @@ func TestTransferTransaction(t *testing.T) {
 				accRepo *mock.MockAccountRepository,
 				transRepo *mock.MockTradeRecordsRepository,
 				eventRepo *mock.MockTransactionEventRepository) {
-				accRepo.EXPECT().GetAccount(ctx, int64(2)).Return(nil, nil).Times(1)
-				accRepo.EXPECT().CreateAccount(ctx, int64(2)).Return(nil).Times(1)
-				accRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(nil, nil).Times(1)
+				accRepo.EXPECT().CreateAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
 				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(nil, nil).Times(1)
 				accRepo.EXPECT().ReserveBalance(ctx, int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(nil).Times(1)
 				transRepo.EXPECT().CreateTradeRecord(ctx, gomock.AssignableToTypeOf(&entity.TradeRecords{})).
@@ -69,7 +84,7 @@ func TestTransferTransaction(t *testing.T) {
 				accRepo *mock.MockAccountRepository,
 				transRepo *mock.MockTradeRecordsRepository,
 				eventRepo *mock.MockTransactionEventRepository) {
-				accRepo.EXPECT().GetAccount(ctx, int64(2)).Return(nil, errors.New("db error")).Times(1)
+				accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(nil, errors.New("db error")).Times(1)
 				uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
 			},
 			expectedErr: errors.New("db error"),
@@ -80,8 +95,8 @@ func TestTransferTransaction(t *testing.T) {
 				accRepo *mock.MockAccountRepository,
 				transRepo *mock.MockTradeRecordsRepository,
 				eventRepo *mock.MockTransactionEventRepository) {
-				accRepo.EXPECT().GetAccount(ctx, int64(2)).Return(nil, nil).Times(1)
-				accRepo.EXPECT().CreateAccount(ctx, int64(2)).Return(errors.New("create error")).Times(1)
+				accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(nil, nil).Times(1)
+				accRepo.EXPECT().CreateAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(errors.New("create error")).Times(1)
 				uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
 			},
 			expectedErr: errors.New("create error"),
@@ -93,8 +108,8 @@ func TestTransferTransaction(t *testing.T) {
 				transRepo *mock.MockTradeRecordsRepository,
 				eventRepo *mock.MockTransactionEventRepository) {
 				uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
-				accRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
-				accRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(nil, nil).Times(1)
 				accRepo.EXPECT().ReserveBalance(ctx, int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).
 					Return(errors.New("reserve error")).Times(1)
@@ -108,8 +123,8 @@ func TestTransferTransaction(t *testing.T) {
 				accRepo *mock.MockAccountRepository,
 				transRepo *mock.MockTradeRecordsRepository,
 				eventRepo *mock.MockTransactionEventRepository) {
-				accRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
-				accRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(nil, nil).Times(1)
 				accRepo.EXPECT().ReserveBalance(ctx, int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).
 					Return(nil).Times(1)
@@ -125,8 +140,8 @@ func TestTransferTransaction(t *testing.T) {
 				accRepo *mock.MockAccountRepository,
 				transRepo *mock.MockTradeRecordsRepository,
 				eventRepo *mock.MockTransactionEventRepository) {
-				accRepo.EXPECT().GetAccount(ctx, int64(1)).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
-				accRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(nil, nil).Times(1)
 				accRepo.EXPECT().ReserveBalance(ctx, int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).
 					Return(nil).Times(1)
@@ -144,7 +159,7 @@ func TestTransferTransaction(t *testing.T) {
 				accRepo *mock.MockAccountRepository,
 				transRepo *mock.MockTradeRecordsRepository,
 				eventRepo *mock.MockTransactionEventRepository) {
-				accRepo.EXPECT().GetAccount(ctx, int64(2)).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				conflictRecord := &entity.TradeRecords{TransactionID: "existing"}
 				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).
 					Return(conflictRecord, errors.New("conflict error")).Times(1)
@@ -163,10 +178,14 @@ func TestTransferTransaction(t *testing.T) {
 			accRepo := mock.NewMockAccountRepository(ctrl)
 			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
 			eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+			ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+			uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+			stubPointLots(ctrl, uow)
+			ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).AnyTimes()
 
 			tt.setupMocks(uow, accRepo, transRepo, eventRepo)
 
-			err := svc.TransferTransaction(ctx, uow, 123, 1, 2, valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)))
+			_, err := svc.TransferTransaction(ctx, uow, 123, 1, 2, valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)), time.Now().Add(time.Minute))
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr.Error())
@@ -202,7 +221,9 @@ func TestConfirmTransaction(t *testing.T) {
 				}
 				trans.Confirm()
 
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(2), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(nil).Times(1)
 				transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(nil).Times(1)
 				eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(2)
@@ -222,6 +243,24 @@ func TestConfirmTransaction(t *testing.T) {
 			},
 			expectedErr: errors.New("get transaction error"),
 		},
+		{
+			name: "fail - transaction expired",
+			setupMocks: func(uow *mock.MockUnitOfWork, accRepo *mock.MockAccountRepository, transRepo *mock.MockTradeRecordsRepository, eventRepo *mock.MockTransactionEventRepository) {
+				pendingStatus := valueobject.TccPending
+				expiredAt := time.Now().Add(-time.Minute)
+				trans := &entity.TradeRecords{
+					TransactionID: "tx-123",
+					FromAccountID: 1,
+					ToAccountID:   2,
+					Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
+					Status:        int32(pendingStatus),
+					ExpiredAt:     &expiredAt,
+				}
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+			},
+			expectedErr: errors.New("transaction has passed its deadline"),
+		},
 		{
 			name: "fail - UnreserveBalance error",
 			setupMocks: func(uow *mock.MockUnitOfWork, accRepo *mock.MockAccountRepository, transRepo *mock.MockTradeRecordsRepository, eventRepo *mock.MockTransactionEventRepository) {
@@ -234,7 +273,9 @@ func TestConfirmTransaction(t *testing.T) {
 					Status:        int32(pendingStatus),
 				}
 				trans.Confirm()
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(2), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(errors.New("unreserve error")).Times(1)
 				uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
 				uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
@@ -253,7 +294,9 @@ func TestConfirmTransaction(t *testing.T) {
 					Status:        int32(pendingStatus),
 				}
 				trans.Confirm()
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(2), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(nil).Times(1)
 				transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(errors.New("update error")).Times(1)
 				uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
@@ -273,7 +316,9 @@ func TestConfirmTransaction(t *testing.T) {
 					Status:        int32(pendingStatus),
 				}
 				trans.Confirm()
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(2), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(nil).Times(1)
 				transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(nil).Times(1)
 				eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(errors.New("create event error")).Times(1)
@@ -293,9 +338,13 @@ func TestConfirmTransaction(t *testing.T) {
 			accRepo := mock.NewMockAccountRepository(ctrl)
 			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
 			eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+			ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+			uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+			stubPointLots(ctrl, uow)
+			ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).AnyTimes()
 
 			tt.setupMocks(uow, accRepo, transRepo, eventRepo)
-			err := svc.ConfirmTransaction(ctx, uow, 123, 1, 2)
+			_, err := svc.ConfirmTransaction(ctx, uow, 123, 1, 2)
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr.Error())
@@ -327,9 +376,10 @@ func TestCancelTransaction(t *testing.T) {
 					Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
 					Status:        int32(pendingStatus),
 				}
-				trans.Cancel()
+				trans.Cancel(valueobject.CancelReasonClient)
 
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(nil).Times(1)
 				transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(nil).Times(1)
 				eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(2)
@@ -359,8 +409,9 @@ func TestCancelTransaction(t *testing.T) {
 					Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
 					Status:        int32(pendingStatus),
 				}
-				trans.Cancel()
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				trans.Cancel(valueobject.CancelReasonClient)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(errors.New("unreserve error")).Times(1)
 				uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
 				uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
@@ -378,8 +429,9 @@ func TestCancelTransaction(t *testing.T) {
 					Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
 					Status:        int32(pendingStatus),
 				}
-				trans.Cancel()
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				trans.Cancel(valueobject.CancelReasonClient)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(nil).Times(1)
 				transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(errors.New("update error")).Times(1)
 				uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
@@ -398,8 +450,9 @@ func TestCancelTransaction(t *testing.T) {
 					Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
 					Status:        int32(pendingStatus),
 				}
-				trans.Cancel()
-				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(1)
+				trans.Cancel(valueobject.CancelReasonClient)
+				transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), &pendingStatus).Return(trans, nil).Times(2)
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
 				accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(1), valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))).Return(nil).Times(1)
 				transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(nil).Times(1)
 				eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(errors.New("create event error")).Times(1)
@@ -419,9 +472,13 @@ func TestCancelTransaction(t *testing.T) {
 			accRepo := mock.NewMockAccountRepository(ctrl)
 			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
 			eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+			ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+			uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+			stubPointLots(ctrl, uow)
+			ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).AnyTimes()
 
 			tt.setupMocks(uow, accRepo, transRepo, eventRepo)
-			err := svc.CancelTransaction(ctx, uow, 123, 1, 2)
+			_, err := svc.CancelTransaction(ctx, uow, 123, 1, 2, valueobject.CancelReasonClient)
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedErr.Error())
@@ -431,3 +488,716 @@ func TestCancelTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestConfirmTransactionWritesBalancedLedgerEntries(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mock.NewMockUnitOfWork(ctrl)
+	accRepo := mock.NewMockAccountRepository(ctrl)
+	transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+	eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+	ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+	uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+	uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+	uow.EXPECT().TransactionEventRepository().Return(eventRepo).AnyTimes()
+	uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+	stubPointLots(ctrl, uow)
+
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))
+	trans := &entity.TradeRecords{
+		TransactionID: "tx-123",
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        amount,
+		Status:        int32(valueobject.TccPending),
+	}
+	transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), gomock.Any()).Return(trans, nil).Times(2)
+	accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+	accRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+	accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(2), amount).Return(nil).Times(1)
+	transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(nil).Times(1)
+	eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).AnyTimes()
+
+	var written []*entity.LedgerEntry
+	ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entries []*entity.LedgerEntry) error {
+			written = entries
+			return nil
+		}).Times(1)
+
+	_, err := NewTransactionApplicationService().ConfirmTransaction(ctx, uow, 123, 1, 2)
+	assert.NoError(t, err)
+	assert.True(t, entity.IsLedgerBalanced(written), "confirm should write balanced ledger entries")
+	assert.Len(t, written, 2)
+	assert.Equal(t, int64(1), written[0].AccountID)
+	assert.Equal(t, valueobject.LedgerBucketReserved, written[0].Bucket)
+	assert.Equal(t, valueobject.LedgerDebit, written[0].Direction)
+	assert.Equal(t, int64(2), written[1].AccountID)
+	assert.Equal(t, valueobject.LedgerBucketAvailable, written[1].Bucket)
+	assert.Equal(t, valueobject.LedgerCredit, written[1].Direction)
+	for _, entry := range written {
+		assert.Equal(t, "tx-123", entry.TransactionID)
+	}
+}
+
+func TestReplayTransfer(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))
+	existing := &entity.TradeRecords{
+		TransactionID: "tx-123",
+		Nonce:         123,
+		FromAccountID: 1,
+		ToAccountID:   2,
+		Amount:        amount,
+		Status:        int32(valueobject.TccConfirmed),
+	}
+
+	tests := []struct {
+		name        string
+		record      *entity.TradeRecords
+		recordErr   error
+		to          int64
+		amount      valueobject.Money
+		expected    *entity.TradeRecords
+		expectedErr error
+	}{
+		{
+			name:      "new nonce - nothing to replay",
+			recordErr: gorm.ErrRecordNotFound,
+			to:        2,
+			amount:    amount,
+		},
+		{
+			name:     "same parameters - original trade returned",
+			record:   existing,
+			to:       2,
+			amount:   amount,
+			expected: existing,
+		},
+		{
+			name:        "different amount - conflict",
+			record:      existing,
+			to:          2,
+			amount:      valueobject.NewMoneyFromDecimal(decimal.NewFromInt(50)),
+			expectedErr: errors.New("nonce already used with different parameters"),
+		},
+		{
+			name:        "different recipient - conflict",
+			record:      existing,
+			to:          3,
+			amount:      amount,
+			expectedErr: errors.New("nonce already used with different parameters"),
+		},
+		{
+			name:        "lookup error",
+			recordErr:   errors.New("db error"),
+			to:          2,
+			amount:      amount,
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	svc := NewTransactionApplicationService()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := mock.NewMockUnitOfWork(ctrl)
+			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+			uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+			transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(tt.record, tt.recordErr).Times(1)
+
+			got, err := svc.ReplayTransfer(ctx, uow, 123, 1, tt.to, tt.amount)
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSettledTransactionReplay(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	record := func(status valueobject.TccStatus) *entity.TradeRecords {
+		return &entity.TradeRecords{
+			TransactionID: "tx-123",
+			Nonce:         123,
+			FromAccountID: 1,
+			ToAccountID:   2,
+			Amount:        valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)),
+			Status:        int32(status),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		stored      *entity.TradeRecords
+		run         func(svc TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error)
+		expectedErr error
+	}{
+		{
+			name:   "confirm retried after confirm returns original trade",
+			stored: record(valueobject.TccConfirmed),
+			run: func(svc TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error) {
+				return svc.ConfirmTransaction(ctx, uow, 123, 1, 2)
+			},
+		},
+		{
+			name:   "confirm after cancel is a conflict",
+			stored: record(valueobject.TccCanceled),
+			run: func(svc TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error) {
+				return svc.ConfirmTransaction(ctx, uow, 123, 1, 2)
+			},
+			expectedErr: errors.New("transaction already canceled"),
+		},
+		{
+			name:   "cancel retried after cancel returns original trade",
+			stored: record(valueobject.TccCanceled),
+			run: func(svc TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error) {
+				return svc.CancelTransaction(ctx, uow, 123, 1, 2, valueobject.CancelReasonClient)
+			},
+		},
+		{
+			name:   "cancel after confirm is a conflict",
+			stored: record(valueobject.TccConfirmed),
+			run: func(svc TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error) {
+				return svc.CancelTransaction(ctx, uow, 123, 1, 2, valueobject.CancelReasonClient)
+			},
+			expectedErr: errors.New("transaction already confirmed"),
+		},
+	}
+
+	svc := NewTransactionApplicationService()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := mock.NewMockUnitOfWork(ctrl)
+			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+			uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+			transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), valueobject.TccPending.Ptr()).Return(nil, gorm.ErrRecordNotFound).Times(1)
+			transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(tt.stored, nil).Times(1)
+
+			got, err := tt.run(svc, uow)
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.stored, got)
+		})
+	}
+}
+
+func TestConfirmTransactionSettledWhileWaitingForAccountLocks(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mock.NewMockUnitOfWork(ctrl)
+	accRepo := mock.NewMockAccountRepository(ctrl)
+	transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+	uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+	uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+
+	pending := &entity.TradeRecords{TransactionID: "tx-123", Nonce: 123, FromAccountID: 1, ToAccountID: 2, Amount: valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100)), Status: int32(valueobject.TccPending)}
+	confirmed := *pending
+	confirmed.Status = int32(valueobject.TccConfirmed)
+
+	gomock.InOrder(
+		transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), valueobject.TccPending.Ptr()).Return(pending, nil),
+		accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.NewFromInt(100)), nil),
+		accRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil),
+		transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), valueobject.TccPending.Ptr()).Return(nil, gorm.ErrRecordNotFound),
+		transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(&confirmed, nil),
+	)
+
+	got, err := NewTransactionApplicationService().ConfirmTransaction(ctx, uow, 123, 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &confirmed, got, "a confirm that lost the race replays the settled trade instead of moving the balance twice")
+}
+
+func TestMintTransaction(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mock.NewMockUnitOfWork(ctrl)
+	accRepo := mock.NewMockAccountRepository(ctrl)
+	transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+	eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+	ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+	uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+	uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+	uow.EXPECT().TransactionEventRepository().Return(eventRepo).AnyTimes()
+	uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+	lotRepo := mock.NewMockPointLotRepository(ctrl)
+	uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(500))
+	transRepo.EXPECT().GetTradeRecord(ctx, int64(7), valueobject.SystemAccountID, nil).Return(nil, gorm.ErrRecordNotFound).Times(1)
+	accRepo.EXPECT().GetAccount(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode).Return(dummyAccount(valueobject.SystemAccountID, decimal.Zero, decimal.Zero), nil).Times(1)
+	accRepo.EXPECT().GetAccount(ctx, int64(1), valueobject.DefaultAssetCode).Return(nil, gorm.ErrRecordNotFound).Times(1)
+	accRepo.EXPECT().CreateAccount(ctx, int64(1), valueobject.DefaultAssetCode).Return(nil).Times(1)
+	accRepo.EXPECT().CreditBalance(ctx, int64(1), amount).Return(nil).Times(1)
+	transRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+	eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
+
+	var lots []*entity.PointLot
+	lotRepo.EXPECT().CreatePointLots(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, created []*entity.PointLot) error {
+			lots = created
+			return nil
+		}).Times(1)
+
+	var written []*entity.LedgerEntry
+	ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, entries []*entity.LedgerEntry) error {
+			written = entries
+			return nil
+		}).Times(1)
+
+	expiresAt := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	trans, err := NewTransactionApplicationService().MintTransaction(ctx, uow, 7, 1, amount, "campaign", "ticket-42", &expiresAt)
+	assert.NoError(t, err)
+	assert.Equal(t, valueobject.TradeTypeMint, trans.TradeType)
+	assert.Equal(t, valueobject.SystemAccountID, trans.FromAccountID)
+	assert.Equal(t, int32(valueobject.TccConfirmed), trans.Status)
+	assert.Equal(t, "campaign", trans.ReasonCode)
+	assert.True(t, entity.IsLedgerBalanced(written), "mint should write balanced ledger entries")
+	assert.Equal(t, valueobject.LedgerBucketIssuance, written[0].Bucket)
+	assert.Len(t, lots, 1, "mint should open one lot")
+	assert.Equal(t, int64(1), lots[0].AccountID)
+	assert.True(t, lots[0].RemainingAmount.Equals(amount))
+	assert.Equal(t, &expiresAt, lots[0].ExpiresAt)
+	assert.Equal(t, trans.TransactionID, lots[0].TransactionID)
+}
+
+func TestBurnTransaction(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))
+
+	tests := []struct {
+		name        string
+		account     *entity.Account
+		accountErr  error
+		stored      *entity.TradeRecords
+		amount      valueobject.Money
+		reasonCode  string
+		expectBurn  bool
+		expectedErr error
+	}{
+		{
+			name:       "success",
+			account:    dummyAccount(1, decimal.NewFromInt(150), decimal.Zero),
+			amount:     amount,
+			reasonCode: "fraud",
+			expectBurn: true,
+		},
+		{
+			name:        "insufficient balance",
+			account:     dummyAccount(1, decimal.NewFromInt(50), decimal.Zero),
+			amount:      amount,
+			reasonCode:  "fraud",
+			expectedErr: errors.New("insufficient balance"),
+		},
+		{
+			name:        "account not found",
+			accountErr:  gorm.ErrRecordNotFound,
+			amount:      amount,
+			reasonCode:  "fraud",
+			expectedErr: errors.New("burn phase - get account"),
+		},
+		{
+			name:        "missing reason code",
+			amount:      amount,
+			expectedErr: errors.New("reason code is required"),
+		},
+		{
+			name:        "non-positive amount",
+			amount:      valueobject.Zero,
+			reasonCode:  "fraud",
+			expectedErr: errors.New("amount must be positive"),
+		},
+		{
+			name: "retried burn returns original trade",
+			stored: &entity.TradeRecords{
+				TransactionID: "tx-burn",
+				Nonce:         8,
+				FromAccountID: 1,
+				ToAccountID:   valueobject.SystemAccountID,
+				Amount:        amount,
+				Status:        int32(valueobject.TccConfirmed),
+				TradeType:     valueobject.TradeTypeBurn,
+			},
+			amount:     amount,
+			reasonCode: "fraud",
+		},
+		{
+			name: "nonce used by a transfer - conflict",
+			stored: &entity.TradeRecords{
+				TransactionID: "tx-transfer",
+				Nonce:         8,
+				FromAccountID: 1,
+				ToAccountID:   2,
+				Amount:        amount,
+				Status:        int32(valueobject.TccPending),
+				TradeType:     valueobject.TradeTypeTransfer,
+			},
+			amount:      amount,
+			reasonCode:  "fraud",
+			expectedErr: errors.New("nonce already used with different parameters"),
+		},
+	}
+
+	svc := NewTransactionApplicationService()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := mock.NewMockUnitOfWork(ctrl)
+			accRepo := mock.NewMockAccountRepository(ctrl)
+			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+			eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+			ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+			uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+			uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+			uow.EXPECT().TransactionEventRepository().Return(eventRepo).AnyTimes()
+			uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+			stubPointLots(ctrl, uow)
+
+			storedErr := error(nil)
+			if tt.stored == nil {
+				storedErr = gorm.ErrRecordNotFound
+			}
+			transRepo.EXPECT().GetTradeRecord(ctx, int64(8), int64(1), nil).Return(tt.stored, storedErr).MaxTimes(1)
+			if tt.stored == nil {
+				accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(tt.account, tt.accountErr).MaxTimes(1)
+			}
+			if tt.expectBurn {
+				accRepo.EXPECT().GetAccount(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode).Return(nil, gorm.ErrRecordNotFound).Times(1)
+				accRepo.EXPECT().CreateAccount(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode).Return(nil).Times(1)
+				accRepo.EXPECT().DebitBalance(ctx, int64(1), tt.amount).Return(nil).Times(1)
+				ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).Times(1)
+				transRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+				eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
+			}
+
+			trans, err := svc.BurnTransaction(ctx, uow, 8, 1, tt.amount, tt.reasonCode, "")
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			if tt.stored != nil {
+				assert.Equal(t, tt.stored, trans)
+				return
+			}
+			assert.Equal(t, valueobject.TradeTypeBurn, trans.TradeType)
+			assert.Equal(t, valueobject.SystemAccountID, trans.ToAccountID)
+			assert.Equal(t, int32(valueobject.TccConfirmed), trans.Status)
+		})
+	}
+}
+
+func dummyLot(id, accountID int64, remaining int64, expiresAt *time.Time) *entity.PointLot {
+	lot := entity.NewPointLot(accountID, "tx-lot", valueobject.NewMoneyFromDecimal(decimal.NewFromInt(remaining)), expiresAt)
+	lot.ID = id
+	return lot
+}
+
+func TestTransferTransactionReservesSoonestExpiringLots(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	soon, later, past := now.Add(24*time.Hour), now.Add(48*time.Hour), now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		amount      int64
+		expectedErr error
+		expected    []int64
+	}{
+		{name: "soonest expiring lot first", amount: 60, expected: []int64{2, 1}},
+		{name: "expired lot is not spendable", amount: 90, expectedErr: errors.New("insufficient balance")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uow := mock.NewMockUnitOfWork(ctrl)
+			accRepo := mock.NewMockAccountRepository(ctrl)
+			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+			eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+			ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+			lotRepo := mock.NewMockPointLotRepository(ctrl)
+			uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+			uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+			uow.EXPECT().TransactionEventRepository().Return(eventRepo).AnyTimes()
+			uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+			uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+
+			amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(tt.amount))
+			accRepo.EXPECT().GetAccount(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+			accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+			transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), nil).Return(nil, nil).Times(1)
+			lotRepo.EXPECT().ListPointLots(ctx, int64(1), valueobject.DefaultAssetCode).Return([]*entity.PointLot{
+				dummyLot(1, 1, 50, &later),
+				dummyLot(2, 1, 30, &soon),
+				dummyLot(3, 1, 20, &past),
+			}, nil).Times(1)
+
+			var reserved []*entity.LotAllocation
+			if tt.expectedErr == nil {
+				accRepo.EXPECT().ReserveBalance(ctx, int64(1), amount).Return(nil).Times(1)
+				lotRepo.EXPECT().ReservePointLots(ctx, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error {
+						reserved = allocations
+						return nil
+					}).Times(1)
+				ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).Times(1)
+				transRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+				eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
+			}
+
+			_, err := NewTransactionApplicationService().TransferTransaction(ctx, uow, 123, 1, 2, amount, now.Add(time.Minute))
+			if tt.expectedErr != nil {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			var lotIDs []int64
+			for _, allocation := range reserved {
+				lotIDs = append(lotIDs, allocation.LotID)
+			}
+			assert.Equal(t, tt.expected, lotIDs)
+			assert.True(t, entity.SumLotAllocations(reserved).Equals(amount))
+		})
+	}
+}
+
+func TestConfirmTransactionMovesLotsToReceiver(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mock.NewMockUnitOfWork(ctrl)
+	accRepo := mock.NewMockAccountRepository(ctrl)
+	transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+	eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+	ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+	lotRepo := mock.NewMockPointLotRepository(ctrl)
+	uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+	uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+	uow.EXPECT().TransactionEventRepository().Return(eventRepo).AnyTimes()
+	uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+	uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))
+	expiresAt := time.Now().Add(24 * time.Hour)
+	allocations := []*entity.LotAllocation{
+		{LotID: 5, Amount: valueobject.NewMoneyFromDecimal(decimal.NewFromInt(70)), ExpiresAt: &expiresAt},
+	}
+	trans := &entity.TradeRecords{TransactionID: "tx-123", FromAccountID: 1, ToAccountID: 2, Amount: amount, Status: int32(valueobject.TccPending)}
+	transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), gomock.Any()).Return(trans, nil).Times(2)
+	accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.Zero, decimal.Zero), nil).Times(1)
+	accRepo.EXPECT().GetAccountForUpdate(ctx, int64(2), valueobject.DefaultAssetCode).Return(dummyAccount(2, decimal.Zero, decimal.Zero), nil).Times(1)
+	accRepo.EXPECT().UnreserveBalance(ctx, int64(1), int64(2), amount).Return(nil).Times(1)
+	lotRepo.EXPECT().GetLotAllocations(ctx, "tx-123").Return(allocations, nil).Times(1)
+	lotRepo.EXPECT().SettlePointLots(ctx, "tx-123", allocations).Return(nil).Times(1)
+	ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).Times(1)
+	transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(nil).Times(1)
+	eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
+
+	var received []*entity.PointLot
+	lotRepo.EXPECT().CreatePointLots(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, lots []*entity.PointLot) error {
+			received = lots
+			return nil
+		}).Times(1)
+
+	_, err := NewTransactionApplicationService().ConfirmTransaction(ctx, uow, 123, 1, 2)
+	assert.NoError(t, err)
+	assert.Len(t, received, 2, "allocated lots keep their expiry and the uncovered rest never expires")
+	assert.Equal(t, int64(2), received[0].AccountID)
+	assert.Equal(t, &expiresAt, received[0].ExpiresAt)
+	assert.True(t, received[0].RemainingAmount.Equals(valueobject.NewMoneyFromDecimal(decimal.NewFromInt(70))))
+	assert.Nil(t, received[1].ExpiresAt)
+	assert.True(t, received[1].RemainingAmount.Equals(valueobject.NewMoneyFromDecimal(decimal.NewFromInt(30))))
+}
+
+// Transfers reserved before point lots existed have no allocations, so
+// settling them has to open a lot for the whole amount or the lots would no
+// longer add up to the balances.
+func TestSettleTransactionWithoutLotAllocations(t *testing.T) {
+	ctx := context.Background()
+	amount := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(100))
+
+	tests := []struct {
+		name           string
+		settle         func(ts TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error)
+		unreserveTo    int64
+		lockedAccounts []int64
+		expectedOwner  int64
+	}{
+		{
+			name: "confirm opens the lot for the receiver",
+			settle: func(ts TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error) {
+				return ts.ConfirmTransaction(ctx, uow, 123, 1, 2)
+			},
+			unreserveTo:    2,
+			lockedAccounts: []int64{1, 2},
+			expectedOwner:  2,
+		},
+		{
+			name: "cancel opens the lot for the sender",
+			settle: func(ts TransactionApplicationService, uow *mock.MockUnitOfWork) (*entity.TradeRecords, error) {
+				return ts.CancelTransaction(ctx, uow, 123, 1, 2, valueobject.CancelReasonClient)
+			},
+			unreserveTo:    1,
+			lockedAccounts: []int64{1},
+			expectedOwner:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uow := mock.NewMockUnitOfWork(ctrl)
+			accRepo := mock.NewMockAccountRepository(ctrl)
+			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+			eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+			ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+			lotRepo := mock.NewMockPointLotRepository(ctrl)
+			uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+			uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+			uow.EXPECT().TransactionEventRepository().Return(eventRepo).AnyTimes()
+			uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+			uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+
+			trans := &entity.TradeRecords{TransactionID: "tx-123", FromAccountID: 1, ToAccountID: 2, Amount: amount, Status: int32(valueobject.TccPending)}
+			transRepo.EXPECT().GetTradeRecord(ctx, int64(123), int64(1), gomock.Any()).Return(trans, nil).Times(2)
+			for _, id := range tt.lockedAccounts {
+				accRepo.EXPECT().GetAccountForUpdate(ctx, id, valueobject.DefaultAssetCode).Return(dummyAccount(id, decimal.Zero, decimal.Zero), nil).Times(1)
+			}
+			accRepo.EXPECT().UnreserveBalance(ctx, int64(1), tt.unreserveTo, amount).Return(nil).Times(1)
+			lotRepo.EXPECT().GetLotAllocations(ctx, "tx-123").Return(nil, nil).Times(1)
+			lotRepo.EXPECT().SettlePointLots(ctx, "tx-123", gomock.Nil()).Return(nil).AnyTimes()
+			lotRepo.EXPECT().ReleasePointLots(ctx, "tx-123", gomock.Nil()).Return(nil).AnyTimes()
+			ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).Return(nil).Times(1)
+			transRepo.EXPECT().UpdateTradeRecord(ctx, trans).Return(nil).Times(1)
+			eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).Return(nil).Times(1)
+
+			var opened []*entity.PointLot
+			lotRepo.EXPECT().CreatePointLots(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, lots []*entity.PointLot) error {
+					opened = lots
+					return nil
+				}).Times(1)
+
+			_, err := tt.settle(NewTransactionApplicationService(), uow)
+			assert.NoError(t, err)
+			if assert.Len(t, opened, 1) {
+				assert.Equal(t, tt.expectedOwner, opened[0].AccountID)
+				assert.Nil(t, opened[0].ExpiresAt)
+				assert.True(t, opened[0].RemainingAmount.Equals(amount))
+			}
+		})
+	}
+}
+
+func TestExpirePointLots(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		lots         []*entity.PointLot
+		expectExpire bool
+	}{
+		{
+			name:         "expired lot is burned",
+			lots:         []*entity.PointLot{dummyLot(1, 1, 20, &past), dummyLot(2, 1, 80, &future)},
+			expectExpire: true,
+		},
+		{
+			name: "nothing expired",
+			lots: []*entity.PointLot{dummyLot(2, 1, 80, &future), dummyLot(3, 1, 20, nil)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			uow := mock.NewMockUnitOfWork(ctrl)
+			accRepo := mock.NewMockAccountRepository(ctrl)
+			transRepo := mock.NewMockTradeRecordsRepository(ctrl)
+			eventRepo := mock.NewMockTransactionEventRepository(ctrl)
+			ledgerRepo := mock.NewMockLedgerRepository(ctrl)
+			lotRepo := mock.NewMockPointLotRepository(ctrl)
+			uow.EXPECT().AccountRepository().Return(accRepo).AnyTimes()
+			uow.EXPECT().TradeRecordsRepository().Return(transRepo).AnyTimes()
+			uow.EXPECT().TransactionEventRepository().Return(eventRepo).AnyTimes()
+			uow.EXPECT().LedgerRepository().Return(ledgerRepo).AnyTimes()
+			uow.EXPECT().PointLotRepository().Return(lotRepo).AnyTimes()
+
+			expired := valueobject.NewMoneyFromDecimal(decimal.NewFromInt(20))
+			accRepo.EXPECT().GetAccountForUpdate(ctx, int64(1), valueobject.DefaultAssetCode).Return(dummyAccount(1, decimal.NewFromInt(100), decimal.Zero), nil).Times(1)
+			lotRepo.EXPECT().ListPointLots(ctx, int64(1), valueobject.DefaultAssetCode).Return(tt.lots, nil).Times(1)
+
+			var eventType string
+			var written []*entity.LedgerEntry
+			if tt.expectExpire {
+				accRepo.EXPECT().GetAccount(ctx, valueobject.SystemAccountID, valueobject.DefaultAssetCode).Return(dummyAccount(valueobject.SystemAccountID, decimal.Zero, decimal.Zero), nil).Times(1)
+				accRepo.EXPECT().DebitBalance(ctx, int64(1), expired).Return(nil).Times(1)
+				lotRepo.EXPECT().ConsumePointLots(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, allocations []*entity.LotAllocation) error {
+						assert.Len(t, allocations, 1)
+						assert.Equal(t, int64(1), allocations[0].LotID)
+						return nil
+					}).Times(1)
+				ledgerRepo.EXPECT().CreateLedgerEntries(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, entries []*entity.LedgerEntry) error {
+						written = entries
+						return nil
+					}).Times(1)
+				transRepo.EXPECT().CreateTradeRecord(ctx, gomock.Any()).Return(nil).Times(1)
+				eventRepo.EXPECT().CreateTransactionEvent(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, evt *entity.TransactionEvent) error {
+						eventType = evt.EventType
+						return nil
+					}).Times(1)
+			}
+
+			trans, err := NewTransactionApplicationService().ExpirePointLots(ctx, uow, 1, valueobject.DefaultAssetCode, now)
+			assert.NoError(t, err)
+			if !tt.expectExpire {
+				assert.Nil(t, trans)
+				return
+			}
+			assert.Equal(t, valueobject.TradeTypeExpire, trans.TradeType)
+			assert.Equal(t, valueobject.SystemAccountID, trans.ToAccountID)
+			assert.Equal(t, int64(-1), trans.Nonce, "expiry trades take the negated lowest burned lot id")
+			assert.True(t, trans.Amount.Equals(expired))
+			assert.Equal(t, "expired", eventType)
+			assert.True(t, entity.IsLedgerBalanced(written))
+			assert.Equal(t, valueobject.LedgerEntryExpire, written[0].EntryType)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_trade_records_status_expired_at;
+
+ALTER TABLE public.trade_records
+    DROP COLUMN IF EXISTS expired_at;
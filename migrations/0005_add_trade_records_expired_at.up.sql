@@ -0,0 +1,5 @@
+ALTER TABLE public.trade_records
+    ADD COLUMN IF NOT EXISTS expired_at TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_trade_records_status_expired_at
+    ON public.trade_records (status, expired_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transaction_event_unpublished;
+
+ALTER TABLE public.transaction_event
+    DROP COLUMN IF EXISTS published_at;
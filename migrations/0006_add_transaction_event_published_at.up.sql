@@ -0,0 +1,6 @@
+ALTER TABLE public.transaction_event
+    ADD COLUMN IF NOT EXISTS published_at TIMESTAMP WITHOUT TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_transaction_event_unpublished
+    ON public.transaction_event (id)
+    WHERE published_at IS NULL;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS public.ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id UUID NOT NULL,
+    account_id BIGINT NOT NULL,
+    bucket VARCHAR(20) NOT NULL,
+    entry_type VARCHAR(20) NOT NULL,
+    direction VARCHAR(6) NOT NULL,
+    amount NUMERIC(18,2) NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (direction IN ('debit', 'credit')),
+    CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account_bucket
+    ON public.ledger_entries (account_id, bucket);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id
+    ON public.ledger_entries (transaction_id);
+
+-- Opening balances for accounts that existed before the ledger, booked against
+-- the system issuance account (account_id 0).
+INSERT INTO public.ledger_entries (transaction_id, account_id, bucket, entry_type, direction, amount)
+SELECT '00000000-0000-0000-0000-000000000000', 0, 'issuance', 'opening', 'debit', available_balance
+FROM public.account WHERE available_balance > 0
+UNION ALL
+SELECT '00000000-0000-0000-0000-000000000000', user_id, 'available', 'opening', 'credit', available_balance
+FROM public.account WHERE available_balance > 0
+UNION ALL
+SELECT '00000000-0000-0000-0000-000000000000', 0, 'issuance', 'opening', 'debit', reserved_balance
+FROM public.account WHERE reserved_balance > 0
+UNION ALL
+SELECT '00000000-0000-0000-0000-000000000000', user_id, 'reserved', 'opening', 'credit', reserved_balance
+FROM public.account WHERE reserved_balance > 0;
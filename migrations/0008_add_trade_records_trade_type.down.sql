@@ -0,0 +1,6 @@
+ALTER TABLE public.trade_records DROP CONSTRAINT IF EXISTS chk_trade_records_trade_type;
+
+ALTER TABLE public.trade_records
+    DROP COLUMN IF EXISTS external_reference,
+    DROP COLUMN IF EXISTS reason_code,
+    DROP COLUMN IF EXISTS trade_type;
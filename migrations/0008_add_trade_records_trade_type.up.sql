@@ -0,0 +1,12 @@
+-- Minted and burned points are booked against the system account.
+INSERT INTO public.account (user_id, available_balance, reserved_balance)
+VALUES (0, 0, 0)
+ON CONFLICT (user_id) DO NOTHING;
+
+ALTER TABLE public.trade_records
+    ADD COLUMN IF NOT EXISTS trade_type VARCHAR(20) NOT NULL DEFAULT 'transfer',
+    ADD COLUMN IF NOT EXISTS reason_code VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS external_reference VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE public.trade_records
+    ADD CONSTRAINT chk_trade_records_trade_type CHECK (trade_type IN ('transfer', 'mint', 'burn'));
@@ -0,0 +1,24 @@
+-- Only default-asset data fits the single-balance schema.
+DELETE FROM public.ledger_entries WHERE asset_code <> 'POINTS';
+DELETE FROM public.transaction_event
+WHERE transaction_id IN (SELECT transaction_id FROM public.trade_records WHERE asset_code <> 'POINTS');
+DELETE FROM public.trade_records WHERE asset_code <> 'POINTS';
+DELETE FROM public.account WHERE asset_code <> 'POINTS';
+
+DROP INDEX IF EXISTS idx_ledger_entries_account_asset_bucket;
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account_bucket
+    ON public.ledger_entries (account_id, bucket);
+ALTER TABLE public.ledger_entries DROP COLUMN IF EXISTS asset_code;
+
+ALTER TABLE public.trade_records
+    DROP CONSTRAINT IF EXISTS fk_from_account,
+    DROP CONSTRAINT IF EXISTS fk_to_account;
+ALTER TABLE public.trade_records DROP COLUMN IF EXISTS asset_code;
+
+ALTER TABLE public.account DROP CONSTRAINT account_pkey;
+ALTER TABLE public.account ADD CONSTRAINT account_pkey PRIMARY KEY (user_id);
+ALTER TABLE public.account DROP COLUMN IF EXISTS asset_code;
+
+ALTER TABLE public.trade_records
+    ADD CONSTRAINT fk_from_account FOREIGN KEY (from_account_id) REFERENCES public.account(user_id),
+    ADD CONSTRAINT fk_to_account FOREIGN KEY (to_account_id) REFERENCES public.account(user_id);
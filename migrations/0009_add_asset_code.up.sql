@@ -0,0 +1,24 @@
+-- Balances are keyed by (user_id, asset_code); existing rows move to the default asset.
+ALTER TABLE public.trade_records
+    DROP CONSTRAINT IF EXISTS fk_from_account,
+    DROP CONSTRAINT IF EXISTS fk_to_account;
+
+ALTER TABLE public.account
+    ADD COLUMN IF NOT EXISTS asset_code VARCHAR(16) NOT NULL DEFAULT 'POINTS';
+
+ALTER TABLE public.account DROP CONSTRAINT account_pkey;
+ALTER TABLE public.account ADD CONSTRAINT account_pkey PRIMARY KEY (user_id, asset_code);
+
+ALTER TABLE public.trade_records
+    ADD COLUMN IF NOT EXISTS asset_code VARCHAR(16) NOT NULL DEFAULT 'POINTS';
+
+ALTER TABLE public.trade_records
+    ADD CONSTRAINT fk_from_account FOREIGN KEY (from_account_id, asset_code) REFERENCES public.account(user_id, asset_code),
+    ADD CONSTRAINT fk_to_account FOREIGN KEY (to_account_id, asset_code) REFERENCES public.account(user_id, asset_code);
+
+ALTER TABLE public.ledger_entries
+    ADD COLUMN IF NOT EXISTS asset_code VARCHAR(16) NOT NULL DEFAULT 'POINTS';
+
+DROP INDEX IF EXISTS idx_ledger_entries_account_bucket;
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account_asset_bucket
+    ON public.ledger_entries (account_id, asset_code, bucket);
@@ -0,0 +1,10 @@
+DELETE FROM public.transaction_event
+WHERE transaction_id IN (SELECT transaction_id FROM public.trade_records WHERE trade_type = 'expire');
+DELETE FROM public.trade_records WHERE trade_type = 'expire';
+
+ALTER TABLE public.trade_records DROP CONSTRAINT IF EXISTS chk_trade_records_trade_type;
+ALTER TABLE public.trade_records
+    ADD CONSTRAINT chk_trade_records_trade_type CHECK (trade_type IN ('transfer', 'mint', 'burn'));
+
+DROP TABLE IF EXISTS public.point_lot_allocations;
+DROP TABLE IF EXISTS public.point_lots;
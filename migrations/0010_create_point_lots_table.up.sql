@@ -0,0 +1,40 @@
+-- Every credit opens a lot; debits and reservations consume lots soonest-expiring first.
+CREATE TABLE IF NOT EXISTS public.point_lots (
+    id BIGSERIAL PRIMARY KEY,
+    account_id BIGINT NOT NULL,
+    asset_code VARCHAR(16) NOT NULL DEFAULT 'POINTS',
+    transaction_id UUID NOT NULL,
+    amount NUMERIC(18,2) NOT NULL,
+    remaining_amount NUMERIC(18,2) NOT NULL,
+    reserved_amount NUMERIC(18,2) NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITHOUT TIME ZONE,
+    created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_point_lots_account FOREIGN KEY (account_id, asset_code) REFERENCES public.account(user_id, asset_code),
+    CHECK (amount > 0),
+    CHECK (remaining_amount >= 0),
+    CHECK (reserved_amount >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_point_lots_account_asset
+    ON public.point_lots (account_id, asset_code, expires_at) WHERE remaining_amount > 0 OR reserved_amount > 0;
+
+CREATE INDEX IF NOT EXISTS idx_point_lots_expires_at
+    ON public.point_lots (expires_at) WHERE remaining_amount > 0;
+
+-- Lots held by pending transfers, settled or released with the transfer.
+CREATE TABLE IF NOT EXISTS public.point_lot_allocations (
+    transaction_id UUID NOT NULL,
+    lot_id BIGINT NOT NULL REFERENCES public.point_lots(id),
+    amount NUMERIC(18,2) NOT NULL,
+    PRIMARY KEY (transaction_id, lot_id),
+    CHECK (amount > 0)
+);
+
+-- Existing available balances become opening lots that never expire.
+INSERT INTO public.point_lots (account_id, asset_code, transaction_id, amount, remaining_amount)
+SELECT user_id, asset_code, '00000000-0000-0000-0000-000000000000', available_balance, available_balance
+FROM public.account WHERE available_balance > 0 AND user_id <> 0;
+
+ALTER TABLE public.trade_records DROP CONSTRAINT IF EXISTS chk_trade_records_trade_type;
+ALTER TABLE public.trade_records
+    ADD CONSTRAINT chk_trade_records_trade_type CHECK (trade_type IN ('transfer', 'mint', 'burn', 'expire'));
@@ -0,0 +1 @@
+ALTER TABLE public.account DROP COLUMN IF EXISTS fencing_token;
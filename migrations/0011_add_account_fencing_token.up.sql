@@ -0,0 +1,4 @@
+-- Fencing token of the last lock holder that wrote each account; writes
+-- carrying an older token are rejected.
+ALTER TABLE public.account
+    ADD COLUMN IF NOT EXISTS fencing_token BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+DROP SEQUENCE IF EXISTS public.lock_fencing_token_seq;
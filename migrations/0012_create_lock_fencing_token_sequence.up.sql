@@ -0,0 +1,8 @@
+-- Every lock backend draws its fencing tokens from this sequence, so tokens
+-- keep growing across restarts and lock backend changes. It starts above the
+-- newest token already recorded on an account.
+CREATE SEQUENCE IF NOT EXISTS public.lock_fencing_token_seq;
+
+SELECT setval('public.lock_fencing_token_seq', max_token)
+FROM (SELECT MAX(fencing_token) AS max_token FROM public.account) AS recorded
+WHERE max_token > 0;
@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations so the points binary can
+// apply them without the migrate CLI.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: D:/Practice/go-practice/points/internal/domain/access_policy.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	valueobject "points/internal/domain/valueobject"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccessPolicy is a mock of AccessPolicy interface.
+type MockAccessPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessPolicyMockRecorder
+}
+
+// MockAccessPolicyMockRecorder is the mock recorder for MockAccessPolicy.
+type MockAccessPolicyMockRecorder struct {
+	mock *MockAccessPolicy
+}
+
+// NewMockAccessPolicy creates a new mock instance.
+func NewMockAccessPolicy(ctrl *gomock.Controller) *MockAccessPolicy {
+	mock := &MockAccessPolicy{ctrl: ctrl}
+	mock.recorder = &MockAccessPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessPolicy) EXPECT() *MockAccessPolicyMockRecorder {
+	return m.recorder
+}
+
+// Permissions mocks base method.
+func (m *MockAccessPolicy) Permissions(principal *valueobject.Principal) []valueobject.Permission {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Permissions", principal)
+	ret0, _ := ret[0].([]valueobject.Permission)
+	return ret0
+}
+
+// Permissions indicates an expected call of Permissions.
+func (mr *MockAccessPolicyMockRecorder) Permissions(principal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Permissions", reflect.TypeOf((*MockAccessPolicy)(nil).Permissions), principal)
+}
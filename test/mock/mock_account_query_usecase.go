@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: D:/Practice/go-practice/points/internal/domain/account_query_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	entity "points/internal/domain/entity"
+	valueobject "points/internal/domain/valueobject"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAccountQueryUsecase is a mock of AccountQueryUsecase interface.
+type MockAccountQueryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountQueryUsecaseMockRecorder
+}
+
+// MockAccountQueryUsecaseMockRecorder is the mock recorder for MockAccountQueryUsecase.
+type MockAccountQueryUsecaseMockRecorder struct {
+	mock *MockAccountQueryUsecase
+}
+
+// NewMockAccountQueryUsecase creates a new mock instance.
+func NewMockAccountQueryUsecase(ctrl *gomock.Controller) *MockAccountQueryUsecase {
+	mock := &MockAccountQueryUsecase{ctrl: ctrl}
+	mock.recorder = &MockAccountQueryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountQueryUsecase) EXPECT() *MockAccountQueryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockAccountQueryUsecase) GetAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, userID, asset)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockAccountQueryUsecaseMockRecorder) GetAccount(ctx, userID, asset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountQueryUsecase)(nil).GetAccount), ctx, userID, asset)
+}
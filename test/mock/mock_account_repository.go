@@ -37,32 +37,105 @@ func (m *MockAccountRepository) EXPECT() *MockAccountRepositoryMockRecorder {
 }
 
 // CreateAccount mocks base method.
-func (m *MockAccountRepository) CreateAccount(ctx context.Context, userID int64) error {
+func (m *MockAccountRepository) CreateAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", ctx, userID)
+	ret := m.ctrl.Call(m, "CreateAccount", ctx, userID, asset)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockAccountRepositoryMockRecorder) CreateAccount(ctx, userID interface{}) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) CreateAccount(ctx, userID, asset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccountRepository)(nil).CreateAccount), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockAccountRepository)(nil).CreateAccount), ctx, userID, asset)
+}
+
+// CreditBalance mocks base method.
+func (m *MockAccountRepository) CreditBalance(ctx context.Context, userID int64, amount valueobject.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreditBalance", ctx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreditBalance indicates an expected call of CreditBalance.
+func (mr *MockAccountRepositoryMockRecorder) CreditBalance(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreditBalance", reflect.TypeOf((*MockAccountRepository)(nil).CreditBalance), ctx, userID, amount)
+}
+
+// DebitBalance mocks base method.
+func (m *MockAccountRepository) DebitBalance(ctx context.Context, userID int64, amount valueobject.Money) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DebitBalance", ctx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DebitBalance indicates an expected call of DebitBalance.
+func (mr *MockAccountRepositoryMockRecorder) DebitBalance(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebitBalance", reflect.TypeOf((*MockAccountRepository)(nil).DebitBalance), ctx, userID, amount)
 }
 
 // GetAccount mocks base method.
-func (m *MockAccountRepository) GetAccount(ctx context.Context, userID int64) (*entity.Account, error) {
+func (m *MockAccountRepository) GetAccount(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", ctx, userID)
+	ret := m.ctrl.Call(m, "GetAccount", ctx, userID, asset)
 	ret0, _ := ret[0].(*entity.Account)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAccount indicates an expected call of GetAccount.
-func (mr *MockAccountRepositoryMockRecorder) GetAccount(ctx, userID interface{}) *gomock.Call {
+func (mr *MockAccountRepositoryMockRecorder) GetAccount(ctx, userID, asset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountRepository)(nil).GetAccount), ctx, userID, asset)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockAccountRepository) GetAccountForUpdate(ctx context.Context, userID int64, asset valueobject.AssetCode) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", ctx, userID, asset)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockAccountRepositoryMockRecorder) GetAccountForUpdate(ctx, userID, asset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockAccountRepository)(nil).GetAccountForUpdate), ctx, userID, asset)
+}
+
+// ListAccounts mocks base method.
+func (m *MockAccountRepository) ListAccounts(ctx context.Context) ([]*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", ctx)
+	ret0, _ := ret[0].([]*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockAccountRepositoryMockRecorder) ListAccounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockAccountRepository)(nil).ListAccounts), ctx)
+}
+
+// ListUserAccounts mocks base method.
+func (m *MockAccountRepository) ListUserAccounts(ctx context.Context, userID int64) ([]*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserAccounts", ctx, userID)
+	ret0, _ := ret[0].([]*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserAccounts indicates an expected call of ListUserAccounts.
+func (mr *MockAccountRepositoryMockRecorder) ListUserAccounts(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockAccountRepository)(nil).GetAccount), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserAccounts", reflect.TypeOf((*MockAccountRepository)(nil).ListUserAccounts), ctx, userID)
 }
 
 // ReserveBalance mocks base method.
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: D:/Practice/go-practice/points/internal/domain/admin_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	command "points/internal/domain/command"
+	entity "points/internal/domain/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdminUsecase is a mock of AdminUsecase interface.
+type MockAdminUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminUsecaseMockRecorder
+}
+
+// MockAdminUsecaseMockRecorder is the mock recorder for MockAdminUsecase.
+type MockAdminUsecaseMockRecorder struct {
+	mock *MockAdminUsecase
+}
+
+// NewMockAdminUsecase creates a new mock instance.
+func NewMockAdminUsecase(ctrl *gomock.Controller) *MockAdminUsecase {
+	mock := &MockAdminUsecase{ctrl: ctrl}
+	mock.recorder = &MockAdminUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminUsecase) EXPECT() *MockAdminUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Burn mocks base method.
+func (m *MockAdminUsecase) Burn(ctx context.Context, req *command.BurnCommand) (*entity.TradeRecords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Burn", ctx, req)
+	ret0, _ := ret[0].(*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Burn indicates an expected call of Burn.
+func (mr *MockAdminUsecaseMockRecorder) Burn(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Burn", reflect.TypeOf((*MockAdminUsecase)(nil).Burn), ctx, req)
+}
+
+// Mint mocks base method.
+func (m *MockAdminUsecase) Mint(ctx context.Context, req *command.MintCommand) (*entity.TradeRecords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Mint", ctx, req)
+	ret0, _ := ret[0].(*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Mint indicates an expected call of Mint.
+func (mr *MockAdminUsecaseMockRecorder) Mint(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Mint", reflect.TypeOf((*MockAdminUsecase)(nil).Mint), ctx, req)
+}
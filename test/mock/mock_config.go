@@ -116,6 +116,18 @@ func (mr *MockConfigMockRecorder) Sub(key interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sub", reflect.TypeOf((*MockConfig)(nil).Sub), key)
 }
 
+// Subscribe mocks base method.
+func (m *MockConfig) Subscribe(fn func(port.ConfigChange)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Subscribe", fn)
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockConfigMockRecorder) Subscribe(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockConfig)(nil).Subscribe), fn)
+}
+
 // Unmarshal mocks base method.
 func (m *MockConfig) Unmarshal(out interface{}) error {
 	m.ctrl.T.Helper()
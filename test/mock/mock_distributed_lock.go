@@ -51,6 +51,21 @@ func (mr *MockLockerMockRecorder) Acquire(ctx, key, lockDuration, retryInterval
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockLocker)(nil).Acquire), ctx, key, lockDuration, retryInterval)
 }
 
+// AcquireAll mocks base method.
+func (m *MockLocker) AcquireAll(ctx context.Context, keys []string, lockDuration, retryInterval time.Duration) (domain.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireAll", ctx, keys, lockDuration, retryInterval)
+	ret0, _ := ret[0].(domain.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireAll indicates an expected call of AcquireAll.
+func (mr *MockLockerMockRecorder) AcquireAll(ctx, keys, lockDuration, retryInterval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireAll", reflect.TypeOf((*MockLocker)(nil).AcquireAll), ctx, keys, lockDuration, retryInterval)
+}
+
 // MockLock is a mock of Lock interface.
 type MockLock struct {
 	ctrl     *gomock.Controller
@@ -101,3 +116,17 @@ func (mr *MockLockMockRecorder) Renew(ctx, ttl interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockLock)(nil).Renew), ctx, ttl)
 }
+
+// Token mocks base method.
+func (m *MockLock) Token() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Token")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// Token indicates an expected call of Token.
+func (mr *MockLockMockRecorder) Token() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockLock)(nil).Token))
+}
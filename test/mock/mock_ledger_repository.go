@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: D:/Practice/go-practice/points/internal/domain/repository/ledger_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	entity "points/internal/domain/entity"
+	valueobject "points/internal/domain/valueobject"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateLedgerEntries mocks base method.
+func (m *MockLedgerRepository) CreateLedgerEntries(ctx context.Context, entries []*entity.LedgerEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLedgerEntries", ctx, entries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateLedgerEntries indicates an expected call of CreateLedgerEntries.
+func (mr *MockLedgerRepositoryMockRecorder) CreateLedgerEntries(ctx, entries interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLedgerEntries", reflect.TypeOf((*MockLedgerRepository)(nil).CreateLedgerEntries), ctx, entries)
+}
+
+// GetLedgerBalance mocks base method.
+func (m *MockLedgerRepository) GetLedgerBalance(ctx context.Context, accountID int64, asset valueobject.AssetCode, bucket valueobject.LedgerBucket) (valueobject.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerBalance", ctx, accountID, asset, bucket)
+	ret0, _ := ret[0].(valueobject.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerBalance indicates an expected call of GetLedgerBalance.
+func (mr *MockLedgerRepositoryMockRecorder) GetLedgerBalance(ctx, accountID, asset, bucket interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerBalance", reflect.TypeOf((*MockLedgerRepository)(nil).GetLedgerBalance), ctx, accountID, asset, bucket)
+}
+
+// GetLedgerEntries mocks base method.
+func (m *MockLedgerRepository) GetLedgerEntries(ctx context.Context, transactionID string) ([]*entity.LedgerEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerEntries", ctx, transactionID)
+	ret0, _ := ret[0].([]*entity.LedgerEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLedgerEntries indicates an expected call of GetLedgerEntries.
+func (mr *MockLedgerRepositoryMockRecorder) GetLedgerEntries(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerEntries", reflect.TypeOf((*MockLedgerRepository)(nil).GetLedgerEntries), ctx, transactionID)
+}
@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: D:/Practice/go-practice/points/internal/domain/repository/point_lot_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	entity "points/internal/domain/entity"
+	valueobject "points/internal/domain/valueobject"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPointLotRepository is a mock of PointLotRepository interface.
+type MockPointLotRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPointLotRepositoryMockRecorder
+}
+
+// MockPointLotRepositoryMockRecorder is the mock recorder for MockPointLotRepository.
+type MockPointLotRepositoryMockRecorder struct {
+	mock *MockPointLotRepository
+}
+
+// NewMockPointLotRepository creates a new mock instance.
+func NewMockPointLotRepository(ctrl *gomock.Controller) *MockPointLotRepository {
+	mock := &MockPointLotRepository{ctrl: ctrl}
+	mock.recorder = &MockPointLotRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPointLotRepository) EXPECT() *MockPointLotRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumePointLots mocks base method.
+func (m *MockPointLotRepository) ConsumePointLots(ctx context.Context, allocations []*entity.LotAllocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumePointLots", ctx, allocations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumePointLots indicates an expected call of ConsumePointLots.
+func (mr *MockPointLotRepositoryMockRecorder) ConsumePointLots(ctx, allocations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumePointLots", reflect.TypeOf((*MockPointLotRepository)(nil).ConsumePointLots), ctx, allocations)
+}
+
+// CreatePointLots mocks base method.
+func (m *MockPointLotRepository) CreatePointLots(ctx context.Context, lots []*entity.PointLot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePointLots", ctx, lots)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePointLots indicates an expected call of CreatePointLots.
+func (mr *MockPointLotRepositoryMockRecorder) CreatePointLots(ctx, lots interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePointLots", reflect.TypeOf((*MockPointLotRepository)(nil).CreatePointLots), ctx, lots)
+}
+
+// GetLotAllocations mocks base method.
+func (m *MockPointLotRepository) GetLotAllocations(ctx context.Context, transactionID string) ([]*entity.LotAllocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLotAllocations", ctx, transactionID)
+	ret0, _ := ret[0].([]*entity.LotAllocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLotAllocations indicates an expected call of GetLotAllocations.
+func (mr *MockPointLotRepositoryMockRecorder) GetLotAllocations(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLotAllocations", reflect.TypeOf((*MockPointLotRepository)(nil).GetLotAllocations), ctx, transactionID)
+}
+
+// ListExpiredPointLots mocks base method.
+func (m *MockPointLotRepository) ListExpiredPointLots(ctx context.Context, now time.Time, limit int) ([]*entity.PointLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredPointLots", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.PointLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredPointLots indicates an expected call of ListExpiredPointLots.
+func (mr *MockPointLotRepositoryMockRecorder) ListExpiredPointLots(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredPointLots", reflect.TypeOf((*MockPointLotRepository)(nil).ListExpiredPointLots), ctx, now, limit)
+}
+
+// ListPointLots mocks base method.
+func (m *MockPointLotRepository) ListPointLots(ctx context.Context, accountID int64, asset valueobject.AssetCode) ([]*entity.PointLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPointLots", ctx, accountID, asset)
+	ret0, _ := ret[0].([]*entity.PointLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPointLots indicates an expected call of ListPointLots.
+func (mr *MockPointLotRepositoryMockRecorder) ListPointLots(ctx, accountID, asset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPointLots", reflect.TypeOf((*MockPointLotRepository)(nil).ListPointLots), ctx, accountID, asset)
+}
+
+// ReleasePointLots mocks base method.
+func (m *MockPointLotRepository) ReleasePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleasePointLots", ctx, transactionID, allocations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleasePointLots indicates an expected call of ReleasePointLots.
+func (mr *MockPointLotRepositoryMockRecorder) ReleasePointLots(ctx, transactionID, allocations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasePointLots", reflect.TypeOf((*MockPointLotRepository)(nil).ReleasePointLots), ctx, transactionID, allocations)
+}
+
+// ReservePointLots mocks base method.
+func (m *MockPointLotRepository) ReservePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReservePointLots", ctx, transactionID, allocations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReservePointLots indicates an expected call of ReservePointLots.
+func (mr *MockPointLotRepositoryMockRecorder) ReservePointLots(ctx, transactionID, allocations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReservePointLots", reflect.TypeOf((*MockPointLotRepository)(nil).ReservePointLots), ctx, transactionID, allocations)
+}
+
+// SettlePointLots mocks base method.
+func (m *MockPointLotRepository) SettlePointLots(ctx context.Context, transactionID string, allocations []*entity.LotAllocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettlePointLots", ctx, transactionID, allocations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SettlePointLots indicates an expected call of SettlePointLots.
+func (mr *MockPointLotRepositoryMockRecorder) SettlePointLots(ctx, transactionID, allocations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettlePointLots", reflect.TypeOf((*MockPointLotRepository)(nil).SettlePointLots), ctx, transactionID, allocations)
+}
@@ -88,6 +88,18 @@ func (mr *MockSettingsManagerMockRecorder) Sub(key interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sub", reflect.TypeOf((*MockSettingsManager)(nil).Sub), key)
 }
 
+// Subscribe mocks base method.
+func (m *MockSettingsManager) Subscribe(fn func(port.ConfigChange)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Subscribe", fn)
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockSettingsManagerMockRecorder) Subscribe(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSettingsManager)(nil).Subscribe), fn)
+}
+
 // Unmarshal mocks base method.
 func (m *MockSettingsManager) Unmarshal(out interface{}) error {
 	m.ctrl.T.Helper()
@@ -101,3 +113,15 @@ func (mr *MockSettingsManagerMockRecorder) Unmarshal(out interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unmarshal", reflect.TypeOf((*MockSettingsManager)(nil).Unmarshal), out)
 }
+
+// WatchConfig mocks base method.
+func (m *MockSettingsManager) WatchConfig() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WatchConfig")
+}
+
+// WatchConfig indicates an expected call of WatchConfig.
+func (mr *MockSettingsManagerMockRecorder) WatchConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchConfig", reflect.TypeOf((*MockSettingsManager)(nil).WatchConfig))
+}
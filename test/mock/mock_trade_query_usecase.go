@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: D:/Practice/go-practice/points/internal/domain/trade_query_usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	query "points/internal/domain/query"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTradeQueryUsecase is a mock of TradeQueryUsecase interface.
+type MockTradeQueryUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeQueryUsecaseMockRecorder
+}
+
+// MockTradeQueryUsecaseMockRecorder is the mock recorder for MockTradeQueryUsecase.
+type MockTradeQueryUsecaseMockRecorder struct {
+	mock *MockTradeQueryUsecase
+}
+
+// NewMockTradeQueryUsecase creates a new mock instance.
+func NewMockTradeQueryUsecase(ctrl *gomock.Controller) *MockTradeQueryUsecase {
+	mock := &MockTradeQueryUsecase{ctrl: ctrl}
+	mock.recorder = &MockTradeQueryUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeQueryUsecase) EXPECT() *MockTradeQueryUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetTrade mocks base method.
+func (m *MockTradeQueryUsecase) GetTrade(ctx context.Context, req *query.GetTradeQuery) (*query.TradeDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrade", ctx, req)
+	ret0, _ := ret[0].(*query.TradeDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrade indicates an expected call of GetTrade.
+func (mr *MockTradeQueryUsecaseMockRecorder) GetTrade(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrade", reflect.TypeOf((*MockTradeQueryUsecase)(nil).GetTrade), ctx, req)
+}
+
+// ListAccountTrades mocks base method.
+func (m *MockTradeQueryUsecase) ListAccountTrades(ctx context.Context, req *query.ListAccountTradesQuery) (*query.TradePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccountTrades", ctx, req)
+	ret0, _ := ret[0].(*query.TradePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccountTrades indicates an expected call of ListAccountTrades.
+func (mr *MockTradeQueryUsecaseMockRecorder) ListAccountTrades(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccountTrades", reflect.TypeOf((*MockTradeQueryUsecase)(nil).ListAccountTrades), ctx, req)
+}
@@ -7,8 +7,10 @@ package mock
 import (
 	context "context"
 	entity "points/internal/domain/entity"
+	repository "points/internal/domain/repository"
 	valueobject "points/internal/domain/valueobject"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -64,6 +66,36 @@ func (mr *MockTradeRecordsRepositoryMockRecorder) CreateTradeRecord(ctx, trans i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTradeRecord", reflect.TypeOf((*MockTradeRecordsRepository)(nil).CreateTradeRecord), ctx, trans)
 }
 
+// GetConfirmedTradeRecordsWithoutEvent mocks base method.
+func (m *MockTradeRecordsRepository) GetConfirmedTradeRecordsWithoutEvent(ctx context.Context) ([]*entity.TradeRecords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfirmedTradeRecordsWithoutEvent", ctx)
+	ret0, _ := ret[0].([]*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfirmedTradeRecordsWithoutEvent indicates an expected call of GetConfirmedTradeRecordsWithoutEvent.
+func (mr *MockTradeRecordsRepositoryMockRecorder) GetConfirmedTradeRecordsWithoutEvent(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfirmedTradeRecordsWithoutEvent", reflect.TypeOf((*MockTradeRecordsRepository)(nil).GetConfirmedTradeRecordsWithoutEvent), ctx)
+}
+
+// GetExpiredTradeRecords mocks base method.
+func (m *MockTradeRecordsRepository) GetExpiredTradeRecords(ctx context.Context, deadline time.Time, limit int) ([]*entity.TradeRecords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredTradeRecords", ctx, deadline, limit)
+	ret0, _ := ret[0].([]*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredTradeRecords indicates an expected call of GetExpiredTradeRecords.
+func (mr *MockTradeRecordsRepositoryMockRecorder) GetExpiredTradeRecords(ctx, deadline, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredTradeRecords", reflect.TypeOf((*MockTradeRecordsRepository)(nil).GetExpiredTradeRecords), ctx, deadline, limit)
+}
+
 // GetTradeRecord mocks base method.
 func (m *MockTradeRecordsRepository) GetTradeRecord(ctx context.Context, nonce, from int64, status *valueobject.TccStatus) (*entity.TradeRecords, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +111,36 @@ func (mr *MockTradeRecordsRepositoryMockRecorder) GetTradeRecord(ctx, nonce, fro
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTradeRecord", reflect.TypeOf((*MockTradeRecordsRepository)(nil).GetTradeRecord), ctx, nonce, from, status)
 }
 
+// ListTradeRecords mocks base method.
+func (m *MockTradeRecordsRepository) ListTradeRecords(ctx context.Context, filter repository.TradeRecordFilter) ([]*entity.TradeRecords, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTradeRecords", ctx, filter)
+	ret0, _ := ret[0].([]*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTradeRecords indicates an expected call of ListTradeRecords.
+func (mr *MockTradeRecordsRepositoryMockRecorder) ListTradeRecords(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTradeRecords", reflect.TypeOf((*MockTradeRecordsRepository)(nil).ListTradeRecords), ctx, filter)
+}
+
+// SumPendingAmountsBySender mocks base method.
+func (m *MockTradeRecordsRepository) SumPendingAmountsBySender(ctx context.Context) (map[repository.AccountAsset]valueobject.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPendingAmountsBySender", ctx)
+	ret0, _ := ret[0].(map[repository.AccountAsset]valueobject.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPendingAmountsBySender indicates an expected call of SumPendingAmountsBySender.
+func (mr *MockTradeRecordsRepositoryMockRecorder) SumPendingAmountsBySender(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPendingAmountsBySender", reflect.TypeOf((*MockTradeRecordsRepository)(nil).SumPendingAmountsBySender), ctx)
+}
+
 // UpdateTradeRecord mocks base method.
 func (m *MockTradeRecordsRepository) UpdateTradeRecord(ctx context.Context, trans *entity.TradeRecords) error {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	command "points/internal/domain/command"
+	entity "points/internal/domain/entity"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,11 +37,12 @@ func (m *MockTradeUsecase) EXPECT() *MockTradeUsecaseMockRecorder {
 }
 
 // Cancel mocks base method.
-func (m *MockTradeUsecase) Cancel(ctx context.Context, req *command.CancelCommand) error {
+func (m *MockTradeUsecase) Cancel(ctx context.Context, req *command.CancelCommand) (*entity.TradeRecords, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Cancel", ctx, req)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Cancel indicates an expected call of Cancel.
@@ -50,11 +52,12 @@ func (mr *MockTradeUsecaseMockRecorder) Cancel(ctx, req interface{}) *gomock.Cal
 }
 
 // ManualConfirm mocks base method.
-func (m *MockTradeUsecase) ManualConfirm(ctx context.Context, req *command.ConfirmCommand) error {
+func (m *MockTradeUsecase) ManualConfirm(ctx context.Context, req *command.ConfirmCommand) (*entity.TradeRecords, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ManualConfirm", ctx, req)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ManualConfirm indicates an expected call of ManualConfirm.
@@ -64,11 +67,12 @@ func (mr *MockTradeUsecaseMockRecorder) ManualConfirm(ctx, req interface{}) *gom
 }
 
 // Transfer mocks base method.
-func (m *MockTradeUsecase) Transfer(ctx context.Context, req *command.TransferCommand) error {
+func (m *MockTradeUsecase) Transfer(ctx context.Context, req *command.TransferCommand) (*entity.TradeRecords, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Transfer", ctx, req)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(*entity.TradeRecords)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Transfer indicates an expected call of Transfer.
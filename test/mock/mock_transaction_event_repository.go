@@ -8,6 +8,7 @@ import (
 	context "context"
 	entity "points/internal/domain/entity"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -48,3 +49,47 @@ func (mr *MockTransactionEventRepositoryMockRecorder) CreateTransactionEvent(ctx
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransactionEvent", reflect.TypeOf((*MockTransactionEventRepository)(nil).CreateTransactionEvent), ctx, event)
 }
+
+// GetTransactionEvents mocks base method.
+func (m *MockTransactionEventRepository) GetTransactionEvents(ctx context.Context, transactionID string) ([]*entity.TransactionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionEvents", ctx, transactionID)
+	ret0, _ := ret[0].([]*entity.TransactionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionEvents indicates an expected call of GetTransactionEvents.
+func (mr *MockTransactionEventRepositoryMockRecorder) GetTransactionEvents(ctx, transactionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionEvents", reflect.TypeOf((*MockTransactionEventRepository)(nil).GetTransactionEvents), ctx, transactionID)
+}
+
+// GetUnpublishedTransactionEvents mocks base method.
+func (m *MockTransactionEventRepository) GetUnpublishedTransactionEvents(ctx context.Context, limit int) ([]*entity.TransactionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnpublishedTransactionEvents", ctx, limit)
+	ret0, _ := ret[0].([]*entity.TransactionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnpublishedTransactionEvents indicates an expected call of GetUnpublishedTransactionEvents.
+func (mr *MockTransactionEventRepositoryMockRecorder) GetUnpublishedTransactionEvents(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnpublishedTransactionEvents", reflect.TypeOf((*MockTransactionEventRepository)(nil).GetUnpublishedTransactionEvents), ctx, limit)
+}
+
+// MarkTransactionEventPublished mocks base method.
+func (m *MockTransactionEventRepository) MarkTransactionEventPublished(ctx context.Context, id int32, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTransactionEventPublished", ctx, id, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTransactionEventPublished indicates an expected call of MarkTransactionEventPublished.
+func (mr *MockTransactionEventRepositoryMockRecorder) MarkTransactionEventPublished(ctx, id, publishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTransactionEventPublished", reflect.TypeOf((*MockTransactionEventRepository)(nil).MarkTransactionEventPublished), ctx, id, publishedAt)
+}
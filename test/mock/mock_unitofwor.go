@@ -49,6 +49,48 @@ func (mr *MockUnitOfWorkMockRecorder) AccountRepository() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountRepository", reflect.TypeOf((*MockUnitOfWork)(nil).AccountRepository))
 }
 
+// LedgerRepository mocks base method.
+func (m *MockUnitOfWork) LedgerRepository() repository.LedgerRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LedgerRepository")
+	ret0, _ := ret[0].(repository.LedgerRepository)
+	return ret0
+}
+
+// LedgerRepository indicates an expected call of LedgerRepository.
+func (mr *MockUnitOfWorkMockRecorder) LedgerRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LedgerRepository", reflect.TypeOf((*MockUnitOfWork)(nil).LedgerRepository))
+}
+
+// PointLotRepository mocks base method.
+func (m *MockUnitOfWork) PointLotRepository() repository.PointLotRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PointLotRepository")
+	ret0, _ := ret[0].(repository.PointLotRepository)
+	return ret0
+}
+
+// PointLotRepository indicates an expected call of PointLotRepository.
+func (mr *MockUnitOfWorkMockRecorder) PointLotRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PointLotRepository", reflect.TypeOf((*MockUnitOfWork)(nil).PointLotRepository))
+}
+
+// SnapshotTransaction mocks base method.
+func (m *MockUnitOfWork) SnapshotTransaction(arg0 context.Context, arg1 func(repository.UnitOfWork) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotTransaction", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SnapshotTransaction indicates an expected call of SnapshotTransaction.
+func (mr *MockUnitOfWorkMockRecorder) SnapshotTransaction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotTransaction", reflect.TypeOf((*MockUnitOfWork)(nil).SnapshotTransaction), arg0, arg1)
+}
+
 // TradeRecordsRepository mocks base method.
 func (m *MockUnitOfWork) TradeRecordsRepository() repository.TradeRecordsRepository {
 	m.ctrl.T.Helper()
@@ -24,7 +24,7 @@ func NewDummyDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	assert.NoError(t, err, "failed to open in-memory sqlite database")
 
-	err = db.AutoMigrate(&model.Account{}, &model.TradeRecord{}, &model.TransactionEvent{})
+	err = db.AutoMigrate(&model.Account{}, &model.TradeRecord{}, &model.TransactionEvent{}, &model.LedgerEntry{}, &model.PointLot{}, &model.PointLotAllocation{})
 	assert.NoError(t, err, "failed to migrate database schema")
 	return db
 }
@@ -98,7 +98,7 @@ func NewTestContainerDB(t *testing.T) *gorm.DB {
 	sqlDB.SetMaxOpenConns(10)
 	sqlDB.SetMaxIdleConns(10)
 
-	err = db.AutoMigrate(&model.Account{}, &model.TradeRecord{}, &model.TransactionEvent{})
+	err = db.AutoMigrate(&model.Account{}, &model.TradeRecord{}, &model.TransactionEvent{}, &model.LedgerEntry{}, &model.PointLot{}, &model.PointLotAllocation{})
 	assert.NoError(t, err, "failed to migrate database schema")
 
 	err = db.Exec(`